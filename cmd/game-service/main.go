@@ -1,15 +1,22 @@
 package main
 
 import (
+	"crypto/rand"
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
+	"path/filepath"
 	"runtime"
 	"strconv"
+	"syscall"
 	"time"
 
 	"github.com/go-redis/redis"
+	"gitlab.com/pokesync/game-service/internal/game-logic/battles"
 	"gitlab.com/pokesync/game-service/internal/game-logic/commands"
+	"gitlab.com/pokesync/game-service/internal/game-logic/healing"
+	"gitlab.com/pokesync/game-service/internal/game-logic/pc"
 	"gitlab.com/pokesync/game-service/internal/game-service/account"
 	"gitlab.com/pokesync/game-service/internal/game-service/character"
 	"gitlab.com/pokesync/game-service/internal/game-service/chat"
@@ -20,6 +27,7 @@ import (
 	"gitlab.com/pokesync/game-service/internal/game-service/login"
 	"gitlab.com/pokesync/game-service/internal/game-service/server"
 	"gitlab.com/pokesync/game-service/internal/game-service/status"
+	"gitlab.com/pokesync/game-service/internal/game-service/token"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
@@ -47,6 +55,18 @@ const RedisHostEnv = "POKESYNC_REDIS_HOST"
 // the port of the Redis server to connect to.
 const RedisPortEnv = "POKESYNC_REDIS_PORT"
 
+// StorageEnv is the name of the environment variable of the kind of
+// storage to keep accounts and characters in.
+const StorageEnv = "POKESYNC_STORAGE"
+
+// DataDirectoryEnv is the name of the environment variable of the
+// directory to keep accounts and characters in when using FileStorage.
+const DataDirectoryEnv = "POKESYNC_DATA_DIRECTORY"
+
+// TokenSecretEnv is the name of the environment variable of the
+// secret to sign session tokens with.
+const TokenSecretEnv = "POKESYNC_TOKEN_SECRET"
+
 // DefaultWorldID is the id of the game world to fallback to if no environment
 // variable is set.
 const DefaultWorldID = 1
@@ -67,15 +87,41 @@ const DefaultRedisHost = "localhost"
 // variable is set.
 const DefaultRedisPort = 6379
 
+// DefaultDataDirectory is the directory to fallback to if no environment
+// variable is set.
+const DefaultDataDirectory = "data"
+
+// These are the kinds of storage the server can be configured to keep
+// accounts and characters in.
+const (
+	// RedisStorage caches characters in a Redis instance.
+	RedisStorage = "redis"
+
+	// FileStorage keeps accounts and characters in files in a local
+	// directory, so that no external services are required. Meant
+	// for local development.
+	FileStorage = "file"
+)
+
+// ShutdownCountdown is the amount of time online players are given to
+// wrap up, after the server was told to shut down.
+const ShutdownCountdown = 10 * time.Second
+
+// ShutdownTimeout is the maximum amount of time the server is given to
+// shut down gracefully, including the ShutdownCountdown.
+const ShutdownTimeout = 1 * time.Minute
+
 // loginCodec is a message Codec that holds marshallers and demarshallers
 // specific for the login aspect of the server.
 var loginCodec = client.NewCodec().
 	Include(login.RequestConfig).
+	Include(login.ResumeSessionConfig).
 	Include(login.RequestTimedOutConfig).
 	Include(login.ErrorDuringAccountFetchConfig).
 	Include(login.AccountDisabledConfig).
 	Include(login.AlreadyLoggedInConfig).
-	Include(login.InvalidCredentialsConfig)
+	Include(login.InvalidCredentialsConfig).
+	Include(login.InvalidEmailConfig)
 
 // chatCodec is a message Codec that holds marshallers and demarshallers
 // specific for the public chatting aspect of the server.
@@ -91,6 +137,7 @@ var gameCodec = client.NewCodec().
 	Include(gameTransport.UnableToFetchProfileConfig).
 	Include(gameTransport.LoginSuccessConfig).
 	Include(gameTransport.RefreshMapConfig).
+	Include(gameTransport.TeleportAvatarConfig).
 	Include(gameTransport.MoveAvatarConfig).
 	Include(gameTransport.MoveCameraConfig).
 	Include(gameTransport.ResetCameraConfig).
@@ -105,12 +152,48 @@ var gameCodec = client.NewCodec().
 	Include(gameTransport.EntityUpdateConfig).
 	Include(gameTransport.InteractWithEntityConfig).
 	Include(gameTransport.SubmitChatCommandConfig).
+	Include(gameTransport.CharacterListConfig).
+	Include(gameTransport.LoginQueuePositionConfig).
+	Include(gameTransport.CreateCharacterConfig).
+	Include(gameTransport.RejectCharacterCreationConfig).
 	Include(gameTransport.SelectCharacterConfig).
 	Include(gameTransport.SetDonatorPointsConfig).
 	Include(gameTransport.SetPokeDollarsConfig).
 	Include(gameTransport.SetPartySlotConfig).
 	Include(gameTransport.SelectPlayerOptionConfig).
-	Include(gameTransport.SetServerTimeConfig)
+	Include(gameTransport.SetServerTimeConfig).
+	Include(gameTransport.ChooseBattleMoveConfig).
+	Include(gameTransport.SwitchBattleMonsterConfig).
+	Include(gameTransport.UseBattleItemConfig).
+	Include(gameTransport.FleeBattleConfig).
+	Include(gameTransport.StartBattleConfig).
+	Include(gameTransport.BattleTurnResultConfig).
+	Include(gameTransport.EndBattleConfig).
+	Include(gameTransport.AnswerEvolutionConfig).
+	Include(gameTransport.OfferEvolutionConfig).
+	Include(gameTransport.AnswerMoveConfig).
+	Include(gameTransport.OfferMoveConfig).
+	Include(gameTransport.BrowsePCBoxConfig).
+	Include(gameTransport.DepositPCMonsterConfig).
+	Include(gameTransport.WithdrawPCMonsterConfig).
+	Include(gameTransport.MovePCMonsterConfig).
+	Include(gameTransport.ReleasePCMonsterConfig).
+	Include(gameTransport.ClosePCConfig).
+	Include(gameTransport.PCBoxContentsConfig).
+	Include(gameTransport.SetPCSlotConfig).
+	Include(gameTransport.OfferTradeMonsterConfig).
+	Include(gameTransport.RetractTradeOfferConfig).
+	Include(gameTransport.OfferTradeDollarsConfig).
+	Include(gameTransport.AcceptTradeConfig).
+	Include(gameTransport.DeclineTradeConfig).
+	Include(gameTransport.TradeRequestConfig).
+	Include(gameTransport.OpenTradeConfig).
+	Include(gameTransport.SetTradeOfferConfig).
+	Include(gameTransport.SetTradeStageConfig).
+	Include(gameTransport.CloseTradeConfig).
+	Include(gameTransport.MoveInventoryItemConfig).
+	Include(gameTransport.UseItemConfig).
+	Include(gameTransport.SetInventorySlotConfig)
 
 // messageCodec holds demarshallers and marshallers of messages.
 var messageCodec = client.NewCodec().
@@ -132,22 +215,53 @@ func main() {
 	tcpHost := getTCPServerHostFromEnv()
 	tcpPort := getTCPServerPortFromEnv()
 
-	redisHost := getRedisHostFromEnv()
-	redisPort := getRedisPortFromEnv()
+	characterCacheConfig := character.CacheConfig{
+		ExpireAfter: 5 * time.Minute,
+	}
 
-	redisClient, err := connectToRedis(redisHost, redisPort)
-	if err != nil {
-		log.Fatal("Failed to connect to a Redis server instance", err)
+	var stores *storage
+	switch kind := getStorageFromEnv(); kind {
+	case RedisStorage:
+		redisHost := getRedisHostFromEnv()
+		redisPort := getRedisPortFromEnv()
+
+		redisClient, err := connectToRedis(redisHost, redisPort)
+		if err != nil {
+			log.Fatal("Failed to connect to a Redis server instance", err)
+		}
+
+		logger.Infof("Connected to Redis instance at %v:%v", redisHost, redisPort)
+
+		stores = createRedisStorage(redisClient, worldID, characterCacheConfig)
+
+	case FileStorage:
+		dataDirectory := getDataDirectoryFromEnv()
+
+		stores, err = createFileStorage(dataDirectory, characterCacheConfig)
+		if err != nil {
+			logger.Fatal(err)
+		}
+
+		logger.Info("Storing accounts and characters in ", dataDirectory)
+
+	default:
+		logger.Fatalf("Unknown storage %q, expected either %q or %q", kind, RedisStorage, FileStorage)
 	}
 
-	logger.Infof("Connected to Redis instance at %v:%v", redisHost, redisPort)
+	tokenSecret, err := getTokenSecretFromEnv()
+	if err != nil {
+		logger.Fatal(err)
+	}
 
 	assetsConfig := game.AssetConfig{
-		ItemDirectory:    "assets/config/item",
-		NpcDirectory:     "assets/config/npc",
-		MonsterDirectory: "assets/config/monster",
-		ObjectDirectory:  "assets/config/object",
-		WorldDirectory:   "assets/config/world",
+		ItemDirectory:      "assets/config/item",
+		NpcDirectory:       "assets/config/npc",
+		MonsterDirectory:   "assets/config/monster",
+		MoveDirectory:      "assets/config/move",
+		TypeDirectory:      "assets/config/type",
+		EncounterDirectory: "assets/config/encounter",
+		ObjectDirectory:    "assets/config/object",
+		WorldDirectory:     "assets/config/world",
 	}
 
 	assetBundle, err := game.LoadAssetBundle(assetsConfig)
@@ -155,6 +269,22 @@ func main() {
 		logger.Fatal(err)
 	}
 
+	nameRules, err := character.NewNameRules(character.NameRulesConfig{
+		MinLength: 3,
+		MaxLength: 12,
+
+		AllowDigits: true,
+		AllowSpaces: true,
+
+		ReservedWords: []string{"admin", "moderator", "staff", "pokesync", "system"},
+		StaffFile:     "assets/config/name/staff.txt",
+		DenylistFile:  "assets/config/name/denylist.txt",
+	})
+
+	if err != nil {
+		logger.Fatal(err)
+	}
+
 	routingConfig := client.RouterConfig{
 		PublicationTimeout: 1 * time.Second,
 	}
@@ -165,18 +295,38 @@ func main() {
 		WorkerCount: runtime.NumCPU(),
 	}
 
-	characterCacheConfig := character.CacheConfig{
-		ExpireAfter: 5 * time.Minute,
-	}
-
 	charactersConfig := character.Config{
 		WorkerCount: runtime.NumCPU(),
+
+		FlushInterval:  5 * time.Second,
+		FlushBatchSize: 128,
+		RetryBackoff:   1 * time.Second,
 	}
 
+	tokenConfig := token.Config{
+		Secret:      tokenSecret,
+		ExpireAfter: 24 * time.Hour,
+	}
+
+	tokenIssuer := token.NewIssuer(tokenConfig, stores.tokenStore)
+
 	gameConfig := game.Config{
 		IntervalRate:          50 * time.Millisecond,
 		CharacterFetchTimeout: 5 * time.Second,
+		CharacterLimit:        3,
 		EntityLimit:           32768,
+		MaxPlayers:            2000,
+		StaffOverflow:         50,
+
+		NameRules: nameRules,
+		StartPosition: game.Position{
+			MapX:   0,
+			MapZ:   2,
+			LocalX: 60,
+			LocalZ: 40,
+		},
+
+		TokenIssuer: tokenIssuer,
 
 		// not to be confused with IntervalRate, ClockRate is the rate at
 		// which the game time progresses (think day/night, seasons) and
@@ -185,13 +335,29 @@ func main() {
 		ClockRate:         250 * time.Millisecond,
 		ClockSynchronizer: game.NewGMT0Synchronizer(),
 
+		ShinyOdds: game.DefaultShinyOdds,
+
 		SessionConfig: game.SessionConfig{
 			CommandLimit: 16,
 			EventLimit:   256,
 		},
 
+		// the amount of time a player is kept in the world after its
+		// connection dropped, for the user to be able to reconnect
+		LingerDuration: 1 * time.Minute,
+
+		AutosaveInterval: 5 * time.Minute,
+
+		// users that select a character whilst the world is full are
+		// queued up and informed of their position every few seconds
+		LoginQueueLimit:      512,
+		LoginQueueUpdateRate: 5 * time.Second,
+
 		Modules: []game.Module{
 			commands.Module,
+			battles.Module,
+			pc.Module,
+			healing.Module,
 		},
 	}
 
@@ -226,14 +392,11 @@ func main() {
 		ClientConfig: clientConfig,
 	}
 
-	accountRepository := account.NewInMemoryRepository()
 	passwordMatcher := account.BasicPasswordMatcher()
 
-	characterCache := character.NewRedisCache(characterCacheConfig, redisClient)
-	characterRepository := character.NewInMemoryRepository()
-	characterService := character.NewService(charactersConfig, logger, characterCache, characterRepository)
+	characterService := character.NewService(charactersConfig, logger, stores.characterCache, stores.characterRepository)
 
-	accountService := account.NewService(accountConfig, logger, accountRepository)
+	accountService := account.NewService(accountConfig, logger, stores.accountRepository)
 	chatService := chat.NewService(chatConfig, logger, routing)
 
 	authenticator := login.NewAuthenticator(
@@ -242,22 +405,15 @@ func main() {
 		passwordMatcher,
 	)
 
-	loginService := login.NewService(loginConfig, logger, authenticator, routing)
+	loginService := login.NewService(loginConfig, logger, authenticator, tokenIssuer, routing)
 
-	gameService := game.NewService(gameConfig, routing, characterService.LoadProfile, characterService.SaveProfile, assetBundle, logger)
-	discordService := discord.NewService(discordConfig, logger)
-	statusService := status.NewService(statusConfig, logger, status.NewRedisNotifier(redisClient, worldID), status.NewProvider(gameService))
+	// accounts of banned users are disabled through the account service,
+	// which only exists once its storage has been set up
+	gameConfig.DisableAccount = accountService.DisableAccount
 
-	// should something go wrong and cause a panic, always safely
-	// tear down these services
-	defer func() {
-		accountService.Stop()
-		chatService.Stop()
-		loginService.Stop()
-		discordService.Stop()
-		gameService.Stop()
-		statusService.Stop()
-	}()
+	gameService := game.NewService(gameConfig, routing, characterService.LoadProfiles, characterService.CreateProfile, characterService.SaveProfile, assetBundle, logger)
+	discordService := discord.NewService(discordConfig, logger)
+	statusService := status.NewService(statusConfig, logger, stores.statusNotifier, status.NewProvider(gameService))
 
 	logger.Info("Client build: ", ClientBuildNo)
 	logger.Info("World ID: ", worldID)
@@ -265,6 +421,9 @@ func main() {
 	logger.Info("Item configs loaded: ", assetBundle.Items.Count())
 	logger.Info("Npc configs loaded: ", assetBundle.Npcs.Count())
 	logger.Info("Monster configs loaded: ", assetBundle.Monsters.Count())
+	logger.Info("Move configs loaded: ", assetBundle.Moves.Count())
+	logger.Info("Types loaded: ", len(assetBundle.Types.Types()))
+	logger.Info("Encounter tables loaded: ", assetBundle.Encounters.Count())
 
 	logger.Info("Game pulse rate: ", gameConfig.IntervalRate)
 	logger.Info("Game clock rate: ", gameConfig.ClockRate)
@@ -278,23 +437,130 @@ func main() {
 
 	logger.Info("Account fetch timeout: ", authConfig.AccountFetchTimeout)
 	logger.Info("Character fetch timeout: ", gameConfig.CharacterFetchTimeout)
+	logger.Info("Characters per account: ", gameConfig.CharacterLimit)
+	logger.Info("Player capacity: ", gameConfig.MaxPlayers)
+	logger.Info("Staff overflow capacity: ", gameConfig.StaffOverflow)
 
 	logger.Info("Upstream byte limit: ", clientConfig.ReadBufferSize)
 	logger.Info("Downstream byte limit: ", clientConfig.WriteBufferSize)
 
 	logger.Info("Game Session command limit: ", gameConfig.SessionConfig.CommandLimit)
 	logger.Info("Game Session event limit: ", gameConfig.SessionConfig.EventLimit)
+	logger.Info("Game Session linger duration: ", gameConfig.LingerDuration)
+	logger.Info("Autosave interval: ", gameConfig.AutosaveInterval)
 
 	logger.Info("Server status update rate: ", statusConfig.RefreshRate)
 
 	logger.Info("Router publication timeout: ", routingConfig.PublicationTimeout)
 
+	logger.Info("Shutdown countdown: ", ShutdownCountdown)
+	logger.Info("Shutdown timeout: ", ShutdownTimeout)
+
 	tcpListener := server.NewTCPListener(serverConfig, routing, logger)
-	if err := tcpListener.Bind(tcpHost, tcpPort); err != nil {
+
+	bindErrors := make(chan error, 1)
+	go func() {
+		bindErrors <- tcpListener.Bind(tcpHost, tcpPort)
+	}()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+
+	select {
+	case err := <-bindErrors:
 		logger.Fatal(err)
+
+	case sig := <-signals:
+		logger.Info("Received ", sig, ", shutting down ...")
+	}
+
+	shutdown := func() {
+		if err := tcpListener.Unbind(); err != nil {
+			logger.Error(err)
+		}
+
+		gameService.Countdown(ShutdownCountdown)
+		gameService.Stop()
+
+		// waits for every saved character to be flushed
+		characterService.Stop()
+		logger.Info("Character service metrics: ", characterService.Metrics())
+
+		routing.Close()
+
+		accountService.Stop()
+		chatService.Stop()
+		loginService.Stop()
+		discordService.Stop()
+		statusService.Stop()
+	}
+
+	done := make(chan bool)
+	go func() {
+		shutdown()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		logger.Info("PokeSync has shut down")
+
+	case <-time.After(ShutdownTimeout):
+		logger.Error("Timed out whilst shutting down after ", ShutdownTimeout)
+
+	case sig := <-signals:
+		logger.Error("Received ", sig, " whilst shutting down, shutting down forcefully")
 	}
 }
 
+// storage holds the stores the server keeps its state in.
+type storage struct {
+	accountRepository   account.Repository
+	characterCache      character.Cache
+	characterRepository character.Repository
+	tokenStore          token.Store
+	statusNotifier      status.Notifier
+}
+
+// createRedisStorage creates the stores to use when characters are cached
+// in the given Redis instance.
+func createRedisStorage(redisClient *redis.Client, worldID int, cacheConfig character.CacheConfig) *storage {
+	return &storage{
+		accountRepository:   account.NewInMemoryRepository(),
+		characterCache:      character.NewRedisCache(cacheConfig, redisClient),
+		characterRepository: character.NewInMemoryRepository(),
+		tokenStore:          token.NewRedisStore(redisClient),
+		statusNotifier:      status.NewRedisNotifier(redisClient, worldID),
+	}
+}
+
+// createFileStorage creates the stores to use when accounts and characters
+// are kept in files in the given directory.
+func createFileStorage(directory string, cacheConfig character.CacheConfig) (*storage, error) {
+	accountRepository, err := account.NewFileRepository(filepath.Join(directory, "accounts"))
+	if err != nil {
+		return nil, err
+	}
+
+	characterCache, err := character.NewFileCache(cacheConfig, filepath.Join(directory, "cache"))
+	if err != nil {
+		return nil, err
+	}
+
+	characterRepository, err := character.NewFileRepository(filepath.Join(directory, "characters"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &storage{
+		accountRepository:   accountRepository,
+		characterCache:      characterCache,
+		characterRepository: characterRepository,
+		tokenStore:          token.NewInMemoryStore(),
+		statusNotifier:      status.NewVoidNotifier(),
+	}, nil
+}
+
 func connectToRedis(host string, port int) (*redis.Client, error) {
 	client := redis.NewClient(&redis.Options{
 		Addr:     fmt.Sprint(host, ":", port),
@@ -334,6 +600,40 @@ func getWorldIDFromEnv() int {
 	return port
 }
 
+func getStorageFromEnv() string {
+	kind := os.Getenv(StorageEnv)
+	if len(kind) == 0 {
+		return RedisStorage
+	}
+
+	return kind
+}
+
+func getDataDirectoryFromEnv() string {
+	directory := os.Getenv(DataDirectoryEnv)
+	if len(directory) == 0 {
+		return DefaultDataDirectory
+	}
+
+	return directory
+}
+
+func getTokenSecretFromEnv() ([]byte, error) {
+	secret := os.Getenv(TokenSecretEnv)
+	if len(secret) > 0 {
+		return []byte(secret), nil
+	}
+
+	// without a configured secret, session tokens issued by this
+	// process can not be resumed with after a restart
+	generated := make([]byte, 32)
+	if _, err := rand.Read(generated); err != nil {
+		return nil, err
+	}
+
+	return generated, nil
+}
+
 func getRedisHostFromEnv() string {
 	host := os.Getenv(RedisHostEnv)
 	if len(host) == 0 {
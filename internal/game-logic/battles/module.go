@@ -0,0 +1,20 @@
+package battles
+
+import (
+	"time"
+
+	"gitlab.com/pokesync/game-service/internal/game-service/game"
+	"gitlab.com/pokesync/game-service/internal/game-service/game/battle"
+)
+
+// Module is an externally defined module that starts battles.
+func Module(dk *game.DependencyKit) {
+	dk.OnWildEncounter(startWildBattle)
+}
+
+func startWildBattle(dk *game.DependencyKit, plr *game.Player, wild *game.Monster) error {
+	config := battle.Config{Monsters: dk.Monsters(), Moves: dk.Moves(), Items: dk.Items(), TypeChart: dk.TypeChart(), Evolutions: dk.Evolutions(), Respawn: dk.Respawn}
+
+	_, err := battle.StartWild(config, plr, wild.MonsterData, time.Now().UnixNano(), dk.GiveMonster)
+	return err
+}
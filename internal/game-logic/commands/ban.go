@@ -0,0 +1,29 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"gitlab.com/pokesync/game-service/internal/game-service/character"
+	"gitlab.com/pokesync/game-service/internal/game-service/game"
+)
+
+func banPlayer(dk *game.DependencyKit, plr *game.Player, arguments []string) error {
+	if !plr.Rank().IsStaff() || len(arguments) < 1 {
+		return nil
+	}
+
+	displayName := character.DisplayName(strings.Join(arguments, " "))
+
+	target := dk.PlayerByName(displayName)
+	if target == nil {
+		return fmt.Errorf("there is no player named %v", displayName)
+	}
+
+	if target.Rank().IsStaff() {
+		return fmt.Errorf("staff member %v cannot be banned", displayName)
+	}
+
+	dk.Ban(target)
+	return nil
+}
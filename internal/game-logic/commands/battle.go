@@ -0,0 +1,45 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"gitlab.com/pokesync/game-service/internal/game-service/game"
+	"gitlab.com/pokesync/game-service/internal/game-service/game/battle"
+)
+
+func startWildBattle(dk *game.DependencyKit, plr *game.Player, arguments []string) error {
+	if len(arguments) < 1 {
+		return nil
+	}
+
+	modelID, err := strconv.Atoi(arguments[0])
+	if err != nil {
+		return err
+	}
+
+	level := defaultPartyLevel
+	if len(arguments) > 1 {
+		level, err = strconv.Atoi(arguments[1])
+		if err != nil {
+			return err
+		}
+	}
+
+	if level < game.MinLevel || level > game.MaxLevel {
+		return fmt.Errorf("level must be between %v and %v", game.MinLevel, game.MaxLevel)
+	}
+
+	descriptor := dk.MonsterDescriptor(game.ModelID(modelID))
+	if descriptor == nil {
+		return fmt.Errorf("there is no monster of id %v", modelID)
+	}
+
+	wild := dk.GenerateMonster(descriptor, level)
+
+	config := battle.Config{Monsters: dk.Monsters(), Moves: dk.Moves(), Items: dk.Items(), TypeChart: dk.TypeChart(), Evolutions: dk.Evolutions(), Respawn: dk.Respawn}
+	_, err = battle.StartWild(config, plr, &wild, time.Now().UnixNano(), dk.GiveMonster)
+
+	return err
+}
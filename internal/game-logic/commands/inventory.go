@@ -0,0 +1,28 @@
+package commands
+
+import (
+	"strconv"
+
+	"gitlab.com/pokesync/game-service/internal/game-service/game"
+)
+
+func addToInventory(dk *game.DependencyKit, plr *game.Player, arguments []string) error {
+	if len(arguments) < 1 {
+		return nil
+	}
+
+	itemID, err := strconv.Atoi(arguments[0])
+	if err != nil {
+		return err
+	}
+
+	quantity := 1
+	if len(arguments) > 1 {
+		quantity, err = strconv.Atoi(arguments[1])
+		if err != nil {
+			return err
+		}
+	}
+
+	return plr.Inventory().Add(itemID, quantity)
+}
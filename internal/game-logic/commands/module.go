@@ -9,4 +9,7 @@ func Module(dk *game.DependencyKit) {
 	dk.OnCommand("pos", showPosition)
 	dk.OnCommand("addparty", addToParty)
 	dk.OnCommand("clearparty", clearParty)
+	dk.OnCommand("additem", addToInventory)
+	dk.OnCommand("battle", startWildBattle)
+	dk.OnCommand("ban", banPlayer)
 }
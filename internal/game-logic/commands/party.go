@@ -1,10 +1,16 @@
 package commands
 
 import (
-	"gitlab.com/pokesync/game-service/internal/game-service/game"
+	"fmt"
 	"strconv"
+
+	"gitlab.com/pokesync/game-service/internal/game-service/game"
 )
 
+// defaultPartyLevel is the level of monsters that are added to the party
+// without specifying a level.
+const defaultPartyLevel = 5
+
 func addToParty(dk *game.DependencyKit, plr *game.Player, arguments []string) error {
 	if len(arguments) < 1 {
 		return nil
@@ -15,12 +21,27 @@ func addToParty(dk *game.DependencyKit, plr *game.Player, arguments []string) er
 		return err
 	}
 
-	plr.PartyBelt().Add(dk.CreateMonster(plr.Position(), game.MonsterData{
-		ModelID:         game.ModelID(modelID),
-		Gender:          game.Man,
-		StatusCondition: game.Healthy,
-		Coloration:      game.RegularColour,
-	}))
+	level := defaultPartyLevel
+	if len(arguments) > 1 {
+		level, err = strconv.Atoi(arguments[1])
+		if err != nil {
+			return err
+		}
+	}
+
+	if level < game.MinLevel || level > game.MaxLevel {
+		return fmt.Errorf("level must be between %v and %v", game.MinLevel, game.MaxLevel)
+	}
+
+	descriptor := dk.MonsterDescriptor(game.ModelID(modelID))
+	if descriptor == nil {
+		return fmt.Errorf("there is no monster of id %v", modelID)
+	}
+
+	monster := dk.GenerateMonster(descriptor, level)
+	monster.OriginalTrainer = plr.DisplayName()
+
+	plr.PartyBelt().Add(dk.CreateMonster(plr.Position(), monster))
 
 	return nil
 }
@@ -0,0 +1,22 @@
+package healing
+
+import (
+	"gitlab.com/pokesync/game-service/internal/game-service/game"
+	"gitlab.com/pokesync/game-service/internal/game-service/game/entity"
+)
+
+// healingMachineObject is the ModelID of the healing machine objects that
+// heal the party of the players that use them.
+const healingMachineObject game.ModelID = 5
+
+// Module is an externally defined module that heals the party of players
+// at the healing points in the world, which become the points they
+// respawn at.
+func Module(dk *game.DependencyKit) {
+	dk.OnObjectInteraction(healingMachineObject, heal)
+}
+
+func heal(dk *game.DependencyKit, plr *game.Player, healingPoint *entity.Entity) error {
+	dk.VisitHealingPoint(plr)
+	return nil
+}
@@ -0,0 +1,21 @@
+package pc
+
+import (
+	"gitlab.com/pokesync/game-service/internal/game-service/game"
+	"gitlab.com/pokesync/game-service/internal/game-service/game/entity"
+)
+
+// pcObject is the ModelID of the PC objects through which players access
+// the boxes of their PC.
+const pcObject game.ModelID = 4
+
+// Module is an externally defined module that gives players access to
+// their PC through the PC objects in the world.
+func Module(dk *game.DependencyKit) {
+	dk.OnObjectInteraction(pcObject, openPC)
+}
+
+func openPC(dk *game.DependencyKit, plr *game.Player, terminal *entity.Entity) error {
+	plr.OpenPC(terminal)
+	return nil
+}
@@ -1,10 +1,41 @@
 package account
 
-import "golang.org/x/crypto/bcrypt"
+import (
+	"net/mail"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// These are the statuses an Email can be validated with. An Email
+// is only valid if it is given the ValidEmail status.
+const (
+	ValidEmail       EmailStatus = 0
+	EmailTooLong     EmailStatus = 1
+	MalformedEmail   EmailStatus = 2
+	LocalPartTooLong EmailStatus = 3
+	MalformedDomain  EmailStatus = 4
+)
+
+const (
+	// MaxEmailLength is the maximum length of an e-mail address.
+	MaxEmailLength = 254
+
+	// MaxLocalPartLength is the maximum length of the part of an e-mail
+	// address that precedes the @ sign.
+	MaxLocalPartLength = 64
+
+	// MaxDomainLabelLength is the maximum length of each of the dot
+	// separated labels of the domain of an e-mail address.
+	MaxDomainLabelLength = 63
+)
 
 // Email represents an e-mail address.
 type Email string
 
+// EmailStatus is the outcome of validating an Email.
+type EmailStatus int
+
 // Password is a secret value that is associated with an account and grants
 // access to it and should therefore only be known by its owner.
 type Password string
@@ -12,16 +43,84 @@ type Password string
 // PasswordMatcher searches for equality between two given Password's.
 type PasswordMatcher func(p1, p2 Password) (bool, error)
 
-// Account represents a user.
+// Account represents a user. A Disabled account, such as that of a banned
+// user, can no longer be logged into.
 type Account struct {
 	Email    Email
 	Password Password
+	Disabled bool
 }
 
 // Validate validates the Email string value. Returns whether
 // the e-mail is a valid one or not.
 func (email Email) Validate() bool {
-	return true
+	return email.Status() == ValidEmail
+}
+
+// Status validates the Email string value, returning the EmailStatus
+// that describes why the e-mail is or isn't a valid one. Only plain
+// addresses in the form of local@domain.tld are considered valid.
+func (email Email) Status() EmailStatus {
+	value := string(email)
+	if len(value) > MaxEmailLength {
+		return EmailTooLong
+	}
+
+	address, err := mail.ParseAddress(value)
+	if err != nil || address.Name != "" || address.Address != value {
+		return MalformedEmail
+	}
+
+	at := strings.LastIndex(value, "@")
+
+	localPart := value[:at]
+	domain := value[at+1:]
+
+	if len(localPart) > MaxLocalPartLength {
+		return LocalPartTooLong
+	}
+
+	if !isValidDomain(domain) {
+		return MalformedDomain
+	}
+
+	return ValidEmail
+}
+
+// isValidDomain returns whether the given domain name of an e-mail address
+// consists of at least two valid labels.
+func isValidDomain(domain string) bool {
+	labels := strings.Split(domain, ".")
+	if len(labels) < 2 {
+		return false
+	}
+
+	for _, label := range labels {
+		if len(label) == 0 || len(label) > MaxDomainLabelLength {
+			return false
+		}
+
+		if label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+
+		for _, r := range label {
+			if !(r >= 'a' && r <= 'z') && !(r >= 'A' && r <= 'Z') && !(r >= '0' && r <= '9') && r != '-' {
+				return false
+			}
+		}
+	}
+
+	// a top-level domain is never entirely numeric, which rules out
+	// addresses with a raw IP address as its domain
+	tld := labels[len(labels)-1]
+	for _, r := range tld {
+		if r < '0' || r > '9' {
+			return true
+		}
+	}
+
+	return false
 }
 
 // BasicPasswordMatcher is a PasswordMatcher that performs a simple
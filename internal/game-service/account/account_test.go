@@ -0,0 +1,45 @@
+package account
+
+import "testing"
+
+func TestEmail_Status(t *testing.T) {
+	emails := map[Email]EmailStatus{
+		"sino@gmail.com":             ValidEmail,
+		"sino.tje+pokesync@gmail.nl": ValidEmail,
+		"sino@mail.co.uk":            ValidEmail,
+		"":                           MalformedEmail,
+		"sino":                       MalformedEmail,
+		"sino@":                      MalformedEmail,
+		"Sino <sino@gmail.com>":      MalformedEmail,
+		"sino@gmail":                 MalformedDomain,
+		"sino@127.0.0.1":             MalformedDomain,
+		"sino@-gmail.com":            MalformedDomain,
+		"sino@gmail..com":            MalformedEmail,
+	}
+
+	for email, expected := range emails {
+		if status := email.Status(); status != expected {
+			t.Errorf("expected status of %q to equal %v but was %v instead", email, expected, status)
+		}
+	}
+}
+
+func TestEmail_StatusTooLong(t *testing.T) {
+	localPart := make([]byte, 65)
+	for i := range localPart {
+		localPart[i] = 'a'
+	}
+
+	if status := Email(string(localPart) + "@gmail.com").Status(); status != LocalPartTooLong {
+		t.Errorf("expected status to equal %v but was %v instead", LocalPartTooLong, status)
+	}
+
+	domain := make([]byte, 250)
+	for i := range domain {
+		domain[i] = 'a'
+	}
+
+	if status := Email("sino@" + string(domain) + ".com").Status(); status != EmailTooLong {
+		t.Errorf("expected status to equal %v but was %v instead", EmailTooLong, status)
+	}
+}
@@ -0,0 +1,122 @@
+package account
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// FileRepository is an account Repository that stores every Account
+// as a JSON file in a local directory. It is meant for local development,
+// as accounts that are unknown to the FileRepository are registered under
+// the Password they are first logged into with.
+type FileRepository struct {
+	directory string
+	mutex     *sync.Mutex
+}
+
+// accountFile is the JSON document an Account is stored as.
+type accountFile struct {
+	Email    Email    `json:"email"`
+	Password Password `json:"password"`
+	Disabled bool     `json:"disabled,omitempty"`
+}
+
+// NewFileRepository constructs a new instance of a FileRepository that
+// stores its accounts in the specified directory, which is created if it
+// does not yet exist.
+func NewFileRepository(directory string) (*FileRepository, error) {
+	if err := os.MkdirAll(directory, 0700); err != nil {
+		return nil, err
+	}
+
+	return &FileRepository{directory: directory, mutex: &sync.Mutex{}}, nil
+}
+
+// Get looks up the Account that is registered under the specified Email,
+// registering a new Account under the given Password if there is none yet.
+func (repo *FileRepository) Get(email Email, password Password) (*Account, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	account, err := repo.read(email)
+	if os.IsNotExist(err) {
+		account := Account{Email: email, Password: password}
+		if err := repo.write(account); err != nil {
+			return nil, err
+		}
+
+		return &account, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+// Put puts the given Account under the specified Email into the Repository.
+func (repo *FileRepository) Put(email Email, account Account) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	account.Email = email
+	return repo.write(account)
+}
+
+// Disable disables the Account that is registered under the specified
+// Email, for it to no longer be logged into.
+func (repo *FileRepository) Disable(email Email) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	account, err := repo.read(email)
+	if err != nil {
+		return err
+	}
+
+	account.Disabled = true
+	return repo.write(*account)
+}
+
+// read reads the Account that is registered under the specified Email
+// from its file.
+func (repo *FileRepository) read(email Email) (*Account, error) {
+	fileBytes, err := ioutil.ReadFile(repo.pathOf(email))
+	if err != nil {
+		return nil, err
+	}
+
+	var file accountFile
+	if err := json.Unmarshal(fileBytes, &file); err != nil {
+		return nil, err
+	}
+
+	return &Account{Email: file.Email, Password: file.Password, Disabled: file.Disabled}, nil
+}
+
+// write writes the given Account to its file, replacing the file as a
+// whole so that a crash never leaves a partially written Account behind.
+func (repo *FileRepository) write(account Account) error {
+	fileBytes, err := json.MarshalIndent(accountFile{Email: account.Email, Password: account.Password, Disabled: account.Disabled}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	path := repo.pathOf(account.Email)
+	if err := ioutil.WriteFile(path+".tmp", fileBytes, 0600); err != nil {
+		return err
+	}
+
+	return os.Rename(path+".tmp", path)
+}
+
+// pathOf returns the path to the file of the Account that is registered
+// under the specified Email.
+func (repo *FileRepository) pathOf(email Email) string {
+	return filepath.Join(repo.directory, url.PathEscape(string(email))+".json")
+}
@@ -0,0 +1,57 @@
+package account
+
+import "testing"
+
+func TestFileRepository_RegistersUnknownAccounts(t *testing.T) {
+	directory := t.TempDir()
+
+	repo, err := NewFileRepository(directory)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repo.Get(Email("sino@gmail.com"), Password("hello123"))
+
+	restarted, err := NewFileRepository(directory)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	account, err := restarted.Get(Email("sino@gmail.com"), Password("guessing"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if account.Password != "hello123" {
+		t.Errorf("expected password %v but was %v instead", "hello123", account.Password)
+	}
+}
+
+func TestFileRepository_DisablesAccounts(t *testing.T) {
+	directory := t.TempDir()
+
+	repo, err := NewFileRepository(directory)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repo.Get(Email("sino@gmail.com"), Password("hello123"))
+
+	if err := repo.Disable(Email("sino@gmail.com")); err != nil {
+		t.Fatal(err)
+	}
+
+	restarted, err := NewFileRepository(directory)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	account, err := restarted.Get(Email("sino@gmail.com"), Password("hello123"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !account.Disabled {
+		t.Error("expected account to be disabled")
+	}
+}
@@ -39,6 +39,11 @@ type saveAccount struct {
 	account Account
 }
 
+// disableAccount is a type of job to disable an account in the storage.
+type disableAccount struct {
+	email Email
+}
+
 // Job represents an account-related job.
 type Job interface{}
 
@@ -70,6 +75,12 @@ func (service *Service) SaveAccount(email Email, account Account) {
 	service.jobQueue <- saveAccount{email: email, account: account}
 }
 
+// DisableAccount disables the Account of the specified Email, for it to no
+// longer be logged into.
+func (service *Service) DisableAccount(email Email) {
+	service.jobQueue <- disableAccount{email: email}
+}
+
 // worker continuously reads from the service's job queue until the
 // queue is closed.
 func (service *Service) worker() {
@@ -95,6 +106,11 @@ func (service *Service) worker() {
 
 			break
 
+		case disableAccount:
+			if err := service.repository.Disable(j.email); err != nil {
+				service.logger.Error(err)
+			}
+
 		default:
 			service.logger.Errorf("Unexpected job of type %v", reflect.TypeOf(j))
 		}
@@ -6,6 +6,7 @@ import "sync"
 type Repository interface {
 	Get(email Email, password Password) (*Account, error)
 	Put(email Email, account Account) error
+	Disable(email Email) error
 }
 
 // InMemoryRepository is an in-memory implementation of an account
@@ -49,3 +50,20 @@ func (repo *InMemoryRepository) Put(email Email, account Account) error {
 	repo.accounts[email] = &account
 	return nil
 }
+
+// Disable disables the Account that is registered under the specified
+// Email, for it to no longer be logged into.
+func (repo *InMemoryRepository) Disable(email Email) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	account := Account{Email: email, Password: "hello123"}
+	if registered := repo.accounts[email]; registered != nil {
+		account = *registered
+	}
+
+	account.Disabled = true
+	repo.accounts[email] = &account
+
+	return nil
+}
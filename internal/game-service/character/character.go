@@ -1,9 +1,15 @@
 package character
 
 import (
+	"errors"
+	"strings"
 	"time"
 )
 
+// ErrDisplayNameTaken is returned when attempting to create a character
+// under a DisplayName that is already in use by another character.
+var ErrDisplayNameTaken = errors.New("display name is already taken")
+
 // DisplayName is a name of a user's account that is exposed
 // ingame to other users.
 type DisplayName string
@@ -21,12 +27,21 @@ var (
 	GameDeveloper UserGroup = 6
 )
 
+// IsStaff returns whether the UserGroup is one of the staff's.
+func (group UserGroup) IsStaff() bool {
+	return group >= Moderator
+}
+
 // Profile represents a player character's last saved game state.
 type Profile struct {
 	DisplayName  DisplayName `json:"displayName"`
 	UserGroup    UserGroup   `json:"userGroup"`
 	LastLoggedIn *time.Time  `json:"lastLoggedIn"`
 
+	// SnapshotAt is when the Profile was taken from the game state, which
+	// orders saves of the same character. It is not persisted.
+	SnapshotAt time.Time `json:"-"`
+
 	Gender int `json:"gender"`
 
 	BicycleType   int `json:"bicycleType"`
@@ -37,4 +52,92 @@ type Profile struct {
 	MapZ   int `json:"mapZ"`
 	LocalX int `json:"localX"`
 	LocalZ int `json:"localZ"`
+
+	Party []MonsterProfile `json:"party"`
+	PC    []PCEntry        `json:"pc"`
+
+	// Respawn is the healing point the character was last healed at, to
+	// which the character is sent back after all of its monsters fainted.
+	// Nil if the character has yet to be healed anywhere.
+	Respawn *RespawnPoint `json:"respawn"`
+
+	Inventory []ItemEntry `json:"inventory"`
+}
+
+// RespawnPoint is the position of the healing point a player character
+// is sent back to after all of its monsters fainted.
+type RespawnPoint struct {
+	MapX   int `json:"mapX"`
+	MapZ   int `json:"mapZ"`
+	LocalX int `json:"localX"`
+	LocalZ int `json:"localZ"`
+}
+
+// MonsterProfile represents the last saved state of a monster that is
+// owned by a player character.
+type MonsterProfile struct {
+	ModelID         int         `json:"modelId"`
+	Gender          int         `json:"gender"`
+	StatusCondition int         `json:"statusCondition"`
+	Coloration      int         `json:"coloration"`
+	Nickname        string      `json:"nickname"`
+	OriginalTrainer DisplayName `json:"originalTrainer"`
+
+	Level      int `json:"level"`
+	Experience int `json:"experience"`
+
+	IndividualValues [6]int `json:"individualValues"`
+	EffortValues     [6]int `json:"effortValues"`
+	Nature           int    `json:"nature"`
+
+	HitPoints int `json:"hitPoints"`
+	Happiness int `json:"happiness"`
+
+	Moves []KnownMoveProfile `json:"moves"`
+}
+
+// KnownMoveProfile is a move that is known by a monster, along with the
+// amount of power points it has left.
+type KnownMoveProfile struct {
+	MoveID int `json:"moveId"`
+	PP     int `json:"pp"`
+}
+
+// FullHitPoints is the amount of HitPoints of a MonsterProfile that is
+// restored at full health, for monsters that were saved before their
+// health was.
+const FullHitPoints = -1
+
+// PCEntry is a monster that is deposited in a slot of one of the boxes
+// of a player character's PC.
+type PCEntry struct {
+	Box     int            `json:"box"`
+	Slot    int            `json:"slot"`
+	Monster MonsterProfile `json:"monster"`
+}
+
+// ItemEntry is a quantity of a single item held in a player character's
+// inventory.
+type ItemEntry struct {
+	ItemID   int `json:"itemId"`
+	Quantity int `json:"quantity"`
+}
+
+// Key returns the case-insensitive form of the DisplayName, which is
+// what uniqueness of display names is checked against.
+func (name DisplayName) Key() string {
+	return strings.ToLower(string(name))
+}
+
+// withProfile returns the given slice of Profile's with the specified Profile
+// either replacing the entry of the same DisplayName or appended to the end.
+func withProfile(profiles []*Profile, profile *Profile) []*Profile {
+	for i, p := range profiles {
+		if p.DisplayName.Key() == profile.DisplayName.Key() {
+			profiles[i] = profile
+			return profiles
+		}
+	}
+
+	return append(profiles, profile)
 }
@@ -0,0 +1,220 @@
+package character
+
+import (
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"gitlab.com/pokesync/game-service/internal/game-service/account"
+)
+
+// profileFileExtension is the extension of the files in which the
+// character Profile's of an account are stored.
+const profileFileExtension = ".json"
+
+// FileRepository is a Repository that stores the character Profile's of
+// every account as a file in a local directory. It is meant for local
+// development, to not require a database.
+type FileRepository struct {
+	directory string
+	owners    map[string]account.Email
+	mutex     *sync.Mutex
+}
+
+// FileCache is a Cache that stores the character Profile's of every
+// account as a file in a local directory. It is meant for local
+// development, to not require a Redis instance.
+type FileCache struct {
+	config    CacheConfig
+	directory string
+	mutex     *sync.Mutex
+}
+
+// NewFileRepository constructs a new instance of a FileRepository that
+// stores its profiles in the specified directory, which is created if it
+// does not yet exist. The profiles that are already stored are read to
+// learn which display names are taken.
+func NewFileRepository(directory string) (*FileRepository, error) {
+	if err := os.MkdirAll(directory, 0700); err != nil {
+		return nil, err
+	}
+
+	repo := &FileRepository{
+		directory: directory,
+		owners:    make(map[string]account.Email),
+		mutex:     &sync.Mutex{},
+	}
+
+	files, err := ioutil.ReadDir(directory)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), profileFileExtension) {
+			continue
+		}
+
+		email, err := url.PathUnescape(strings.TrimSuffix(file.Name(), profileFileExtension))
+		if err != nil {
+			return nil, err
+		}
+
+		profiles, err := readProfiles(filepath.Join(directory, file.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, profile := range profiles {
+			repo.owners[profile.DisplayName.Key()] = account.Email(email)
+		}
+	}
+
+	return repo, nil
+}
+
+// NewFileCache constructs a new instance of a FileCache that stores its
+// profiles in the specified directory, which is created if it does not
+// yet exist.
+func NewFileCache(config CacheConfig, directory string) (*FileCache, error) {
+	if err := os.MkdirAll(directory, 0700); err != nil {
+		return nil, err
+	}
+
+	return &FileCache{config: config, directory: directory, mutex: &sync.Mutex{}}, nil
+}
+
+// Get attempts to fetch the character Profile's that are stored under the
+// specified e-mail address.
+func (repo *FileRepository) Get(email account.Email) ([]*Profile, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	profiles, err := readProfiles(profilePathOf(repo.directory, email))
+	if os.IsNotExist(err) {
+		return []*Profile{}, nil
+	}
+
+	return profiles, err
+}
+
+// Put puts the given Profile under the specified Email into the Repository,
+// overriding any Profile that goes by the same DisplayName.
+func (repo *FileRepository) Put(email account.Email, profile *Profile) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	path := profilePathOf(repo.directory, email)
+
+	profiles, err := readProfiles(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	if err := writeProfiles(path, withProfile(profiles, profile)); err != nil {
+		return err
+	}
+
+	repo.owners[profile.DisplayName.Key()] = email
+	return nil
+}
+
+// Create puts the given Profile under the specified Email into the Repository
+// as a new character. Returns ErrDisplayNameTaken if the Profile's DisplayName
+// is already in use by any other character.
+func (repo *FileRepository) Create(email account.Email, profile *Profile) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	if _, taken := repo.owners[profile.DisplayName.Key()]; taken {
+		return ErrDisplayNameTaken
+	}
+
+	path := profilePathOf(repo.directory, email)
+
+	profiles, err := readProfiles(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	if err := writeProfiles(path, append(profiles, profile)); err != nil {
+		return err
+	}
+
+	repo.owners[profile.DisplayName.Key()] = email
+	return nil
+}
+
+// Get attempts to fetch the character Profile's that are stored under the
+// specified e-mail address. Returns nil if nothing is cached for the
+// specified e-mail address or if the cached profiles have expired.
+func (cache *FileCache) Get(email account.Email) ([]*Profile, error) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	path := profilePathOf(cache.directory, email)
+
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if cache.config.ExpireAfter > 0 && time.Since(info.ModTime()) > cache.config.ExpireAfter {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+
+		return nil, nil
+	}
+
+	return readProfiles(path)
+}
+
+// Put attempts to store the given character Profile's into the Cache.
+func (cache *FileCache) Put(email account.Email, profiles []*Profile) error {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	return writeProfiles(profilePathOf(cache.directory, email), profiles)
+}
+
+// readProfiles reads the character Profile's from the file at the
+// given path.
+func readProfiles(path string) ([]*Profile, error) {
+	fileBytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return DecodeProfiles(fileBytes)
+}
+
+// writeProfiles writes the given character Profile's to the file at the
+// given path, replacing the file as a whole so that a crash never leaves
+// partially written profiles behind.
+func writeProfiles(path string, profiles []*Profile) error {
+	fileBytes, err := EncodeProfiles(profiles)
+	if err != nil {
+		return err
+	}
+
+	if err := ioutil.WriteFile(path+".tmp", fileBytes, 0600); err != nil {
+		return err
+	}
+
+	return os.Rename(path+".tmp", path)
+}
+
+// profilePathOf returns the path to the file in the given directory in
+// which the character Profile's of the specified account are stored.
+func profilePathOf(directory string, email account.Email) string {
+	return filepath.Join(directory, url.PathEscape(string(email))+profileFileExtension)
+}
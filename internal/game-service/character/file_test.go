@@ -0,0 +1,57 @@
+package character
+
+import (
+	"testing"
+	"time"
+
+	"gitlab.com/pokesync/game-service/internal/game-service/account"
+)
+
+func TestFileRepository_SurvivesRestart(t *testing.T) {
+	directory := t.TempDir()
+
+	repo, err := NewFileRepository(directory)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	email := account.Email("sino/tje@gmail.com")
+	if err := repo.Create(email, &Profile{DisplayName: "Sino"}); err != nil {
+		t.Fatal(err)
+	}
+
+	repo.Put(email, &Profile{DisplayName: "Sino", PokeDollars: 500, Party: []MonsterProfile{{ModelID: 25}}})
+
+	restarted, err := NewFileRepository(directory)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	profiles, _ := restarted.Get(email)
+	if len(profiles) != 1 || profiles[0].PokeDollars != 500 || len(profiles[0].Party) != 1 {
+		t.Errorf("expected the saved profile to be read back but was %+v", profiles)
+	}
+
+	if err := restarted.Create(account.Email("someone@gmail.com"), &Profile{DisplayName: "sINO"}); err != ErrDisplayNameTaken {
+		t.Error("expected display name to still be taken after a restart")
+	}
+}
+
+func TestFileCache_Expires(t *testing.T) {
+	cache, err := NewFileCache(CacheConfig{ExpireAfter: time.Millisecond}, t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	email := account.Email("sino@gmail.com")
+	if profiles, _ := cache.Get(email); profiles != nil {
+		t.Error("expected nothing to be cached yet")
+	}
+
+	cache.Put(email, []*Profile{{DisplayName: "Sino"}})
+	time.Sleep(10 * time.Millisecond)
+
+	if profiles, _ := cache.Get(email); profiles != nil {
+		t.Error("expected the cached profiles to have expired")
+	}
+}
@@ -0,0 +1,61 @@
+package character
+
+import "sync/atomic"
+
+// Metrics keeps count of the events that occur in the character Service
+// that are of interest for monitoring. Safe for concurrent use.
+type Metrics struct {
+	cacheHits      uint64
+	cacheMisses    uint64
+	flushedWrites  uint64
+	failedFlushes  uint64
+	droppedFlushes uint64
+}
+
+// MetricsSnapshot is a point-in-time copy of the Metrics.
+type MetricsSnapshot struct {
+	CacheHits      uint64
+	CacheMisses    uint64
+	FlushedWrites  uint64
+	FailedFlushes  uint64
+	DroppedFlushes uint64
+	DirtyProfiles  int
+}
+
+// Snapshot takes a point-in-time copy of the Metrics.
+func (metrics *Metrics) Snapshot() MetricsSnapshot {
+	return MetricsSnapshot{
+		CacheHits:      atomic.LoadUint64(&metrics.cacheHits),
+		CacheMisses:    atomic.LoadUint64(&metrics.cacheMisses),
+		FlushedWrites:  atomic.LoadUint64(&metrics.flushedWrites),
+		FailedFlushes:  atomic.LoadUint64(&metrics.failedFlushes),
+		DroppedFlushes: atomic.LoadUint64(&metrics.droppedFlushes),
+	}
+}
+
+// cacheHit counts a character lookup that was served by the Cache.
+func (metrics *Metrics) cacheHit() {
+	atomic.AddUint64(&metrics.cacheHits, 1)
+}
+
+// cacheMiss counts a character lookup that fell back to the Repository.
+func (metrics *Metrics) cacheMiss() {
+	atomic.AddUint64(&metrics.cacheMisses, 1)
+}
+
+// flushed counts a dirty Profile that was written to the Repository.
+func (metrics *Metrics) flushed() {
+	atomic.AddUint64(&metrics.flushedWrites, 1)
+}
+
+// flushFailed counts an attempt at writing a dirty Profile to the
+// Repository that failed and is to be retried.
+func (metrics *Metrics) flushFailed() {
+	atomic.AddUint64(&metrics.failedFlushes, 1)
+}
+
+// flushDropped counts a dirty Profile that could not be written to the
+// Repository before the Service stopped.
+func (metrics *Metrics) flushDropped() {
+	atomic.AddUint64(&metrics.droppedFlushes, 1)
+}
@@ -0,0 +1,213 @@
+package character
+
+import (
+	"bufio"
+	"os"
+	"strings"
+	"unicode"
+)
+
+// These are the statuses a DisplayName can be validated with. A DisplayName
+// is only valid if it is given the ValidName status.
+const (
+	ValidName         NameStatus = 0
+	NameTooShort      NameStatus = 1
+	NameTooLong       NameStatus = 2
+	IllegalCharacters NameStatus = 3
+	MalformedSpacing  NameStatus = 4
+	ReservedName      NameStatus = 5
+	ImpersonatesStaff NameStatus = 6
+	DeniedName        NameStatus = 7
+)
+
+// NameStatus is the outcome of validating a DisplayName.
+type NameStatus int
+
+// NameRulesConfig holds configurations specific to the NameRules.
+type NameRulesConfig struct {
+	MinLength int
+	MaxLength int
+
+	AllowDigits bool
+	AllowSpaces bool
+
+	// ReservedWords are words that may not appear anywhere in a
+	// display name, such as 'admin' or the name of the game.
+	ReservedWords []string
+
+	// StaffNames are the display names of staff members, which other
+	// display names may not resemble.
+	StaffNames []DisplayName
+
+	// StaffFile is the path to a file of further display names of staff
+	// members, one name per line. Lines starting with a # are ignored.
+	// May be left empty.
+	StaffFile string
+
+	// DenylistFile is the path to a file of denied words, one word per
+	// line. Lines starting with a # are ignored. May be left empty.
+	DenylistFile string
+}
+
+// NameRules validates display names against a set of configured rules.
+type NameRules struct {
+	config NameRulesConfig
+
+	reserved []string
+	staff    map[string]bool
+	denied   []string
+}
+
+// lookalikes maps characters to the letter they are commonly used to
+// impersonate with.
+var lookalikes = map[rune]rune{
+	'0': 'o',
+	'1': 'l',
+	'3': 'e',
+	'4': 'a',
+	'5': 's',
+	'7': 't',
+	'8': 'b',
+	'9': 'g',
+	'i': 'l',
+	'|': 'l',
+}
+
+// NewNameRules constructs a new instance of NameRules. May return an error
+// if the configured staff or denylist file could not be read.
+func NewNameRules(config NameRulesConfig) (*NameRules, error) {
+	rules := &NameRules{
+		config: config,
+		staff:  make(map[string]bool),
+	}
+
+	for _, word := range config.ReservedWords {
+		rules.reserved = append(rules.reserved, skeletonOf(word))
+	}
+
+	for _, name := range config.StaffNames {
+		rules.staff[skeletonOf(string(name))] = true
+	}
+
+	if config.StaffFile != "" {
+		staff, err := loadSkeletons(config.StaffFile)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, name := range staff {
+			rules.staff[name] = true
+		}
+	}
+
+	if config.DenylistFile != "" {
+		denied, err := loadSkeletons(config.DenylistFile)
+		if err != nil {
+			return nil, err
+		}
+
+		rules.denied = denied
+	}
+
+	return rules, nil
+}
+
+// loadSkeletons reads a list of words from the file at the specified path,
+// one word per line, and returns their skeletons. May return an error.
+func loadSkeletons(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	var skeletons []string
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		skeletons = append(skeletons, skeletonOf(line))
+	}
+
+	return skeletons, scanner.Err()
+}
+
+// Validate validates the given DisplayName, returning the NameStatus that
+// describes why the display name is or isn't a valid one.
+func (rules *NameRules) Validate(name DisplayName) NameStatus {
+	value := string(name)
+
+	length := len([]rune(value))
+	if length < rules.config.MinLength {
+		return NameTooShort
+	}
+
+	if length > rules.config.MaxLength {
+		return NameTooLong
+	}
+
+	for _, r := range value {
+		switch {
+		case r <= unicode.MaxASCII && unicode.IsLetter(r):
+			continue
+		case rules.config.AllowDigits && r >= '0' && r <= '9':
+			continue
+		case rules.config.AllowSpaces && r == ' ':
+			continue
+		default:
+			return IllegalCharacters
+		}
+	}
+
+	if strings.TrimSpace(value) != value || strings.Contains(value, "  ") {
+		return MalformedSpacing
+	}
+
+	skeleton := skeletonOf(value)
+	if rules.staff[skeleton] {
+		return ImpersonatesStaff
+	}
+
+	for _, word := range rules.reserved {
+		if strings.Contains(skeleton, word) {
+			return ReservedName
+		}
+	}
+
+	for _, word := range rules.denied {
+		if strings.Contains(skeleton, word) {
+			return DeniedName
+		}
+	}
+
+	return ValidName
+}
+
+// skeletonOf reduces the given name to a form in which names that look alike
+// are equal to one another. Casing and spacing is dropped and characters that
+// are often used to impersonate others with, are replaced.
+func skeletonOf(name string) string {
+	var bldr strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsSpace(r) || r == '_' || r == '-' || r == '.' {
+			continue
+		}
+
+		if replacement, exists := lookalikes[r]; exists {
+			r = replacement
+		}
+
+		bldr.WriteRune(r)
+	}
+
+	skeleton := bldr.String()
+	skeleton = strings.Replace(skeleton, "rn", "m", -1)
+	skeleton = strings.Replace(skeleton, "vv", "w", -1)
+
+	return skeleton
+}
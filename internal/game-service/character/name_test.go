@@ -0,0 +1,115 @@
+package character
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+var nameRulesConfig = NameRulesConfig{
+	MinLength: 3,
+	MaxLength: 12,
+
+	AllowDigits: true,
+	AllowSpaces: true,
+
+	ReservedWords: []string{"admin", "pokesync"},
+	StaffNames:    []DisplayName{"Sino", "Morning"},
+}
+
+func TestNameRules_Validate(t *testing.T) {
+	rules, err := NewNameRules(nameRulesConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	names := map[DisplayName]NameStatus{
+		"Ash":           ValidName,
+		"Ash Ketchum":   ValidName,
+		"Red 2":         ValidName,
+		"Al":            NameTooShort,
+		"AshKetchum123": NameTooLong,
+		"Ash_Ketchum":   IllegalCharacters,
+		"Ásh":           IllegalCharacters,
+		" Ash":          MalformedSpacing,
+		"Ash  Ketchum":  MalformedSpacing,
+		"sino":          ImpersonatesStaff,
+		"S1N0":          ImpersonatesStaff,
+		"Mornlng":       ImpersonatesStaff,
+		"Morning":       ImpersonatesStaff,
+		"Adm1n Ash":     ReservedName,
+		"PokeSync Ash":  ReservedName,
+	}
+
+	for name, expected := range names {
+		if status := rules.Validate(name); status != expected {
+			t.Errorf("expected status of %q to equal %v but was %v instead", name, expected, status)
+		}
+	}
+}
+
+func TestNameRules_ValidateDenylist(t *testing.T) {
+	file, err := ioutil.TempFile("", "denylist")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.Remove(file.Name())
+
+	file.WriteString("# comment\nbadword\n\n")
+	file.Close()
+
+	config := nameRulesConfig
+	config.DenylistFile = file.Name()
+
+	rules, err := NewNameRules(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if status := rules.Validate("B4dw0rd Ash"); status != DeniedName {
+		t.Errorf("expected status to equal %v but was %v instead", DeniedName, status)
+	}
+
+	if status := rules.Validate("comment"); status != ValidName {
+		t.Errorf("expected status to equal %v but was %v instead", ValidName, status)
+	}
+}
+
+func TestNameRules_ValidateStaffFile(t *testing.T) {
+	file, err := ioutil.TempFile("", "staff")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.Remove(file.Name())
+
+	file.WriteString("# staff\nProf Oak\n")
+	file.Close()
+
+	config := nameRulesConfig
+	config.StaffFile = file.Name()
+
+	rules, err := NewNameRules(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if status := rules.Validate("Pr0f 0ak"); status != ImpersonatesStaff {
+		t.Errorf("expected status to equal %v but was %v instead", ImpersonatesStaff, status)
+	}
+
+	if status := rules.Validate("Sino"); status != ImpersonatesStaff {
+		t.Errorf("expected the configured staff names to still apply but was %v instead", status)
+	}
+}
+
+func TestNewNameRules_LoadsConfiguredFiles(t *testing.T) {
+	config := nameRulesConfig
+	config.StaffFile = "../../../assets/config/name/staff.txt"
+	config.DenylistFile = "../../../assets/config/name/denylist.txt"
+
+	if _, err := NewNameRules(config); err != nil {
+		t.Fatal(err)
+	}
+}
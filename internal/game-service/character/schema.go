@@ -0,0 +1,279 @@
+package character
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// SchemaVersion is the version of the format in which character Profile's
+// are currently stored.
+const SchemaVersion = 6
+
+// legacySchemaVersion is the version of character Profile's that were
+// stored as a plain JSON array, before stored profiles were versioned.
+const legacySchemaVersion = 1
+
+// SchemaVersionError is returned when attempting to decode character
+// Profile's that were stored in a version of the format this server does
+// not know how to read.
+type SchemaVersionError struct {
+	Version int
+}
+
+// storedProfiles is the versioned document in which character Profile's
+// are stored.
+type storedProfiles struct {
+	Version  int             `json:"version"`
+	Profiles json.RawMessage `json:"profiles"`
+}
+
+// rawProfile is a character Profile of which the fields are yet to be
+// decoded, which allows it to be upgraded between versions.
+type rawProfile map[string]json.RawMessage
+
+// upgrade upgrades raw character Profile's from one version to the next.
+type upgrade func(profiles []rawProfile) ([]rawProfile, error)
+
+// upgrades is the chain of upgrades where the upgrade at index i upgrades
+// raw character Profile's of version i to version i + 1.
+var upgrades = []upgrade{
+	legacySchemaVersion: upgradeToPartyAndPC,
+	2:                   upgradeToMonsterStats,
+	3:                   upgradeToKnownMoves,
+	4:                   upgradeToRespawnPoint,
+	5:                   upgradeToInventory,
+}
+
+// Error returns the description of the SchemaVersionError.
+func (err SchemaVersionError) Error() string {
+	return fmt.Sprintf("character profiles are stored in schema version %v but only up to version %v is supported", err.Version, SchemaVersion)
+}
+
+// EncodeProfiles encodes the given character Profile's into the current
+// version of the storage format.
+func EncodeProfiles(profiles []*Profile) ([]byte, error) {
+	profileBytes, err := json.Marshal(profiles)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(storedProfiles{Version: SchemaVersion, Profiles: profileBytes})
+}
+
+// DecodeProfiles decodes character Profile's from any known version of the
+// storage format, upgrading them to the current version along the way.
+// Returns a SchemaVersionError if the Profile's were stored in a version
+// that is unknown to this server.
+func DecodeProfiles(data []byte) ([]*Profile, error) {
+	version, profileBytes, err := unwrapProfiles(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if version < legacySchemaVersion || version > SchemaVersion {
+		return nil, SchemaVersionError{Version: version}
+	}
+
+	if version < SchemaVersion {
+		profileBytes, err = upgradeProfiles(version, profileBytes)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var profiles []*Profile
+	if err := json.Unmarshal(profileBytes, &profiles); err != nil {
+		return nil, err
+	}
+
+	return profiles, nil
+}
+
+// unwrapProfiles unwraps the given stored document into the version it
+// was stored in and its not yet decoded profiles. Documents that are not
+// versioned are of the legacy version, which was either a plain JSON array
+// of profiles or a single profile object.
+func unwrapProfiles(data []byte) (int, json.RawMessage, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 || trimmed[0] != '{' {
+		return legacySchemaVersion, trimmed, nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(trimmed, &fields); err != nil {
+		return 0, nil, err
+	}
+
+	if _, versioned := fields["version"]; !versioned {
+		return legacySchemaVersion, wrapLegacyProfile(trimmed), nil
+	}
+
+	var stored storedProfiles
+	if err := json.Unmarshal(trimmed, &stored); err != nil {
+		return 0, nil, err
+	}
+
+	return stored.Version, stored.Profiles, nil
+}
+
+// wrapLegacyProfile wraps a single legacy profile object into a JSON array
+// of profiles.
+func wrapLegacyProfile(profile []byte) json.RawMessage {
+	wrapped := make([]byte, 0, len(profile)+2)
+	wrapped = append(wrapped, '[')
+	wrapped = append(wrapped, profile...)
+	return append(wrapped, ']')
+}
+
+// upgradeProfiles runs the given raw profiles of the specified version
+// through the chain of upgrades, up to the current version.
+func upgradeProfiles(version int, profileBytes json.RawMessage) (json.RawMessage, error) {
+	var profiles []rawProfile
+	if err := json.Unmarshal(profileBytes, &profiles); err != nil {
+		return nil, err
+	}
+
+	for ; version < SchemaVersion; version++ {
+		upgraded, err := upgrades[version](profiles)
+		if err != nil {
+			return nil, fmt.Errorf("failed to upgrade character profiles from version %v: %v", version, err)
+		}
+
+		profiles = upgraded
+	}
+
+	return json.Marshal(profiles)
+}
+
+// upgradeToPartyAndPC upgrades legacy profiles to version 2, which
+// introduced the monsters in the party belt and the PC boxes.
+func upgradeToPartyAndPC(profiles []rawProfile) ([]rawProfile, error) {
+	for _, profile := range profiles {
+		if profile == nil {
+			continue
+		}
+
+		if _, ok := profile["party"]; !ok {
+			profile["party"] = json.RawMessage("[]")
+		}
+
+		if _, ok := profile["pc"]; !ok {
+			profile["pc"] = json.RawMessage("[]")
+		}
+	}
+
+	return profiles, nil
+}
+
+// upgradeToMonsterStats upgrades profiles to version 3, which introduced
+// levels and health to monsters. Monsters that were saved before are
+// restored as a level one monster at full health.
+func upgradeToMonsterStats(profiles []rawProfile) ([]rawProfile, error) {
+	for _, profile := range profiles {
+		if profile == nil {
+			continue
+		}
+
+		var party []rawProfile
+		if err := unmarshalField(profile, "party", &party); err != nil {
+			return nil, err
+		}
+
+		for _, monster := range party {
+			upgradeMonsterToStats(monster)
+		}
+
+		var pc []rawProfile
+		if err := unmarshalField(profile, "pc", &pc); err != nil {
+			return nil, err
+		}
+
+		for _, entry := range pc {
+			var monster rawProfile
+			if err := unmarshalField(entry, "monster", &monster); err != nil {
+				return nil, err
+			}
+
+			upgradeMonsterToStats(monster)
+			if err := marshalField(entry, "monster", monster); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := marshalField(profile, "party", party); err != nil {
+			return nil, err
+		}
+
+		if err := marshalField(profile, "pc", pc); err != nil {
+			return nil, err
+		}
+	}
+
+	return profiles, nil
+}
+
+// upgradeToKnownMoves upgrades profiles to version 4, which introduced
+// the moves monsters know. Monsters that were saved before know no moves,
+// which is what a missing field decodes as.
+func upgradeToKnownMoves(profiles []rawProfile) ([]rawProfile, error) {
+	return profiles, nil
+}
+
+// upgradeToRespawnPoint upgrades profiles to version 5, which introduced
+// the healing point characters are sent back to after all of their
+// monsters fainted. Characters that were saved before have yet to be
+// healed anywhere, which is what a missing field decodes as.
+func upgradeToRespawnPoint(profiles []rawProfile) ([]rawProfile, error) {
+	return profiles, nil
+}
+
+// upgradeToInventory upgrades profiles to version 6, which introduced the
+// items characters hold. Characters that were saved before hold no items,
+// which is what a missing field decodes as.
+func upgradeToInventory(profiles []rawProfile) ([]rawProfile, error) {
+	return profiles, nil
+}
+
+// upgradeMonsterToStats gives the given raw monster a level and health,
+// if it does not yet have them.
+func upgradeMonsterToStats(monster rawProfile) {
+	if monster == nil {
+		return
+	}
+
+	if _, ok := monster["level"]; !ok {
+		monster["level"] = json.RawMessage("1")
+	}
+
+	if _, ok := monster["hitPoints"]; !ok {
+		monster["hitPoints"] = json.RawMessage(fmt.Sprint(FullHitPoints))
+	}
+}
+
+// unmarshalField decodes the specified field of the given raw object into
+// the given value, leaving the value untouched if there is no such field.
+func unmarshalField(object rawProfile, field string, value interface{}) error {
+	raw, ok := object[field]
+	if !ok {
+		return nil
+	}
+
+	return json.Unmarshal(raw, value)
+}
+
+// marshalField encodes the given value into the specified field of the
+// given raw object.
+func marshalField(object rawProfile, field string, value interface{}) error {
+	if object == nil {
+		return nil
+	}
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	object[field] = raw
+	return nil
+}
@@ -0,0 +1,263 @@
+package character
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestDecodeProfiles_LegacyVersion(t *testing.T) {
+	legacy := `[{"displayName":"Sino","userGroup":1,"lastLoggedIn":null,"gender":1,"bicycleType":0,"pokedollars":500,"donatorPoints":0,"mapX":1,"mapZ":2,"localX":3,"localZ":4}]`
+
+	profiles, err := DecodeProfiles([]byte(legacy))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(profiles) != 1 {
+		t.Fatalf("expected %v profiles but was %v instead", 1, len(profiles))
+	}
+
+	profile := profiles[0]
+	if profile.DisplayName != "Sino" || profile.UserGroup != Patron || profile.PokeDollars != 500 || profile.LocalZ != 4 {
+		t.Errorf("unexpected legacy profile %+v", profile)
+	}
+
+	if profile.Party == nil || profile.PC == nil {
+		t.Error("expected the legacy profile to be upgraded with an empty party and PC")
+	}
+
+	encoded, err := EncodeProfiles(profiles)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	upgraded, err := DecodeProfiles(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if upgraded[0].DisplayName != "Sino" || upgraded[0].PokeDollars != 500 {
+		t.Errorf("expected the upgraded profile to survive a round trip but was %+v", upgraded[0])
+	}
+}
+
+func TestDecodeProfiles_LegacySingleProfile(t *testing.T) {
+	// a single profile object, exactly as the cache stored it before
+	// stored profiles were versioned
+	legacy := `{"displayName":"Sino","userGroup":1,"lastLoggedIn":"2019-06-01T12:00:00Z","gender":1,"bicycleType":2,"pokedollars":500,"donatorPoints":10,"mapX":1,"mapZ":2,"localX":3,"localZ":4}`
+
+	profiles, err := DecodeProfiles([]byte(legacy))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(profiles) != 1 {
+		t.Fatalf("expected %v profiles but was %v instead", 1, len(profiles))
+	}
+
+	profile := profiles[0]
+	if profile.DisplayName != "Sino" || profile.BicycleType != 2 || profile.DonatorPoints != 10 || profile.LastLoggedIn == nil || profile.MapZ != 2 {
+		t.Errorf("unexpected legacy profile %+v", profile)
+	}
+
+	encoded, err := EncodeProfiles(profiles)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	upgraded, err := DecodeProfiles(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(upgraded, profiles) {
+		t.Errorf("expected the upgraded profile to survive a round trip but was %+v", upgraded[0])
+	}
+}
+
+func TestDecodeProfiles_PartyAndPCVersion(t *testing.T) {
+	stored := `{"version":2,"profiles":[{"displayName":"Sino","party":[{"modelId":25,"nickname":"Sparky"}],"pc":[{"box":3,"slot":7,"monster":{"modelId":1}}]}]}`
+
+	profiles, err := DecodeProfiles([]byte(stored))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	profile := profiles[0]
+	if len(profile.Party) != 1 || profile.Party[0].ModelID != 25 || profile.Party[0].Nickname != "Sparky" {
+		t.Fatalf("unexpected party %+v", profile.Party)
+	}
+
+	if len(profile.PC) != 1 || profile.PC[0].Box != 3 || profile.PC[0].Slot != 7 {
+		t.Fatalf("unexpected PC %+v", profile.PC)
+	}
+
+	for _, monster := range []MonsterProfile{profile.Party[0], profile.PC[0].Monster} {
+		if monster.Level != 1 || monster.HitPoints != FullHitPoints {
+			t.Errorf("expected monster to be upgraded to a level one monster at full health but was %+v", monster)
+		}
+	}
+
+	encoded, err := EncodeProfiles(profiles)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	upgraded, err := DecodeProfiles(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(upgraded[0], profile) {
+		t.Errorf("expected the upgraded profile to survive a round trip but was %+v", upgraded[0])
+	}
+}
+
+func TestDecodeProfiles_MonsterStatsVersion(t *testing.T) {
+	stored := `{"version":3,"profiles":[{"displayName":"Sino","party":[{"modelId":25,"level":12,"hitPoints":30}],"pc":[]}]}`
+
+	profiles, err := DecodeProfiles([]byte(stored))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	monster := profiles[0].Party[0]
+	if monster.Level != 12 || monster.HitPoints != 30 || len(monster.Moves) != 0 {
+		t.Fatalf("expected monster to be upgraded without any known moves but was %+v", monster)
+	}
+
+	encoded, err := EncodeProfiles(profiles)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	upgraded, err := DecodeProfiles(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if upgraded[0].Party[0].Level != 12 || upgraded[0].Party[0].HitPoints != 30 {
+		t.Errorf("expected the upgraded profile to survive a round trip but was %+v", upgraded[0])
+	}
+}
+
+func TestDecodeProfiles_KnownMovesVersion(t *testing.T) {
+	stored := `{"version":4,"profiles":[{"displayName":"Sino","party":[{"modelId":25,"level":12,"hitPoints":30,"moves":[{"moveId":2,"pp":35}]}],"pc":[]}]}`
+
+	profiles, err := DecodeProfiles([]byte(stored))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	profile := profiles[0]
+	if len(profile.Party[0].Moves) != 1 || profile.Party[0].Moves[0].MoveID != 2 || profile.Party[0].Moves[0].PP != 35 {
+		t.Fatalf("expected the known moves to be kept but was %+v", profile.Party[0])
+	}
+
+	if profile.Respawn != nil {
+		t.Fatalf("expected the profile to be upgraded without a respawn point but was %+v", profile.Respawn)
+	}
+
+	encoded, err := EncodeProfiles(profiles)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	upgraded, err := DecodeProfiles(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(upgraded, profiles) {
+		t.Errorf("expected the upgraded profile to survive a round trip but was %+v", upgraded[0])
+	}
+}
+
+func TestDecodeProfiles_RespawnPointVersion(t *testing.T) {
+	stored := `{"version":5,"profiles":[{"displayName":"Sino","party":[],"pc":[],"respawn":{"mapX":2,"mapZ":3,"localX":12,"localZ":9}}]}`
+
+	profiles, err := DecodeProfiles([]byte(stored))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	profile := profiles[0]
+	if profile.Respawn == nil || *profile.Respawn != (RespawnPoint{MapX: 2, MapZ: 3, LocalX: 12, LocalZ: 9}) {
+		t.Fatalf("expected the respawn point to be kept but was %+v", profile.Respawn)
+	}
+
+	if len(profile.Inventory) != 0 {
+		t.Fatalf("expected the profile to be upgraded without any items but was %+v", profile.Inventory)
+	}
+
+	encoded, err := EncodeProfiles(profiles)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	upgraded, err := DecodeProfiles(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(upgraded, profiles) {
+		t.Errorf("expected the upgraded profile to survive a round trip but was %+v", upgraded[0])
+	}
+}
+
+func TestDecodeProfiles_CurrentVersion(t *testing.T) {
+	profiles := []*Profile{{
+		DisplayName: "Sino",
+		PokeDollars: 500,
+		Party: []MonsterProfile{
+			{ModelID: 25, Nickname: "Sparky", OriginalTrainer: "Sino", Level: 12, Experience: 1728, Nature: 3, HitPoints: 30, IndividualValues: [6]int{31, 0, 12, 4, 5, 6}, Moves: []KnownMoveProfile{{MoveID: 2, PP: 35}}},
+		},
+		PC: []PCEntry{
+			{Box: 3, Slot: 7, Monster: MonsterProfile{ModelID: 1, OriginalTrainer: "Sino"}},
+		},
+		Respawn:   &RespawnPoint{MapX: 2, MapZ: 3, LocalX: 12, LocalZ: 9},
+		Inventory: []ItemEntry{{ItemID: 0, Quantity: 5}, {ItemID: 4, Quantity: 1}},
+	}}
+
+	encoded, err := EncodeProfiles(profiles)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded, err := DecodeProfiles(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	profile := decoded[0]
+	if !reflect.DeepEqual(profile.Party, profiles[0].Party) {
+		t.Errorf("expected party %+v but was %+v instead", profiles[0].Party, profile.Party)
+	}
+
+	if !reflect.DeepEqual(profile.PC, profiles[0].PC) {
+		t.Errorf("expected PC %+v but was %+v instead", profiles[0].PC, profile.PC)
+	}
+
+	if !reflect.DeepEqual(profile.Respawn, profiles[0].Respawn) {
+		t.Errorf("expected respawn point %+v but was %+v instead", profiles[0].Respawn, profile.Respawn)
+	}
+
+	if !reflect.DeepEqual(profile.Inventory, profiles[0].Inventory) {
+		t.Errorf("expected inventory %+v but was %+v instead", profiles[0].Inventory, profile.Inventory)
+	}
+}
+
+func TestDecodeProfiles_RefusesFutureVersion(t *testing.T) {
+	future := SchemaVersion + 1
+	_, err := DecodeProfiles([]byte(fmt.Sprintf(`{"version":%v,"profiles":[{"displayName":"Sino"}]}`, future)))
+
+	versionErr, ok := err.(SchemaVersionError)
+	if !ok {
+		t.Fatalf("expected a SchemaVersionError but was %v instead", err)
+	}
+
+	if versionErr.Version != future {
+		t.Errorf("expected version %v but was %v instead", future, versionErr.Version)
+	}
+}
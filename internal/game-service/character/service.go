@@ -1,19 +1,40 @@
 package character
 
 import (
+	"errors"
 	"reflect"
+	"sync"
+	"time"
 
 	"gitlab.com/pokesync/game-service/internal/game-service/account"
 	"go.uber.org/zap"
 )
 
+// ErrServiceStopped is returned when a job is submitted to the Service
+// after it was stopped.
+var ErrServiceStopped = errors.New("character service has stopped")
+
 // Config holds configurations specific to the character Service.
 type Config struct {
 	WorkerCount int
+
+	// saved profiles are written to the cache at once and flushed to
+	// the repository in batches, retrying failed writes with backoff
+	FlushInterval  time.Duration
+	FlushBatchSize int
+	RetryBackoff   time.Duration
 }
 
-// LoadResult is the result from attempting to load a character profile.
+// LoadResult is the result from attempting to load the character profiles
+// of an account.
 type LoadResult struct {
+	Profiles []*Profile
+	Error    error
+}
+
+// CreateResult is the result from attempting to create a new character
+// profile for an account.
+type CreateResult struct {
 	Profile *Profile
 	Error   error
 }
@@ -27,15 +48,38 @@ type Service struct {
 	cache      Cache
 	repository Repository
 
+	writeBehind *writeBehind
+	metrics     *Metrics
+
+	// accountLocks serialises the cache updates of each account
+	accountLocks *accountLocks
+
 	jobQueue chan Job
+	workers  *sync.WaitGroup
+
+	// stopping guards the job queue from being submitted to after it closed
+	stopping *sync.RWMutex
+	stopped  bool
+
+	quit    chan bool
+	flushed chan bool
 }
 
-// loadProfile is a type of job to load a character profile from the storage.
-type loadProfile struct {
+// loadProfiles is a type of job to load the character profiles of an
+// account from the storage.
+type loadProfiles struct {
 	email   account.Email
 	channel chan<- LoadResult
 }
 
+// createProfile is a type of job to register a new character profile
+// in the storage.
+type createProfile struct {
+	email   account.Email
+	profile *Profile
+	channel chan<- CreateResult
+}
+
 // saveProfile is a type of job to save an account in the storage.
 type saveProfile struct {
 	email   account.Email
@@ -48,73 +92,128 @@ type Job interface{}
 // NewService constructs a new Service.
 func NewService(config Config, logger *zap.SugaredLogger, cache Cache, repository Repository) *Service {
 	service := &Service{
-		config:     config,
-		logger:     logger,
-		cache:      cache,
-		repository: repository,
-		jobQueue:   make(chan Job),
+		config:       config,
+		logger:       logger,
+		cache:        cache,
+		repository:   repository,
+		writeBehind:  newWriteBehind(),
+		metrics:      &Metrics{},
+		accountLocks: newAccountLocks(),
+		jobQueue:     make(chan Job),
+		workers:      &sync.WaitGroup{},
+		stopping:     &sync.RWMutex{},
+		quit:         make(chan bool, 1),
+		flushed:      make(chan bool),
 	}
 
+	service.workers.Add(config.WorkerCount)
 	for i := 0; i < config.WorkerCount; i++ {
 		go service.worker()
 	}
 
+	go service.flusher()
+
 	return service
 }
 
-// LoadProfile loads an Account.
-func (service *Service) LoadProfile(email account.Email) <-chan LoadResult {
+// LoadProfiles loads every character Profile of an Account.
+func (service *Service) LoadProfiles(email account.Email) <-chan LoadResult {
 	result := make(chan LoadResult, 1)
-	service.jobQueue <- loadProfile{email: email, channel: result}
+	if !service.submit(loadProfiles{email: email, channel: result}) {
+		result <- LoadResult{Error: ErrServiceStopped}
+	}
+
+	return result
+}
+
+// CreateProfile registers the given Profile as a new character of an Account.
+func (service *Service) CreateProfile(email account.Email, profile *Profile) <-chan CreateResult {
+	result := make(chan CreateResult, 1)
+	if !service.submit(createProfile{email: email, profile: profile, channel: result}) {
+		result <- CreateResult{Error: ErrServiceStopped}
+	}
+
 	return result
 }
 
 // SaveProfile saves the given Profile.
 func (service *Service) SaveProfile(email account.Email, profile *Profile) {
-	service.jobQueue <- saveProfile{email: email, profile: profile}
+	if !service.submit(saveProfile{email: email, profile: profile}) {
+		service.logger.Errorw("character profile saved after the service stopped", "email", email, "profile", profile)
+	}
+}
+
+// submit submits the given Job to the job queue. Returns false if the
+// Service has been stopped.
+func (service *Service) submit(job Job) bool {
+	service.stopping.RLock()
+	defer service.stopping.RUnlock()
+
+	if service.stopped {
+		return false
+	}
+
+	service.jobQueue <- job
+	return true
 }
 
 // worker continuously reads from the service's job queue until the
 // queue is closed.
 func (service *Service) worker() {
+	defer service.workers.Done()
+
 	for job := range service.jobQueue {
 		switch j := job.(type) {
-		case loadProfile:
-			profile, err := service.cache.Get(j.email)
+		case loadProfiles:
+			profiles, err := service.cache.Get(j.email)
 			if err != nil {
 				j.channel <- LoadResult{Error: err}
 				continue
 			}
 
-			if profile != nil {
-				j.channel <- LoadResult{Profile: profile}
+			if profiles != nil {
+				service.metrics.cacheHit()
+
+				j.channel <- LoadResult{Profiles: service.withDirtyProfiles(j.email, profiles)}
 				break
 			}
 
-			profile, err = service.repository.Get(j.email)
+			service.metrics.cacheMiss()
+
+			profiles, err = service.fillCache(j.email)
 			if err != nil {
 				j.channel <- LoadResult{Error: err}
 				continue
 			}
 
-			j.channel <- LoadResult{Profile: profile}
+			j.channel <- LoadResult{Profiles: profiles}
 			break
 
-		case saveProfile:
-			// override what's potentially in the cache
-			err := service.cache.Put(j.email, j.profile)
-			if err != nil {
-				service.logger.Error(err)
+		case createProfile:
+			if err := service.repository.Create(j.email, j.profile); err != nil {
+				j.channel <- CreateResult{Error: err}
+				continue
 			}
 
-			// and also update the backup store, which is our db
-			err = service.repository.Put(j.email, j.profile)
-			if err != nil {
-				service.logger.Error(err)
+			service.accountLocks.lock(j.email)
+			service.updateCache(j.email, j.profile)
+			service.accountLocks.unlock(j.email)
+
+			j.channel <- CreateResult{Profile: j.profile}
+			break
 
-				// TODO what to do with this character profile?
+		case saveProfile:
+			// the cache is updated in the order in which saves are marked
+			service.accountLocks.lock(j.email)
+
+			// have the flusher update the backup store, which is our db,
+			// and override what's potentially in the cache
+			if service.writeBehind.mark(j.email, j.profile) {
+				service.updateCache(j.email, j.profile)
 			}
 
+			service.accountLocks.unlock(j.email)
+
 			break
 
 		default:
@@ -123,7 +222,183 @@ func (service *Service) worker() {
 	}
 }
 
-// Stop stops this Service and cleans up resources.
+// fillCache loads the profiles of the specified account from the
+// repository into the cache.
+func (service *Service) fillCache(email account.Email) ([]*Profile, error) {
+	service.accountLocks.lock(email)
+	defer service.accountLocks.unlock(email)
+
+	profiles, err := service.repository.Get(email)
+	if err != nil {
+		return nil, err
+	}
+
+	// the repository may not have caught up with the latest saves yet
+	profiles = service.withDirtyProfiles(email, profiles)
+
+	if err := service.cache.Put(email, profiles); err != nil {
+		service.logger.Error(err)
+	}
+
+	return profiles, nil
+}
+
+// updateCache updates the cached entry of the given Profile, if the profiles
+// of the specified account are currently held by the cache.
+func (service *Service) updateCache(email account.Email, profile *Profile) {
+	profiles, err := service.cache.Get(email)
+	if err != nil {
+		service.logger.Error(err)
+		return
+	}
+
+	if profiles == nil {
+		return
+	}
+
+	if err := service.cache.Put(email, withProfile(profiles, profile)); err != nil {
+		service.logger.Error(err)
+	}
+}
+
+// withDirtyProfiles overlays the given character Profile's of an account
+// with those of its saved Profile's that have yet to be flushed.
+func (service *Service) withDirtyProfiles(email account.Email, profiles []*Profile) []*Profile {
+	for _, profile := range service.writeBehind.profilesOf(email) {
+		profiles = withProfile(profiles, profile)
+	}
+
+	return profiles
+}
+
+// flusher periodically flushes batches of dirty Profile's to the repository
+// until the Service is stopped, after which every remaining dirty Profile
+// is flushed.
+func (service *Service) flusher() {
+	for {
+		select {
+		case <-service.quit:
+			service.flushAll()
+			close(service.flushed)
+
+			return
+
+		case <-time.After(service.config.FlushInterval):
+			service.flush(service.writeBehind.take(time.Now(), service.config.FlushBatchSize))
+		}
+	}
+}
+
+// flush writes the given batch of dirty Profile's to the repository.
+// Returns the amount of Profile's that failed to be written.
+func (service *Service) flush(batch []*dirtyProfile) int {
+	failures := 0
+	for _, entry := range batch {
+		if err := service.repository.Put(entry.email, entry.profile); err != nil {
+			service.logger.Error(err)
+			service.metrics.flushFailed()
+
+			service.writeBehind.fail(entry, time.Now(), service.config.RetryBackoff)
+
+			failures++
+			continue
+		}
+
+		service.metrics.flushed()
+		service.writeBehind.complete(entry)
+	}
+
+	return failures
+}
+
+// flushAll flushes every dirty Profile regardless of any backoff, retrying
+// failed writes a few more times. Profile's that still could not be written
+// are logged in full, for them to be recovered by hand.
+func (service *Service) flushAll() {
+	const attempts = 3
+
+	for i := 0; i < attempts; i++ {
+		if service.flush(service.writeBehind.take(time.Now(), 0)) == 0 {
+			return
+		}
+
+		time.Sleep(service.config.RetryBackoff)
+	}
+
+	for _, entry := range service.writeBehind.take(time.Now(), 0) {
+		service.metrics.flushDropped()
+		service.logger.Errorw("failed to flush character profile", "email", entry.email, "profile", entry.profile)
+	}
+}
+
+// Metrics returns a snapshot of the Metrics of this Service.
+func (service *Service) Metrics() MetricsSnapshot {
+	snapshot := service.metrics.Snapshot()
+	snapshot.DirtyProfiles = service.writeBehind.size()
+
+	return snapshot
+}
+
+// Stop stops this Service and cleans up resources. Blocks until every
+// pending job has been processed and every dirty Profile has been flushed.
 func (service *Service) Stop() {
+	service.stopping.Lock()
+	service.stopped = true
 	close(service.jobQueue)
+	service.stopping.Unlock()
+
+	service.workers.Wait()
+
+	service.quit <- true
+	<-service.flushed
+}
+
+// accountLocks is a set of mutexes, one for each account that is being
+// worked on. Safe for concurrent use.
+type accountLocks struct {
+	mutex *sync.Mutex
+	locks map[account.Email]*accountLock
+}
+
+// accountLock is the mutex of a single account, along with the amount of
+// workers holding or waiting for it.
+type accountLock struct {
+	mutex   sync.Mutex
+	holders int
+}
+
+// newAccountLocks constructs a new instance of accountLocks.
+func newAccountLocks() *accountLocks {
+	return &accountLocks{mutex: &sync.Mutex{}, locks: make(map[account.Email]*accountLock)}
+}
+
+// lock locks the mutex of the specified account, blocking until it is
+// available.
+func (locks *accountLocks) lock(email account.Email) {
+	locks.mutex.Lock()
+	lock, ok := locks.locks[email]
+	if !ok {
+		lock = &accountLock{}
+		locks.locks[email] = lock
+	}
+
+	lock.holders++
+	locks.mutex.Unlock()
+
+	lock.mutex.Lock()
+}
+
+// unlock unlocks the mutex of the specified account, forgetting about it
+// once no one holds or waits for it anymore.
+func (locks *accountLocks) unlock(email account.Email) {
+	locks.mutex.Lock()
+	defer locks.mutex.Unlock()
+
+	lock := locks.locks[email]
+	lock.holders--
+	if lock.holders == 0 {
+		delete(locks.locks, email)
+	}
+
+	lock.mutex.Unlock()
 }
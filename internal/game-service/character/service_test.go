@@ -0,0 +1,165 @@
+package character
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"gitlab.com/pokesync/game-service/internal/game-service/account"
+	"go.uber.org/zap"
+)
+
+// mapCache is a Cache that holds the profiles of accounts in a map.
+type mapCache struct {
+	mutex    *sync.Mutex
+	profiles map[account.Email][]*Profile
+}
+
+// flakyRepository is a Repository that fails to put profiles for as long
+// as it is told to.
+type flakyRepository struct {
+	*InMemoryRepository
+
+	mutex   *sync.Mutex
+	failing bool
+}
+
+func newMapCache() *mapCache {
+	return &mapCache{mutex: &sync.Mutex{}, profiles: make(map[account.Email][]*Profile)}
+}
+
+func (cache *mapCache) Get(email account.Email) ([]*Profile, error) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	return cache.profiles[email], nil
+}
+
+func (cache *mapCache) Put(email account.Email, profiles []*Profile) error {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	cache.profiles[email] = profiles
+	return nil
+}
+
+func (repo *flakyRepository) Put(email account.Email, profile *Profile) error {
+	repo.mutex.Lock()
+	failing := repo.failing
+	repo.mutex.Unlock()
+
+	if failing {
+		return errors.New("repository is unavailable")
+	}
+
+	return repo.InMemoryRepository.Put(email, profile)
+}
+
+func (repo *flakyRepository) setFailing(failing bool) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	repo.failing = failing
+}
+
+func newTestService(repo Repository) *Service {
+	config := Config{
+		WorkerCount:    1,
+		FlushInterval:  time.Hour,
+		FlushBatchSize: 16,
+		RetryBackoff:   time.Millisecond,
+	}
+
+	return NewService(config, zap.NewNop().Sugar(), newMapCache(), repo)
+}
+
+func TestService_LoadProfilesBeforeFlush(t *testing.T) {
+	repo := NewInMemoryRepository()
+	service := newTestService(repo)
+
+	email := account.Email("sino@gmail.com")
+	service.SaveProfile(email, &Profile{DisplayName: "Sino", PokeDollars: 100})
+
+	result := <-service.LoadProfiles(email)
+	if result.Error != nil {
+		t.Fatal(result.Error)
+	}
+
+	if len(result.Profiles) != 1 || result.Profiles[0].PokeDollars != 100 {
+		t.Error("expected the saved profile to be loaded before it was flushed")
+	}
+
+	if profiles, _ := repo.Get(email); len(profiles) != 0 {
+		t.Error("expected the saved profile not to have been flushed yet")
+	}
+
+	if service.Metrics().CacheMisses != 1 {
+		t.Errorf("expected %v cache miss but was %v instead", 1, service.Metrics().CacheMisses)
+	}
+
+	service.Stop()
+}
+
+func TestService_StopFlushesDirtyProfiles(t *testing.T) {
+	repo := &flakyRepository{InMemoryRepository: NewInMemoryRepository(), mutex: &sync.Mutex{}}
+	service := newTestService(repo)
+
+	email := account.Email("sino@gmail.com")
+	profile := &Profile{DisplayName: "Sino"}
+
+	repo.setFailing(true)
+	service.SaveProfile(email, profile)
+
+	// wait for the worker to pick up the job, as saves are asynchronous
+	<-service.LoadProfiles(email)
+
+	if failures := service.flush(service.writeBehind.take(time.Now(), 0)); failures != 1 {
+		t.Fatalf("expected %v failed flush but was %v instead", 1, failures)
+	}
+
+	repo.setFailing(false)
+	service.Stop()
+
+	if profiles, _ := repo.Get(email); len(profiles) != 1 {
+		t.Error("expected the dirty profile to be flushed when stopping the service")
+	}
+
+	metrics := service.Metrics()
+	if metrics.FailedFlushes != 1 || metrics.FlushedWrites != 1 || metrics.DirtyProfiles != 0 {
+		t.Errorf("unexpected metrics %+v", metrics)
+	}
+}
+
+func TestService_SaveProfileRejectsOlderSnapshot(t *testing.T) {
+	repo := NewInMemoryRepository()
+	service := newTestService(repo)
+
+	email := account.Email("sino@gmail.com")
+	<-service.LoadProfiles(email)
+
+	loggedOut := time.Now()
+	autosaved := loggedOut.Add(-time.Second)
+
+	service.SaveProfile(email, &Profile{DisplayName: "Sino", PokeDollars: 300, SnapshotAt: loggedOut})
+
+	// an autosave that was taken before the logout but arrives late
+	service.SaveProfile(email, &Profile{DisplayName: "Sino", PokeDollars: 100, SnapshotAt: autosaved})
+
+	result := <-service.LoadProfiles(email)
+	if len(result.Profiles) != 1 || result.Profiles[0].PokeDollars != 300 {
+		t.Errorf("expected the cache to keep the newer profile but was %+v", result.Profiles)
+	}
+
+	service.flush(service.writeBehind.take(time.Now(), 0))
+
+	if profiles, _ := repo.Get(email); len(profiles) != 1 || profiles[0].PokeDollars != 300 {
+		t.Errorf("expected the repository to keep the newer profile but was %+v", profiles)
+	}
+
+	if len(service.writeBehind.latest) != 0 {
+		t.Errorf("expected the flushed profile to be forgotten but %v remained", len(service.writeBehind.latest))
+	}
+
+	service.Stop()
+}
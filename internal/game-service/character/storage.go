@@ -1,7 +1,6 @@
 package character
 
 import (
-	"encoding/json"
 	"fmt"
 	"sync"
 	"time"
@@ -12,8 +11,9 @@ import (
 
 // Repository stores account associated player characters.
 type Repository interface {
-	Get(email account.Email) (*Profile, error)
+	Get(email account.Email) ([]*Profile, error)
 	Put(email account.Email, profile *Profile) error
+	Create(email account.Email, profile *Profile) error
 }
 
 // CacheConfig holds configurations specific to the Cache.
@@ -21,9 +21,10 @@ type CacheConfig struct {
 	ExpireAfter time.Duration
 }
 
-// Cache temporarily stores character Profile's.
+// Cache temporarily stores the character Profile's of an account.
 type Cache interface {
-	Repository
+	Get(email account.Email) ([]*Profile, error)
+	Put(email account.Email, profiles []*Profile) error
 }
 
 // RedisCache is a type of Cache that stores character Profile's
@@ -37,14 +38,16 @@ type RedisCache struct {
 // Repository where character profile records are forgotten about
 // once the application's lifecycle ends.
 type InMemoryRepository struct {
-	profiles map[account.Email]*Profile
+	profiles map[account.Email][]*Profile
+	owners   map[string]account.Email
 	mutex    *sync.Mutex
 }
 
 // NewInMemoryRepository constructs a new instance of an InMemoryRepository.
 func NewInMemoryRepository() *InMemoryRepository {
 	return &InMemoryRepository{
-		profiles: make(map[account.Email]*Profile),
+		profiles: make(map[account.Email][]*Profile),
+		owners:   make(map[string]account.Email),
 		mutex:    &sync.Mutex{},
 	}
 }
@@ -57,36 +60,24 @@ func NewRedisCache(config CacheConfig, redisClient *redis.Client) Cache {
 	}
 }
 
-// Get attempts to fetch a character Profile that is stored under the
+// Get attempts to fetch the character Profile's that are stored under the
 // the specified e-mail address.
-func (repo *InMemoryRepository) Get(email account.Email) (*Profile, error) {
+func (repo *InMemoryRepository) Get(email account.Email) ([]*Profile, error) {
 	repo.mutex.Lock()
 	defer repo.mutex.Unlock()
 
-	profile := repo.profiles[email]
-	if profile == nil {
-		profile = &Profile{
-			DisplayName: DisplayName(email),
+	profiles := make([]*Profile, len(repo.profiles[email]))
+	copy(profiles, repo.profiles[email])
 
-			Gender:    0,
-			UserGroup: 5,
-
-			BicycleType: 1,
-
-			MapX:   0,
-			MapZ:   2,
-			LocalX: 60,
-			LocalZ: 40,
-		}
-	}
-
-	return profile, nil
+	return profiles, nil
 }
 
-// Get attempts to fetch a character Profile that is stored under the
-// specified e-mail address. May return an error if something went whilst
-// trying to fetch the Profile from the cache.
-func (cache *RedisCache) Get(email account.Email) (*Profile, error) {
+// Get attempts to fetch the character Profile's that are stored under the
+// specified e-mail address, upgrading them if they were stored in an older
+// version of the storage format. May return an error if something went
+// whilst trying to fetch the Profile's from the cache. Returns nil if nothing is
+// cached for the specified e-mail address.
+func (cache *RedisCache) Get(email account.Email) ([]*Profile, error) {
 	jsonString, err := cache.redisClient.
 		Get(createRedisKey(email)).
 		Result()
@@ -99,26 +90,41 @@ func (cache *RedisCache) Get(email account.Email) (*Profile, error) {
 		return nil, err
 	}
 
-	profile := &Profile{}
-	if err := json.Unmarshal([]byte(jsonString), profile); err != nil {
-		return nil, err
-	}
-
-	return profile, nil
+	return DecodeProfiles([]byte(jsonString))
 }
 
-// Put puts the given Profile under the specified Email into the Repository.
+// Put puts the given Profile under the specified Email into the Repository,
+// overriding any Profile that goes by the same DisplayName.
 func (repo *InMemoryRepository) Put(email account.Email, profile *Profile) error {
 	repo.mutex.Lock()
 	defer repo.mutex.Unlock()
 
-	repo.profiles[email] = profile
+	repo.profiles[email] = withProfile(repo.profiles[email], profile)
+	repo.owners[profile.DisplayName.Key()] = email
+
+	return nil
+}
+
+// Create puts the given Profile under the specified Email into the Repository
+// as a new character. Returns ErrDisplayNameTaken if the Profile's DisplayName
+// is already in use by any other character.
+func (repo *InMemoryRepository) Create(email account.Email, profile *Profile) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	if _, taken := repo.owners[profile.DisplayName.Key()]; taken {
+		return ErrDisplayNameTaken
+	}
+
+	repo.profiles[email] = append(repo.profiles[email], profile)
+	repo.owners[profile.DisplayName.Key()] = email
+
 	return nil
 }
 
-// Put attempts to store the given character Profile into the Cache.
-func (cache *RedisCache) Put(email account.Email, profile *Profile) error {
-	profileBytes, err := json.Marshal(profile)
+// Put attempts to store the given character Profile's into the Cache.
+func (cache *RedisCache) Put(email account.Email, profiles []*Profile) error {
+	profileBytes, err := EncodeProfiles(profiles)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,53 @@
+package character
+
+import (
+	"testing"
+
+	"gitlab.com/pokesync/game-service/internal/game-service/account"
+)
+
+func TestInMemoryRepository_Create(t *testing.T) {
+	repo := NewInMemoryRepository()
+
+	if err := repo.Create(account.Email("sino@gmail.com"), &Profile{DisplayName: "Sino"}); err != nil {
+		t.Error(err)
+	}
+
+	if err := repo.Create(account.Email("sino@gmail.com"), &Profile{DisplayName: "Sinotje"}); err != nil {
+		t.Error(err)
+	}
+
+	profiles, _ := repo.Get(account.Email("sino@gmail.com"))
+	if len(profiles) != 2 {
+		t.Errorf("expected %v profiles but was %v instead", 2, len(profiles))
+	}
+}
+
+func TestInMemoryRepository_CreateDisplayNameTaken(t *testing.T) {
+	repo := NewInMemoryRepository()
+
+	if err := repo.Create(account.Email("sino@gmail.com"), &Profile{DisplayName: "Sino"}); err != nil {
+		t.Error(err)
+	}
+
+	err := repo.Create(account.Email("someone@gmail.com"), &Profile{DisplayName: "sINO"})
+	if err != ErrDisplayNameTaken {
+		t.Error("expected display name to be taken")
+	}
+}
+
+func TestInMemoryRepository_PutOverridesProfile(t *testing.T) {
+	repo := NewInMemoryRepository()
+
+	repo.Create(account.Email("sino@gmail.com"), &Profile{DisplayName: "Sino"})
+	repo.Put(account.Email("sino@gmail.com"), &Profile{DisplayName: "Sino", PokeDollars: 500})
+
+	profiles, _ := repo.Get(account.Email("sino@gmail.com"))
+	if len(profiles) != 1 {
+		t.Errorf("expected %v profiles but was %v instead", 1, len(profiles))
+	}
+
+	if profiles[0].PokeDollars != 500 {
+		t.Error("expected profile to have been overridden")
+	}
+}
@@ -0,0 +1,156 @@
+package character
+
+import (
+	"sync"
+	"time"
+
+	"gitlab.com/pokesync/game-service/internal/game-service/account"
+)
+
+// maxBackoffShift caps the exponential backoff between retries of a
+// failed flush to 2^maxBackoffShift times the configured backoff.
+const maxBackoffShift = 6
+
+// writeBehind buffers saved Profile's that have yet to be flushed to the
+// durable Repository. Safe for concurrent use.
+type writeBehind struct {
+	mutex *sync.Mutex
+	dirty map[dirtyKey]*dirtyProfile
+
+	// latest holds the snapshot time of the last accepted version of each
+	// Profile that has yet to be flushed, including the version that is
+	// being flushed, so that late saves are rejected in the meantime
+	latest map[dirtyKey]time.Time
+}
+
+// dirtyKey identifies a dirty Profile by its owner and DisplayName.
+type dirtyKey struct {
+	email account.Email
+	name  string
+}
+
+// dirtyProfile is a saved Profile that has yet to be flushed.
+type dirtyProfile struct {
+	email   account.Email
+	profile *Profile
+
+	attempts int
+	retryAt  time.Time
+}
+
+// newWriteBehind constructs a new instance of a writeBehind buffer.
+func newWriteBehind() *writeBehind {
+	return &writeBehind{
+		mutex:  &sync.Mutex{},
+		dirty:  make(map[dirtyKey]*dirtyProfile),
+		latest: make(map[dirtyKey]time.Time),
+	}
+}
+
+// mark marks the given Profile as dirty, replacing any older version of
+// the Profile that has yet to be flushed. Returns false if the given Profile
+// is older than a version that was marked before and has yet to be flushed,
+// which happens when saves are taken out of order, in which case the given
+// Profile is discarded.
+func (buffer *writeBehind) mark(email account.Email, profile *Profile) bool {
+	buffer.mutex.Lock()
+	defer buffer.mutex.Unlock()
+
+	key := dirtyKey{email: email, name: profile.DisplayName.Key()}
+	if latest, ok := buffer.latest[key]; ok && isOlder(profile.SnapshotAt, latest) {
+		return false
+	}
+
+	if !profile.SnapshotAt.IsZero() {
+		buffer.latest[key] = profile.SnapshotAt
+	}
+
+	buffer.dirty[key] = &dirtyProfile{email: email, profile: profile}
+	return true
+}
+
+// isOlder returns whether a snapshot taken at the given time was taken
+// before the other snapshot. Snapshots of an unknown time are never older.
+func isOlder(snapshotAt time.Time, other time.Time) bool {
+	if snapshotAt.IsZero() || other.IsZero() {
+		return false
+	}
+
+	return snapshotAt.Before(other)
+}
+
+// profilesOf returns every dirty Profile of the specified account.
+func (buffer *writeBehind) profilesOf(email account.Email) []*Profile {
+	buffer.mutex.Lock()
+	defer buffer.mutex.Unlock()
+
+	var profiles []*Profile
+	for key, entry := range buffer.dirty {
+		if key.email == email {
+			profiles = append(profiles, entry.profile)
+		}
+	}
+
+	return profiles
+}
+
+// take returns up to the specified amount of dirty Profile's that are due
+// for a flush attempt at the given point in time. A limit of zero or less
+// takes every dirty Profile, regardless of whether it is due.
+func (buffer *writeBehind) take(now time.Time, limit int) []*dirtyProfile {
+	buffer.mutex.Lock()
+	defer buffer.mutex.Unlock()
+
+	var batch []*dirtyProfile
+	for _, entry := range buffer.dirty {
+		if limit > 0 && len(batch) >= limit {
+			break
+		}
+
+		if limit > 0 && now.Before(entry.retryAt) {
+			continue
+		}
+
+		batch = append(batch, entry)
+	}
+
+	return batch
+}
+
+// complete removes the given entry from the buffer after it has been
+// flushed, unless a newer version of its Profile was marked in the meantime.
+// The snapshot time of the Profile is forgotten along with it, so that the
+// buffer only ever holds on to Profile's that have yet to be flushed.
+func (buffer *writeBehind) complete(entry *dirtyProfile) {
+	buffer.mutex.Lock()
+	defer buffer.mutex.Unlock()
+
+	key := dirtyKey{email: entry.email, name: entry.profile.DisplayName.Key()}
+	if buffer.dirty[key] == entry {
+		delete(buffer.dirty, key)
+		delete(buffer.latest, key)
+	}
+}
+
+// fail schedules the given entry to be retried after a failed flush, with
+// an exponentially growing backoff.
+func (buffer *writeBehind) fail(entry *dirtyProfile, now time.Time, backoff time.Duration) {
+	buffer.mutex.Lock()
+	defer buffer.mutex.Unlock()
+
+	shift := entry.attempts
+	if shift > maxBackoffShift {
+		shift = maxBackoffShift
+	}
+
+	entry.attempts++
+	entry.retryAt = now.Add(backoff << uint(shift))
+}
+
+// size returns the amount of dirty Profile's in the buffer.
+func (buffer *writeBehind) size() int {
+	buffer.mutex.Lock()
+	defer buffer.mutex.Unlock()
+
+	return len(buffer.dirty)
+}
@@ -85,13 +85,11 @@ func NewService(config Config, logger *zap.SugaredLogger, routing *client.Router
 	return service
 }
 
-// receive receives and handles messages from the specified mailbox.
+// receive receives and handles messages from the specified mailbox,
+// until the mailbox is closed.
 func (service *Service) receive() {
-	for {
-		select {
-		case mail := <-service.mailbox:
-			service.handleMail(mail)
-		}
+	for mail := range service.mailbox {
+		service.handleMail(mail)
 	}
 }
 
@@ -124,9 +122,8 @@ func (service *Service) handleMail(mail client.Mail) {
 	}
 }
 
-// Stop stops this Service and cleans up resources.
+// Stop stops this Service and cleans up resources. The mailbox of this
+// Service is closed along with the Router it is subscribed to.
 func (service *Service) Stop() {
 	// TODO stop all sessions
-
-	close(service.mailbox)
 }
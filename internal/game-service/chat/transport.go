@@ -5,6 +5,10 @@ import (
 	"gitlab.com/pokesync/game-service/pkg/bytes"
 )
 
+// ServerNoticeChannel is the id of the channel that notices of the server
+// itself are displayed in.
+const ServerNoticeChannel byte = 0
+
 var (
 	DisplayChatMessageConfig = client.MessageConfig{
 		Kind:  client.DisplayChatMsg,
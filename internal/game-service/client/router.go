@@ -34,6 +34,7 @@ type Router struct {
 	config    RouterConfig
 	mailboxes map[Topic][]Mailbox
 	mutex     *sync.RWMutex
+	closed    bool
 }
 
 // NewRouter constructs a new instance of Router.
@@ -110,6 +111,28 @@ func (r *Router) Collapse(topic Topic) {
 	delete(r.mailboxes, topic)
 }
 
+// Close collapses every topic, closing every subscriber mailbox once. Mail
+// that is published after the Router is closed, is not delivered.
+func (r *Router) Close() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	// a mailbox may be subscribed to multiple topics
+	closed := make(map[Mailbox]bool)
+	for topic, mailboxes := range r.mailboxes {
+		for _, mailbox := range mailboxes {
+			if !closed[mailbox] {
+				close(mailbox)
+				closed[mailbox] = true
+			}
+		}
+
+		delete(r.mailboxes, topic)
+	}
+
+	r.closed = true
+}
+
 // TopicCount returns the amount of topics that subscribers are subscribed to.
 func (r *Router) TopicCount() int {
 	r.mutex.RLock()
@@ -133,6 +156,10 @@ func (r *Router) Broadcast(mail Mail) {
 	r.mutex.RLock()
 	defer r.mutex.RUnlock()
 
+	if r.closed {
+		return
+	}
+
 	for _, recipients := range r.mailboxes {
 		for _, mailbox := range recipients {
 			select {
@@ -153,6 +180,10 @@ func (r *Router) Publish(topic Topic, mail Mail) bool {
 	r.mutex.RLock()
 	defer r.mutex.RUnlock()
 
+	if r.closed {
+		return false
+	}
+
 	mailboxes := r.mailboxes[topic]
 	for _, mailbox := range mailboxes {
 		select {
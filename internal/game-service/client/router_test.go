@@ -100,3 +100,24 @@ func TestRouter_Unsubscribe(t *testing.T) {
 		t.Error("expected topic count to equal 0")
 	}
 }
+
+func TestRouter_Close(t *testing.T) {
+	router := NewRouter(config)
+
+	m := router.Subscribe("hello")
+	router.SubscribeMailboxToTopic("world", m)
+
+	router.Close()
+
+	if _, open := <-m; open {
+		t.Error("expected mailbox to be closed")
+	}
+
+	if router.TopicCount() != 0 {
+		t.Error("expected topic count to equal 0")
+	}
+
+	if router.Publish("hello", Mail{}) {
+		t.Error("expected mail not to be delivered after the router was closed")
+	}
+}
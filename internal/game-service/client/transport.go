@@ -25,26 +25,64 @@ const (
 	SubmitChatCmd      PacketKind = 13
 	ClickTeleport      PacketKind = 14
 	SelectChatChannel  PacketKind = 15
+	ResumeSession      PacketKind = 16
+	ChooseBattleMove   PacketKind = 17
+	SwitchBattleMon    PacketKind = 18
+	UseBattleItem      PacketKind = 19
+	FleeBattle         PacketKind = 20
+	AnswerEvolution    PacketKind = 21
+	BrowsePCBox        PacketKind = 22
+	DepositPCMonster   PacketKind = 23
+	WithdrawPCMonster  PacketKind = 24
+	MovePCMonster      PacketKind = 25
+	ReleasePCMonster   PacketKind = 26
+	ClosePC            PacketKind = 27
+	OfferTradeMonster  PacketKind = 28
+	RetractTradeOffer  PacketKind = 29
+	OfferTradeDollars  PacketKind = 30
+	AcceptTrade        PacketKind = 31
+	DeclineTrade       PacketKind = 32
+	MoveInventoryItem  PacketKind = 33
+	AnswerMove         PacketKind = 34
+	UseItem            PacketKind = 35
 
 	// Server -> Client
-	MapRefresh           PacketKind = 238
-	SwitchChatChannel    PacketKind = 239
-	SetServerTime        PacketKind = 240
-	SetPokeDollar        PacketKind = 241
-	SetPartySlot         PacketKind = 242
-	SetDonatorPoints     PacketKind = 243
-	ResetOrthoCamera     PacketKind = 244
-	MoveOrthoCamera      PacketKind = 245
-	EntityUpdate         PacketKind = 246
-	CloseDialogue        PacketKind = 247
-	DisplayChatMsg       PacketKind = 248
-	WorldFull            PacketKind = 249
-	LoginRequestTimedOut PacketKind = 250
-	UnableToFetchProfile PacketKind = 251
-	InvalidCredentials   PacketKind = 252
-	AlreadyLoggedIn      PacketKind = 253
-	AccountDisabled      PacketKind = 254
-	LoginSuccess         PacketKind = 255
+	OfferMove               PacketKind = 220
+	SetInventorySlot        PacketKind = 221
+	TeleportAvatar          PacketKind = 222
+	CloseTrade              PacketKind = 223
+	SetTradeStage           PacketKind = 224
+	SetTradeOffer           PacketKind = 225
+	OpenTrade               PacketKind = 226
+	TradeRequest            PacketKind = 227
+	SetPCSlot               PacketKind = 228
+	PCBoxContents           PacketKind = 229
+	OfferEvolution          PacketKind = 230
+	EndBattle               PacketKind = 231
+	BattleTurnResult        PacketKind = 232
+	StartBattle             PacketKind = 233
+	LoginQueuePosition      PacketKind = 234
+	InvalidEmail            PacketKind = 235
+	RejectCharacterCreation PacketKind = 236
+	CharacterList           PacketKind = 237
+	MapRefresh              PacketKind = 238
+	SwitchChatChannel       PacketKind = 239
+	SetServerTime           PacketKind = 240
+	SetPokeDollar           PacketKind = 241
+	SetPartySlot            PacketKind = 242
+	SetDonatorPoints        PacketKind = 243
+	ResetOrthoCamera        PacketKind = 244
+	MoveOrthoCamera         PacketKind = 245
+	EntityUpdate            PacketKind = 246
+	CloseDialogue           PacketKind = 247
+	DisplayChatMsg          PacketKind = 248
+	WorldFull               PacketKind = 249
+	LoginRequestTimedOut    PacketKind = 250
+	UnableToFetchProfile    PacketKind = 251
+	InvalidCredentials      PacketKind = 252
+	AlreadyLoggedIn         PacketKind = 253
+	AccountDisabled         PacketKind = 254
+	LoginSuccess            PacketKind = 255
 )
 
 // PacketKind is a kind of packet.
@@ -0,0 +1,100 @@
+package game
+
+import (
+	"time"
+
+	"gitlab.com/pokesync/game-service/internal/game-service/account"
+	"gitlab.com/pokesync/game-service/internal/game-service/character"
+	"gitlab.com/pokesync/game-service/internal/game-service/game/entity"
+)
+
+// autosaveRate is the rate at which the AutosaveProcessor looks for
+// players that are due for a save.
+const autosaveRate = 1 * time.Second
+
+// ProfileSnapshotter takes a snapshot of a Player's character Profile.
+type ProfileSnapshotter func(player *Player) *character.Profile
+
+// AutosaveProcessor periodically saves the character profiles of every
+// online player. Players are spread out over the autosave interval by
+// their entity ID, so that not every player is saved within the same pulse.
+type AutosaveProcessor struct {
+	snapshotter ProfileSnapshotter
+	saver       CharacterSaver
+
+	buckets int
+	bucket  int
+}
+
+// snapshot is a character Profile of a player awaiting to be saved.
+type snapshot struct {
+	email   account.Email
+	profile *character.Profile
+}
+
+// NewAutosaveSystem constructs a System that saves the character profile
+// of every online player once every specified interval.
+func NewAutosaveSystem(interval time.Duration, snapshotter ProfileSnapshotter, saver CharacterSaver) *entity.System {
+	return entity.NewSystem(entity.NewIntervalPolicy(autosaveRate), NewAutosaveProcessor(interval, snapshotter, saver))
+}
+
+// NewAutosaveProcessor constructs a new instance of an AutosaveProcessor.
+func NewAutosaveProcessor(interval time.Duration, snapshotter ProfileSnapshotter, saver CharacterSaver) *AutosaveProcessor {
+	buckets := int(interval / autosaveRate)
+	if buckets < 1 {
+		buckets = 1
+	}
+
+	return &AutosaveProcessor{snapshotter: snapshotter, saver: saver, buckets: buckets}
+}
+
+// AddedToWorld is called when the System of this Processor is added
+// to the game World.
+func (processor *AutosaveProcessor) AddedToWorld(world *entity.World) error {
+	return nil
+}
+
+// RemovedFromWorld is called when the System of this Processor is removed
+// from the game World.
+func (processor *AutosaveProcessor) RemovedFromWorld(world *entity.World) error {
+	return nil
+}
+
+// Update is called every autosave pulse to snapshot the character profiles
+// of the players that are due for a save. The snapshots are taken on the
+// game goroutine but saved on a separate one, to not hold up the game.
+func (processor *AutosaveProcessor) Update(world *entity.World, deltaTime time.Duration) error {
+	var snapshots []snapshot
+	for _, ent := range world.GetEntitiesFor(processor) {
+		if int(ent.ID)%processor.buckets != processor.bucket {
+			continue
+		}
+
+		session := ent.GetComponent(SessionTag).(*SessionComponent).session
+		snapshots = append(snapshots, snapshot{
+			email:   session.Email,
+			profile: processor.snapshotter(PlayerBy(ent)),
+		})
+	}
+
+	processor.bucket = (processor.bucket + 1) % processor.buckets
+
+	if len(snapshots) > 0 {
+		go processor.save(snapshots)
+	}
+
+	return nil
+}
+
+// save hands the given snapshots over to the CharacterSaver.
+func (processor *AutosaveProcessor) save(snapshots []snapshot) {
+	for _, snapshot := range snapshots {
+		processor.saver(snapshot.email, snapshot.profile)
+	}
+}
+
+// Components returns a pack of ComponentTag's the AutosaveProcessor has
+// interest in.
+func (processor *AutosaveProcessor) Components() entity.ComponentTag {
+	return SessionTag
+}
@@ -0,0 +1,41 @@
+package game
+
+import (
+	"gitlab.com/pokesync/game-service/internal/game-service/account"
+)
+
+// ban bans the user of the given Player. Its account is disabled, every
+// session token that was issued to it is revoked and it is logged out
+// right away, without lingering.
+func (service *Service) ban(plr *Player) {
+	component, ok := plr.GetComponent(SessionTag).(*SessionComponent)
+	if !ok {
+		return
+	}
+
+	session := component.session
+	if cl := session.Client(); cl != nil {
+		service.sessions.Remove(cl.ID)
+	} else {
+		service.lingering.Remove(session.Email)
+	}
+
+	plr.ForfeitBattle()
+	plr.DeclineTrade()
+
+	go service.disableAccount(session.Email)
+
+	service.logout(session)
+	session.Terminate()
+}
+
+// disableAccount disables the account of the specified Email and revokes
+// every session token that was issued to it, for the user to neither log
+// in nor resume a session with it anymore.
+func (service *Service) disableAccount(email account.Email) {
+	service.config.DisableAccount(email)
+
+	if err := service.config.TokenIssuer.RevokeAll(email); err != nil {
+		service.logger.Error(err)
+	}
+}
@@ -0,0 +1,317 @@
+package battle
+
+import "gitlab.com/pokesync/game-service/internal/game-service/game"
+
+const (
+	// switchPriority is the priority at which switching monsters is
+	// performed, which is before any move is used.
+	switchPriority = 6
+
+	// itemPriority is the priority at which items are used, which is
+	// alongside switching monsters.
+	itemPriority = 6
+
+	// fleePriority is the priority at which fleeing is performed, which
+	// is before anything else.
+	fleePriority = 7
+)
+
+// Action is an action a Side performs during a turn.
+type Action interface{}
+
+// UseMove is an Action to have the active monster use the move in the
+// specified slot of its known moves. A monster that has no power points
+// left for any of its moves, struggles instead.
+type UseMove struct {
+	Slot int
+}
+
+// Switch is an Action to switch the active monster for the monster in the
+// specified slot of the party.
+type Switch struct {
+	Slot int
+}
+
+// Flee is an Action to attempt to flee from a wild Battle.
+type Flee struct{}
+
+// priorityOf returns the priority at which the specified Side performs
+// the given Action.
+func (battle *Battle) priorityOf(id SideID, action Action) int {
+	switch a := action.(type) {
+	case Flee:
+		return fleePriority
+	case Switch:
+		return switchPriority
+	case ThrowBall:
+		return itemPriority
+	case UseMove:
+		return battle.moveOf(id, a.Slot).Priority
+	default:
+		return 0
+	}
+}
+
+// moveOf returns the descriptor of the move in the specified slot of the
+// active monster of the specified Side, or the descriptor of struggling
+// if the monster has no power points left for any of its moves.
+func (battle *Battle) moveOf(id SideID, slot int) *game.MoveDescriptor {
+	side := battle.sides[id]
+
+	monster := side.Party[side.active]
+	if !hasUsableMoves(monster) {
+		return &struggle
+	}
+
+	return battle.config.Moves.Get(monster.Moves[slot].MoveID)
+}
+
+// useMove has the active monster of the specified Side use the move in
+// the specified slot against the active monster of the other side.
+func (battle *Battle) useMove(id SideID, slot int) {
+	attacker, defender := battle.active(id), battle.active(other(id))
+	if defender.monster.HitPoints <= 0 {
+		return
+	}
+
+	if !battle.canMove(attacker) {
+		return
+	}
+
+	move := battle.moveOf(id, slot)
+	if move != &struggle {
+		attacker.monster.Moves[slot].PP--
+	}
+
+	battle.record(Event{Kind: MoveUsed, Side: id, Slot: attacker.slot, Move: move.ID})
+
+	if !battle.hits(attacker, move) {
+		battle.record(Event{Kind: MoveMissed, Side: id, Slot: attacker.slot, Move: move.ID})
+		return
+	}
+
+	dealt := 0
+	if move.Category != game.Status && move.Power > 0 {
+		damage := battle.calculateDamage(attacker, defender, move)
+		if damage.amount > defender.monster.HitPoints {
+			damage.amount = defender.monster.HitPoints
+		}
+
+		battle.record(Event{
+			Kind:          Damaged,
+			Side:          defender.id,
+			Slot:          defender.slot,
+			Move:          move.ID,
+			Amount:        damage.amount,
+			Effectiveness: damage.effectiveness,
+			Critical:      damage.critical,
+		})
+
+		if damage.effectiveness == 0 {
+			return
+		}
+
+		dealt = damage.amount
+		battle.hurt(defender, dealt)
+	}
+
+	if move.EffectID != game.NoEffect && battle.random.Intn(100) < move.EffectChance {
+		battle.applyEffect(attacker, defender, move, dealt)
+	}
+}
+
+// canMove rolls whether the given combatant is able to move this turn,
+// given its status condition and whether it flinched.
+func (battle *Battle) canMove(attacker *combatant) bool {
+	monster := attacker.monster
+
+	if attacker.side.flinched {
+		battle.record(Event{Kind: Flinched, Side: attacker.id, Slot: attacker.slot})
+		return false
+	}
+
+	switch monster.StatusCondition {
+	case game.Asleep:
+		// a monster that fell asleep before the battle, sleeps for as
+		// long as a monster that fell asleep during the battle
+		if attacker.side.sleepTurns[attacker.slot] <= 0 {
+			attacker.side.sleepTurns[attacker.slot] = battle.rollSleepTurns()
+		}
+
+		attacker.side.sleepTurns[attacker.slot]--
+		if attacker.side.sleepTurns[attacker.slot] > 0 {
+			battle.record(Event{Kind: CannotMove, Side: attacker.id, Slot: attacker.slot, Status: game.Asleep})
+			return false
+		}
+
+		battle.cure(attacker)
+
+	case game.Frozen:
+		if battle.random.Intn(5) != 0 {
+			battle.record(Event{Kind: CannotMove, Side: attacker.id, Slot: attacker.slot, Status: game.Frozen})
+			return false
+		}
+
+		battle.cure(attacker)
+
+	case game.Paralyzed:
+		if battle.random.Intn(4) == 0 {
+			battle.record(Event{Kind: CannotMove, Side: attacker.id, Slot: attacker.slot, Status: game.Paralyzed})
+			return false
+		}
+	}
+
+	return true
+}
+
+// applyEffect applies the secondary effect of the described move, used
+// by the attacking combatant, that dealt the given amount of damage.
+func (battle *Battle) applyEffect(attacker, defender *combatant, move *game.MoveDescriptor, dealt int) {
+	switch move.EffectID {
+	case game.LowerAttack:
+		battle.changeStage(defender, game.Attack, -1)
+	case game.LowerDefence:
+		battle.changeStage(defender, game.Defence, -1)
+	case game.LowerSpeed:
+		battle.changeStage(defender, game.Speed, -1)
+	case game.LowerAccuracy:
+		battle.changeStage(defender, Accuracy, -1)
+	case game.RaiseAttack:
+		battle.changeStage(attacker, game.Attack, 2)
+	case game.RaiseDefence:
+		battle.changeStage(attacker, game.Defence, 1)
+	case game.BurnEffect:
+		battle.inflict(defender, game.Burnt)
+	case game.PoisonEffect:
+		battle.inflict(defender, game.Poisoned)
+	case game.ParalyzeEffect:
+		battle.inflict(defender, game.Paralyzed)
+	case game.SleepEffect:
+		battle.inflict(defender, game.Asleep)
+	case game.FlinchEffect:
+		// only a monster that has yet to move this turn can flinch
+		if defender.monster.HitPoints > 0 && !defender.side.acted {
+			defender.side.flinched = true
+		}
+	case game.RecoilEffect:
+		if dealt > 0 && attacker.monster.HitPoints > 0 {
+			recoil := dealt / 4
+			if recoil < 1 {
+				recoil = 1
+			}
+
+			battle.record(Event{Kind: RecoilDamaged, Side: attacker.id, Slot: attacker.slot, Amount: recoil})
+			battle.hurt(attacker, recoil)
+		}
+	}
+}
+
+// changeStage raises or lowers the specified stat of the given combatant
+// by the given amount of stages.
+func (battle *Battle) changeStage(target *combatant, stat game.Stat, amount int) {
+	if target.monster.HitPoints <= 0 {
+		return
+	}
+
+	changed := target.side.stages.raise(stat, amount)
+	switch {
+	case changed > 0:
+		battle.record(Event{Kind: StatRaised, Side: target.id, Slot: target.slot, Stat: stat, Amount: changed})
+	case changed < 0:
+		battle.record(Event{Kind: StatLowered, Side: target.id, Slot: target.slot, Stat: stat, Amount: -changed})
+	}
+}
+
+// inflict inflicts the given status condition upon the given combatant,
+// unless it already suffers from one.
+func (battle *Battle) inflict(target *combatant, status game.StatusCondition) {
+	if target.monster.HitPoints <= 0 || target.monster.StatusCondition != game.Healthy {
+		return
+	}
+
+	target.monster.StatusCondition = status
+	if status == game.Asleep {
+		target.side.sleepTurns[target.slot] = battle.rollSleepTurns()
+	}
+
+	battle.record(Event{Kind: StatusInflicted, Side: target.id, Slot: target.slot, Status: status})
+}
+
+// rollSleepTurns rolls the amount of turns a monster that falls asleep
+// is unable to move for, plus the turn in which it wakes up.
+func (battle *Battle) rollSleepTurns() int {
+	return 2 + battle.random.Intn(3)
+}
+
+// cure cures the given combatant of its status condition.
+func (battle *Battle) cure(target *combatant) {
+	status := target.monster.StatusCondition
+	target.monster.StatusCondition = game.Healthy
+
+	battle.record(Event{Kind: StatusCured, Side: target.id, Slot: target.slot, Status: status})
+}
+
+// hurt deals the given amount of damage to the given combatant, which
+// faints if it runs out of health.
+func (battle *Battle) hurt(target *combatant, amount int) {
+	target.monster.HitPoints -= amount
+	if target.monster.HitPoints > 0 {
+		return
+	}
+
+	target.monster.HitPoints = 0
+	battle.record(Event{Kind: Fainted, Side: target.id, Slot: target.slot})
+
+	battle.awardExperience(other(target.id), target)
+}
+
+// awardExperience awards the monsters of the specified Side that faced the
+// given fainted combatant with experience, split evenly between those
+// that have not fainted themselves.
+func (battle *Battle) awardExperience(id SideID, fainted *combatant) {
+	side := battle.sides[id]
+	if !side.EarnsExperience {
+		return
+	}
+
+	var participants []int
+	for slot := range side.Party {
+		if side.participants[slot] && side.Party[slot].HitPoints > 0 {
+			participants = append(participants, slot)
+		}
+	}
+
+	if len(participants) == 0 {
+		return
+	}
+
+	experience := fainted.descriptor.BaseExp * fainted.monster.Level / 7
+	if battle.kind == TrainerBattle {
+		experience = experience * 3 / 2
+	}
+
+	experience /= len(participants)
+	if experience < 1 {
+		experience = 1
+	}
+
+	for _, slot := range participants {
+		monster := side.Party[slot]
+		descriptor := battle.config.Monsters.Get(monster.ModelID)
+
+		battle.record(Event{Kind: ExperienceGained, Side: id, Slot: slot, Amount: experience})
+
+		levelUp := monster.GainExperience(descriptor, battle.config.Moves, experience)
+		if levelUp.LevelsGained > 0 {
+			battle.record(Event{Kind: LevelledUp, Side: id, Slot: slot, Amount: monster.Level})
+		}
+
+		for _, move := range levelUp.Learnt {
+			battle.record(Event{Kind: MoveLearnt, Side: id, Slot: slot, Move: move})
+		}
+
+		for _, move := range levelUp.Pending {
+			battle.record(Event{Kind: MoveNotLearnt, Side: id, Slot: slot, Move: move})
+		}
+	}
+}
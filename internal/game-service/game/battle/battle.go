@@ -0,0 +1,606 @@
+// Package battle implements turn-based battles between monsters. A Battle
+// operates directly on the game.MonsterData of the monsters that take part
+// in it, which is how its results are reported back to the game: health,
+// status conditions, power points and experience are left behind on the
+// monsters once the Battle is over.
+package battle
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+
+	"gitlab.com/pokesync/game-service/internal/game-service/game"
+)
+
+// These are the sides that take part in a Battle.
+const (
+	Challenger SideID = 0
+	Opponent   SideID = 1
+)
+
+// These are the kinds of Battle's that can be fought.
+const (
+	WildBattle    Kind = 0
+	TrainerBattle Kind = 1
+)
+
+// These are the outcomes of a Battle.
+const (
+	Ongoing       Outcome = 0
+	ChallengerWon Outcome = 1
+	OpponentWon   Outcome = 2
+	Fled          Outcome = 3
+	Captured      Outcome = 4
+)
+
+var (
+	// ErrNoUsableMonsters is returned when attempting to battle with a
+	// side of which every monster has fainted.
+	ErrNoUsableMonsters = errors.New("side has no monsters that are able to battle")
+
+	// ErrUnknownMonster is returned when attempting to battle with a
+	// monster that has no MonsterDescriptor.
+	ErrUnknownMonster = errors.New("monster is unknown")
+
+	// ErrFinished is returned when attempting to continue a Battle that
+	// has already finished.
+	ErrFinished = errors.New("battle has already finished")
+
+	// ErrMustReplace is returned when attempting to play a turn whilst a
+	// side has yet to replace its fainted monster.
+	ErrMustReplace = errors.New("a fainted monster has yet to be replaced")
+
+	// ErrNoReplacementNeeded is returned when attempting to replace the
+	// active monster of a side of which the active monster has not fainted.
+	ErrNoReplacementNeeded = errors.New("active monster has not fainted")
+
+	// ErrInvalidMove is returned when attempting to use a move the active
+	// monster does not know or has no power points left for.
+	ErrInvalidMove = errors.New("move can not be used")
+
+	// ErrInvalidSwitch is returned when attempting to switch to a monster
+	// that is either already active, has fainted or does not exist.
+	ErrInvalidSwitch = errors.New("can not switch to monster")
+
+	// ErrCannotFlee is returned when attempting to flee from a Battle
+	// that can not be fled from.
+	ErrCannotFlee = errors.New("can not flee from this battle")
+
+	// ErrUnknownAction is returned when given an Action of an unknown type.
+	ErrUnknownAction = errors.New("unknown action")
+)
+
+// StruggleID is the MoveID that is logged for a monster that struggles,
+// which is not the id of any configured move.
+const StruggleID game.MoveID = -1
+
+// struggle is the move a monster uses when it has no power points left
+// for any of its moves.
+var struggle = game.MoveDescriptor{
+	ID:           StruggleID,
+	Name:         "STRUGGLE",
+	Type:         "NORMAL",
+	Category:     game.Physical,
+	Power:        50,
+	EffectID:     game.RecoilEffect,
+	EffectChance: 100,
+}
+
+// SideID identifies one of the two sides of a Battle.
+type SideID int
+
+// Kind is a kind of Battle.
+type Kind int
+
+// Outcome is the outcome of a Battle.
+type Outcome int
+
+// Config holds the assets a Battle requires. Evolutions are offered to
+// the monsters that levelled up in a Battle once it is over, unless it is
+// nil. A player of whom every monster fainted is handed to Respawn once
+// the Battle is over, unless it is nil.
+type Config struct {
+	Monsters   *game.MonsterConfig
+	Moves      *game.MoveConfig
+	Items      *game.ItemConfig
+	TypeChart  TypeChart
+	Evolutions *game.Evolutions
+	Respawn    func(plr *game.Player)
+}
+
+// Result is the result of a Battle.
+type Result struct {
+	Outcome Outcome
+	Turns   int
+}
+
+// Side is one of the two sides that take part in a Battle, fighting with
+// its party of monsters.
+type Side struct {
+	Party []*game.MonsterData
+
+	// EarnsExperience is whether the monsters of this Side gain experience
+	// from defeating the monsters of the other side.
+	EarnsExperience bool
+
+	active       int
+	stages       Stages
+	sleepTurns   []int
+	participants map[int]bool
+	fleeAttempts int
+
+	acted       bool
+	flinched    bool
+	mustReplace bool
+}
+
+// Battle is a turn-based battle between two Side's. A Battle is
+// deterministic given the seed it was created with. Not safe for
+// concurrent use.
+type Battle struct {
+	config Config
+	kind   Kind
+	random *rand.Rand
+
+	sides [2]*Side
+	turns int
+	log   []Event
+
+	outcome Outcome
+}
+
+// combatant is the active monster of a Side.
+type combatant struct {
+	id         SideID
+	side       *Side
+	slot       int
+	monster    *game.MonsterData
+	descriptor *game.MonsterDescriptor
+	stats      game.Stats
+}
+
+// NewSide constructs a new Side that battles with the given party of
+// monsters.
+func NewSide(party []*game.MonsterData) *Side {
+	return &Side{
+		Party:        party,
+		sleepTurns:   make([]int, len(party)),
+		participants: make(map[int]bool),
+	}
+}
+
+// SideOf constructs a new Side that battles with the monsters of the given
+// PartyBelt and earns experience doing so.
+func SideOf(belt *game.PartyBelt) *Side {
+	party := make([]*game.MonsterData, 0, belt.Size)
+	for slot := 0; slot < belt.Size; slot++ {
+		if monster, err := belt.Get(slot); err == nil && monster != nil {
+			party = append(party, monster.MonsterData)
+		}
+	}
+
+	side := NewSide(party)
+	side.EarnsExperience = true
+
+	return side
+}
+
+// New constructs a new Battle between the given Side's, of which the
+// outcome is determined by the given seed. The first monster of each
+// Side that has not fainted is sent out first.
+func New(config Config, kind Kind, seed int64, challenger, opponent *Side) (*Battle, error) {
+	battle := &Battle{
+		config: config,
+		kind:   kind,
+		random: rand.New(rand.NewSource(seed)),
+		sides:  [2]*Side{challenger, opponent},
+	}
+
+	if battle.config.TypeChart == nil {
+		battle.config.TypeChart = NeutralTypeChart{}
+	}
+
+	for id, side := range battle.sides {
+		for _, monster := range side.Party {
+			if config.Monsters.Get(monster.ModelID) == nil {
+				return nil, ErrUnknownMonster
+			}
+		}
+
+		slot := side.firstUsable()
+		if slot < 0 {
+			return nil, ErrNoUsableMonsters
+		}
+
+		battle.sendOut(SideID(id), slot)
+	}
+
+	return battle, nil
+}
+
+// Side returns the Side of the specified SideID.
+func (battle *Battle) Side(id SideID) *Side {
+	return battle.sides[id]
+}
+
+// Active returns the party slot of the active monster of the Side.
+func (side *Side) Active() int {
+	return side.active
+}
+
+// MustReplace returns whether the active monster of the Side fainted and
+// has yet to be replaced.
+func (side *Side) MustReplace() bool {
+	return side.mustReplace
+}
+
+// firstUsable returns the first party slot of the Side of which the monster
+// has not fainted, or -1 if every monster has fainted.
+func (side *Side) firstUsable() int {
+	for slot, monster := range side.Party {
+		if monster.HitPoints > 0 {
+			return slot
+		}
+	}
+
+	return -1
+}
+
+// Kind returns the Kind of Battle.
+func (battle *Battle) Kind() Kind {
+	return battle.kind
+}
+
+// Log returns every Event that occurred in the Battle so far.
+func (battle *Battle) Log() []Event {
+	return battle.log
+}
+
+// Finished returns whether the Battle has finished.
+func (battle *Battle) Finished() bool {
+	return battle.outcome != Ongoing
+}
+
+// Result returns the Result of the Battle, of which the Outcome is Ongoing
+// for as long as the Battle has not finished.
+func (battle *Battle) Result() Result {
+	return Result{Outcome: battle.outcome, Turns: battle.turns}
+}
+
+// Turn plays out a single turn in which both sides perform the given
+// Action's. Returns the Event's that occurred during the turn.
+func (battle *Battle) Turn(challenger, opponent Action) ([]Event, error) {
+	if battle.Finished() {
+		return nil, ErrFinished
+	}
+
+	actions := [2]Action{challenger, opponent}
+	for id, action := range actions {
+		if battle.sides[id].mustReplace {
+			return nil, ErrMustReplace
+		}
+
+		if err := battle.validate(SideID(id), action); err != nil {
+			return nil, err
+		}
+	}
+
+	start := len(battle.log)
+	battle.turns++
+
+	for _, side := range battle.sides {
+		side.acted = false
+		side.flinched = false
+	}
+
+	for _, id := range battle.order(actions) {
+		if battle.Finished() {
+			break
+		}
+
+		// a monster that fainted before its turn came up, does not
+		// get to perform its action
+		if battle.active(id).monster.HitPoints <= 0 {
+			continue
+		}
+
+		battle.perform(id, actions[id])
+		battle.sides[id].acted = true
+	}
+
+	if !battle.Finished() {
+		battle.endTurn()
+	}
+
+	return battle.log[start:], nil
+}
+
+// Replace sends out the monster in the specified party slot in place of
+// the fainted active monster of the specified Side. Returns the Event's
+// that occurred as a result.
+func (battle *Battle) Replace(id SideID, slot int) ([]Event, error) {
+	if battle.Finished() {
+		return nil, ErrFinished
+	}
+
+	side := battle.sides[id]
+	if !side.mustReplace {
+		return nil, ErrNoReplacementNeeded
+	}
+
+	if err := battle.validate(id, Switch{Slot: slot}); err != nil {
+		return nil, err
+	}
+
+	start := len(battle.log)
+
+	side.mustReplace = false
+	battle.sendOut(id, slot)
+
+	return battle.log[start:], nil
+}
+
+// Forfeit ends the Battle in favour of the side opposing the specified
+// Side. Returns the Event's that occurred as a result.
+func (battle *Battle) Forfeit(id SideID) ([]Event, error) {
+	if battle.Finished() {
+		return nil, ErrFinished
+	}
+
+	start := len(battle.log)
+
+	side := battle.sides[id]
+	battle.record(Event{Kind: Forfeited, Side: id, Slot: side.active})
+
+	if id == Challenger {
+		battle.outcome = OpponentWon
+	} else {
+		battle.outcome = ChallengerWon
+	}
+
+	return battle.log[start:], nil
+}
+
+// RandomMove chooses a random move for the active monster of the specified
+// Side to use, out of the moves it has power points left for.
+func (battle *Battle) RandomMove(id SideID) Action {
+	monster := battle.active(id).monster
+
+	var usable []int
+	for slot, move := range monster.Moves {
+		if move.PP > 0 {
+			usable = append(usable, slot)
+		}
+	}
+
+	if len(usable) == 0 {
+		return UseMove{Slot: 0}
+	}
+
+	return UseMove{Slot: usable[battle.random.Intn(len(usable))]}
+}
+
+// active returns the active monster of the specified Side.
+func (battle *Battle) active(id SideID) *combatant {
+	side := battle.sides[id]
+	monster := side.Party[side.active]
+	descriptor := battle.config.Monsters.Get(monster.ModelID)
+
+	return &combatant{
+		id:         id,
+		side:       side,
+		slot:       side.active,
+		monster:    monster,
+		descriptor: descriptor,
+		stats:      monster.Stats(descriptor),
+	}
+}
+
+// other returns the SideID of the side opposing the specified Side.
+func other(id SideID) SideID {
+	return 1 - id
+}
+
+// sendOut makes the monster in the specified party slot of the specified
+// Side its active monster.
+func (battle *Battle) sendOut(id SideID, slot int) {
+	side := battle.sides[id]
+
+	side.active = slot
+	side.stages = Stages{}
+	side.participants[slot] = true
+
+	// the monsters of the other side have yet to face the new monster
+	opposing := battle.sides[other(id)]
+	opposing.participants = map[int]bool{opposing.active: true}
+
+	battle.record(Event{Kind: SwitchedIn, Side: id, Slot: slot})
+}
+
+// record appends the given Event to the log of the Battle.
+func (battle *Battle) record(event Event) {
+	battle.log = append(battle.log, event)
+}
+
+// order returns the order in which the sides perform the given Action's.
+func (battle *Battle) order(actions [2]Action) []SideID {
+	order := []SideID{Challenger, Opponent}
+
+	// the tie breaker is rolled up front, for the amount of random
+	// numbers drawn to not depend on the actions that were chosen
+	challengerFirst := battle.random.Intn(2) == 0
+
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := order[i], order[j]
+
+		priorityA, priorityB := battle.priorityOf(a, actions[a]), battle.priorityOf(b, actions[b])
+		if priorityA != priorityB {
+			return priorityA > priorityB
+		}
+
+		speedA, speedB := battle.speedOf(a), battle.speedOf(b)
+		if speedA != speedB {
+			return speedA > speedB
+		}
+
+		return (a == Challenger) == challengerFirst
+	})
+
+	return order
+}
+
+// speedOf returns the effective speed of the active monster of the
+// specified Side.
+func (battle *Battle) speedOf(id SideID) int {
+	active := battle.active(id)
+
+	speed := applyStage(active.stats[game.Speed], active.side.stages[game.Speed])
+	if active.monster.StatusCondition == game.Paralyzed {
+		speed /= 2
+	}
+
+	return speed
+}
+
+// validate checks if the given Action can be performed by the specified
+// Side. If not, it returns an error.
+func (battle *Battle) validate(id SideID, action Action) error {
+	side := battle.sides[id]
+
+	switch a := action.(type) {
+	case UseMove:
+		monster := side.Party[side.active]
+		if !hasUsableMoves(monster) {
+			return nil // struggles instead
+		}
+
+		if a.Slot < 0 || a.Slot >= len(monster.Moves) || monster.Moves[a.Slot].PP <= 0 {
+			return ErrInvalidMove
+		}
+
+		if battle.config.Moves.Get(monster.Moves[a.Slot].MoveID) == nil {
+			return ErrInvalidMove
+		}
+
+		return nil
+
+	case Switch:
+		if a.Slot < 0 || a.Slot >= len(side.Party) || a.Slot == side.active || side.Party[a.Slot].HitPoints <= 0 {
+			return ErrInvalidSwitch
+		}
+
+		return nil
+
+	case Flee:
+		if battle.kind != WildBattle {
+			return ErrCannotFlee
+		}
+
+		return nil
+
+	case ThrowBall:
+		if battle.kind != WildBattle {
+			return ErrCannotCapture
+		}
+
+		return nil
+
+	default:
+		return ErrUnknownAction
+	}
+}
+
+// hasUsableMoves returns whether the given monster has power points left
+// for any of its moves.
+func hasUsableMoves(monster *game.MonsterData) bool {
+	for _, move := range monster.Moves {
+		if move.PP > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// perform has the specified Side perform the given Action.
+func (battle *Battle) perform(id SideID, action Action) {
+	switch a := action.(type) {
+	case UseMove:
+		battle.useMove(id, a.Slot)
+	case Switch:
+		battle.sendOut(id, a.Slot)
+	case Flee:
+		battle.flee(id)
+	case ThrowBall:
+		battle.throwBall(id, a.Modifier)
+	}
+}
+
+// flee has the specified Side attempt to flee from the Battle.
+func (battle *Battle) flee(id SideID) {
+	side := battle.sides[id]
+	side.fleeAttempts++
+
+	speed, opposingSpeed := battle.speedOf(id), battle.speedOf(other(id))
+	if opposingSpeed < 1 {
+		opposingSpeed = 1
+	}
+
+	odds := (speed*128/opposingSpeed + 30*side.fleeAttempts) % 256
+	if speed >= opposingSpeed || battle.random.Intn(256) < odds {
+		battle.record(Event{Kind: FleeSucceeded, Side: id, Slot: side.active})
+		battle.outcome = Fled
+		return
+	}
+
+	battle.record(Event{Kind: FleeFailed, Side: id, Slot: side.active})
+}
+
+// endTurn applies the damage of status conditions to the active monsters
+// and decides whether the Battle is over.
+func (battle *Battle) endTurn() {
+	for _, id := range []SideID{Challenger, Opponent} {
+		active := battle.active(id)
+		if active.monster.HitPoints <= 0 {
+			continue
+		}
+
+		var divisor int
+		switch active.monster.StatusCondition {
+		case game.Poisoned:
+			divisor = 8
+		case game.Burnt:
+			divisor = 16
+		default:
+			continue
+		}
+
+		amount := active.stats[game.HitPoints] / divisor
+		if amount < 1 {
+			amount = 1
+		}
+
+		battle.record(Event{Kind: StatusDamaged, Side: id, Slot: active.slot, Amount: amount, Status: active.monster.StatusCondition})
+		battle.hurt(active, amount)
+	}
+
+	battle.decide()
+}
+
+// decide decides whether the Battle is over and otherwise, which sides
+// have to replace their fainted active monster.
+func (battle *Battle) decide() {
+	challenger, opponent := battle.sides[Challenger], battle.sides[Opponent]
+
+	switch {
+	case challenger.firstUsable() < 0:
+		battle.outcome = OpponentWon
+	case opponent.firstUsable() < 0:
+		battle.outcome = ChallengerWon
+	default:
+		for _, side := range battle.sides {
+			side.mustReplace = side.Party[side.active].HitPoints <= 0
+		}
+	}
+}
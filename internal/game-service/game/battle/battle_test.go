@@ -0,0 +1,265 @@
+package battle
+
+import (
+	"reflect"
+	"testing"
+
+	"gitlab.com/pokesync/game-service/internal/game-service/game"
+)
+
+const (
+	tackle      game.MoveID = 0
+	quickAttack game.MoveID = 1
+	thunderWave game.MoveID = 2
+	splash      game.MoveID = 3
+	ember       game.MoveID = 4
+)
+
+const (
+	slowpoke  game.ModelID = 0
+	rattata   game.ModelID = 1
+	charizard game.ModelID = 2
+)
+
+const (
+	pokeBall   = 0
+	masterBall = 1
+	potion     = 2
+)
+
+// newFireChart constructs a TypeChart in which fire does not affect ghosts.
+func newFireChart(t *testing.T) *game.TypeChart {
+	chart, err := game.NewTypeChart(game.TypeChartConfig{
+		Types:    []string{"FIRE", "GHOST"},
+		Matchups: map[string]map[string]float64{"FIRE": {"GHOST": 0}},
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return chart
+}
+
+func newTestConfig() Config {
+	return Config{
+		Monsters: &game.MonsterConfig{Descriptors: []game.MonsterDescriptor{
+			{ID: 0, Name: "SLOWPOKE", BaseExp: 63, CaptureRate: 190, BaseStats: [7]int{90, 65, 65, 40, 40, 15}, Types: game.TypePair{First: "WATER", Second: "PSYCHIC"}},
+			{ID: 1, Name: "RATTATA", BaseExp: 51, CaptureRate: 255, BaseStats: [7]int{30, 56, 35, 25, 35, 72}, Types: game.TypePair{First: "NORMAL"}},
+			{ID: 2, Name: "CHARIZARD", BaseExp: 240, CaptureRate: 45, BaseStats: [7]int{78, 84, 78, 109, 85, 100}, Types: game.TypePair{First: "FIRE", Second: "GHOST"}},
+		}},
+		Moves: &game.MoveConfig{Descriptors: []game.MoveDescriptor{
+			{ID: tackle, Name: "TACKLE", Type: "NORMAL", Category: game.Physical, Power: 40, Accuracy: 100, PP: 35},
+			{ID: quickAttack, Name: "QUICK_ATTACK", Type: "NORMAL", Category: game.Physical, Power: 40, Accuracy: 100, PP: 30, Priority: 1},
+			{ID: thunderWave, Name: "THUNDER_WAVE", Type: "ELECTRIC", Category: game.Status, Accuracy: 0, PP: 20, EffectID: game.ParalyzeEffect, EffectChance: 100},
+			{ID: splash, Name: "SPLASH", Type: "NORMAL", Category: game.Status, PP: 40},
+			{ID: ember, Name: "EMBER", Type: "FIRE", Category: game.Special, Power: 40, Accuracy: 100, PP: 25},
+		}},
+		Items: &game.ItemConfig{Descriptors: []game.ItemDescriptor{
+			{ID: pokeBall, Name: "POKE_BALL", BallModifier: 1},
+			{ID: masterBall, Name: "MASTER_BALL", BallModifier: 255},
+			{ID: potion, Name: "POTION"},
+		}},
+	}
+}
+
+func newTestMonster(config Config, modelID game.ModelID, level int, moves ...game.MoveID) *game.MonsterData {
+	monster := game.NewMonsterData(config.Monsters.Get(modelID), config.Moves, level)
+
+	// the test monsters know exactly the given moves
+	monster.Moves = nil
+	for _, move := range moves {
+		monster.LearnMove(config.Moves.Get(move))
+	}
+
+	return &monster
+}
+
+func eventsOf(events []Event, kind EventKind) []Event {
+	var matching []Event
+	for _, event := range events {
+		if event.Kind == kind {
+			matching = append(matching, event)
+		}
+	}
+
+	return matching
+}
+
+func TestBattle_IsDeterministic(t *testing.T) {
+	play := func() []Event {
+		config := newTestConfig()
+
+		challenger := NewSide([]*game.MonsterData{newTestMonster(config, rattata, 10, tackle, quickAttack)})
+		opponent := NewSide([]*game.MonsterData{newTestMonster(config, slowpoke, 10, tackle)})
+
+		battle, err := New(config, WildBattle, 42, challenger, opponent)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for !battle.Finished() && battle.Result().Turns < 50 {
+			if _, err := battle.Turn(battle.RandomMove(Challenger), battle.RandomMove(Opponent)); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		return battle.Log()
+	}
+
+	if first, second := play(), play(); !reflect.DeepEqual(first, second) {
+		t.Error("expected two battles with the same seed to play out the same")
+	}
+}
+
+func TestBattle_TurnOrder(t *testing.T) {
+	config := newTestConfig()
+
+	challenger := NewSide([]*game.MonsterData{newTestMonster(config, slowpoke, 50, tackle, quickAttack)})
+	opponent := NewSide([]*game.MonsterData{newTestMonster(config, rattata, 50, tackle)})
+
+	battle, _ := New(config, TrainerBattle, 1, challenger, opponent)
+
+	events, _ := battle.Turn(UseMove{Slot: 0}, UseMove{Slot: 0})
+	if used := eventsOf(events, MoveUsed); used[0].Side != Opponent {
+		t.Error("expected the faster monster to move first")
+	}
+
+	events, _ = battle.Turn(UseMove{Slot: 1}, UseMove{Slot: 0})
+	if used := eventsOf(events, MoveUsed); used[0].Side != Challenger || used[0].Move != quickAttack {
+		t.Error("expected the move of higher priority to be used first")
+	}
+}
+
+func TestBattle_ChallengerWinsAndGainsExperience(t *testing.T) {
+	config := newTestConfig()
+
+	attacker := newTestMonster(config, charizard, 50, tackle)
+	challenger := NewSide([]*game.MonsterData{attacker})
+	challenger.EarnsExperience = true
+
+	opponent := NewSide([]*game.MonsterData{newTestMonster(config, rattata, 2, tackle)})
+
+	battle, _ := New(config, WildBattle, 7, challenger, opponent)
+
+	experience := attacker.Experience
+	for turn := 0; !battle.Finished(); turn++ {
+		if turn > 10 {
+			t.Fatal("expected the battle to have finished")
+		}
+
+		battle.Turn(UseMove{Slot: 0}, UseMove{Slot: 0})
+	}
+
+	if battle.Result().Outcome != ChallengerWon {
+		t.Errorf("expected outcome %v but was %v instead", ChallengerWon, battle.Result().Outcome)
+	}
+
+	if attacker.Experience <= experience || len(eventsOf(battle.Log(), ExperienceGained)) != 1 {
+		t.Error("expected the winning monster to have gained experience")
+	}
+
+	if _, err := battle.Turn(UseMove{Slot: 0}, UseMove{Slot: 0}); err != ErrFinished {
+		t.Error("expected the battle to have finished")
+	}
+}
+
+func TestBattle_SwitchAndReplace(t *testing.T) {
+	config := newTestConfig()
+
+	weak := newTestMonster(config, rattata, 2, splash)
+	strong := newTestMonster(config, slowpoke, 30, tackle)
+
+	challenger := NewSide([]*game.MonsterData{weak, strong})
+	opponent := NewSide([]*game.MonsterData{newTestMonster(config, charizard, 60, tackle), newTestMonster(config, rattata, 2, tackle)})
+
+	battle, _ := New(config, TrainerBattle, 3, challenger, opponent)
+
+	if _, err := battle.Turn(Switch{Slot: 0}, UseMove{Slot: 0}); err != ErrInvalidSwitch {
+		t.Error("expected switching to the active monster to be invalid")
+	}
+
+	if _, err := battle.Turn(Flee{}, UseMove{Slot: 0}); err != ErrCannotFlee {
+		t.Error("expected to not be able to flee from a trainer battle")
+	}
+
+	battle.Turn(UseMove{Slot: 0}, UseMove{Slot: 0})
+	if weak.HitPoints != 0 || !challenger.MustReplace() {
+		t.Fatal("expected the weak monster to have fainted")
+	}
+
+	if _, err := battle.Turn(UseMove{Slot: 0}, UseMove{Slot: 0}); err != ErrMustReplace {
+		t.Error("expected the fainted monster to have to be replaced first")
+	}
+
+	if _, err := battle.Replace(Challenger, 0); err != ErrInvalidSwitch {
+		t.Error("expected to not be able to replace with a fainted monster")
+	}
+
+	if _, err := battle.Replace(Challenger, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	events, _ := battle.Turn(UseMove{Slot: 0}, Switch{Slot: 1})
+	if events[0].Kind != SwitchedIn || events[0].Side != Opponent {
+		t.Error("expected switching to happen before any move is used")
+	}
+}
+
+func TestBattle_Flee(t *testing.T) {
+	config := newTestConfig()
+
+	challenger := NewSide([]*game.MonsterData{newTestMonster(config, rattata, 50, tackle)})
+	opponent := NewSide([]*game.MonsterData{newTestMonster(config, slowpoke, 5, quickAttack)})
+
+	battle, _ := New(config, WildBattle, 5, challenger, opponent)
+
+	events, _ := battle.Turn(Flee{}, UseMove{Slot: 0})
+	if len(events) != 1 || events[0].Kind != FleeSucceeded || battle.Result().Outcome != Fled {
+		t.Error("expected the faster monster to flee before the opponent moves")
+	}
+}
+
+func TestBattle_TypeEffectivenessAndStatus(t *testing.T) {
+	config := newTestConfig()
+	config.TypeChart = newFireChart(t)
+
+	attacker := newTestMonster(config, charizard, 50, ember, thunderWave)
+	defender := newTestMonster(config, charizard, 50, splash)
+
+	battle, _ := New(config, TrainerBattle, 9, NewSide([]*game.MonsterData{attacker}), NewSide([]*game.MonsterData{defender}))
+
+	events, _ := battle.Turn(UseMove{Slot: 0}, UseMove{Slot: 0})
+
+	damaged := eventsOf(events, Damaged)
+	if len(damaged) != 1 || damaged[0].Effectiveness != 0 || defender.HitPoints != defender.Stats(config.Monsters.Get(charizard))[game.HitPoints] {
+		t.Error("expected fire to not affect a fire and ghost type")
+	}
+
+	battle.Turn(UseMove{Slot: 1}, UseMove{Slot: 0})
+	if defender.StatusCondition != game.Paralyzed {
+		t.Errorf("expected status condition %v but was %v instead", game.Paralyzed, defender.StatusCondition)
+	}
+}
+
+func TestBattle_Forfeit(t *testing.T) {
+	config := newTestConfig()
+
+	challenger := NewSide([]*game.MonsterData{newTestMonster(config, rattata, 5, tackle)})
+	opponent := NewSide([]*game.MonsterData{newTestMonster(config, slowpoke, 5, tackle)})
+
+	battle, _ := New(config, WildBattle, 11, challenger, opponent)
+
+	events, err := battle.Forfeit(Challenger)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(events) != 1 || events[0].Kind != Forfeited || battle.Result().Outcome != OpponentWon {
+		t.Error("expected the opponent to have won by forfeit")
+	}
+
+	if _, err := battle.Forfeit(Opponent); err != ErrFinished {
+		t.Error("expected the battle to have finished")
+	}
+}
@@ -0,0 +1,100 @@
+package battle
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+
+	"gitlab.com/pokesync/game-service/internal/game-service/game"
+)
+
+const (
+	// guaranteedCapture is the capture value from which a monster is
+	// captured without any shake checks.
+	guaranteedCapture = 255
+
+	// ShakeChecks is the amount of shake checks a monster has to fail at
+	// breaking free from a ball for it to be captured.
+	ShakeChecks = 4
+)
+
+// ErrCannotCapture is returned when attempting to capture a monster in a
+// Battle against a trainer.
+var ErrCannotCapture = errors.New("can not capture the monster of a trainer")
+
+// ThrowBall is an Action to throw a ball at the active monster of the
+// opposing side, in an attempt to capture it. Modifier is the modifier of
+// the ball that is thrown, which makes capturing more likely the higher
+// it is.
+type ThrowBall struct {
+	Modifier float64
+}
+
+// CaptureValue returns the value the odds of capturing the given monster
+// of the given maximum health are rolled against. It grows as the monster
+// loses health, suffers from a status condition, is thrown a ball of a
+// higher modifier and is of a higher capture rate.
+func CaptureValue(descriptor *game.MonsterDescriptor, monster *game.MonsterData, maxHitPoints int, ballModifier float64) float64 {
+	if maxHitPoints < 1 {
+		maxHitPoints = 1
+	}
+
+	value := float64(3*maxHitPoints-2*monster.HitPoints) * float64(descriptor.CaptureRate) * ballModifier / float64(3*maxHitPoints)
+	value = math.Floor(value) * statusBonusOf(monster.StatusCondition)
+
+	return value
+}
+
+// RollCapture rolls whether a monster of the given capture value is
+// captured. Returns the amount of shake checks the monster failed at
+// breaking free, which equals ShakeChecks if it was captured.
+func RollCapture(random *rand.Rand, captureValue float64) int {
+	if captureValue >= guaranteedCapture {
+		return ShakeChecks
+	}
+
+	if captureValue <= 0 {
+		return 0
+	}
+
+	odds := 1048560 / math.Sqrt(math.Sqrt(16711680/captureValue))
+
+	shakes := 0
+	for ; shakes < ShakeChecks; shakes++ {
+		if float64(random.Intn(65536)) >= odds {
+			break
+		}
+	}
+
+	return shakes
+}
+
+// statusBonusOf returns the multiplier a status condition applies to the
+// odds of capturing a monster that suffers from it.
+func statusBonusOf(status game.StatusCondition) float64 {
+	switch status {
+	case game.Asleep, game.Frozen:
+		return 2
+	case game.Paralyzed, game.Poisoned, game.Burnt:
+		return 1.5
+	default:
+		return 1
+	}
+}
+
+// throwBall has the specified Side throw a ball of the given modifier at
+// the active monster of the opposing side.
+func (battle *Battle) throwBall(id SideID, modifier float64) {
+	target := battle.active(other(id))
+
+	value := CaptureValue(target.descriptor, target.monster, target.stats[game.HitPoints], modifier)
+	shakes := RollCapture(battle.random, value)
+
+	battle.record(Event{Kind: BallThrown, Side: target.id, Slot: target.slot, Amount: shakes})
+	if shakes < ShakeChecks {
+		return
+	}
+
+	battle.record(Event{Kind: MonsterCaptured, Side: target.id, Slot: target.slot})
+	battle.outcome = Captured
+}
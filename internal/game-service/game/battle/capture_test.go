@@ -0,0 +1,92 @@
+package battle
+
+import (
+	"math/rand"
+	"testing"
+
+	"gitlab.com/pokesync/game-service/internal/game-service/game"
+)
+
+func TestCaptureValue_GrowsAsHealthDrops(t *testing.T) {
+	config := newTestConfig()
+
+	descriptor := config.Monsters.Get(charizard)
+	monster := newTestMonster(config, charizard, 50)
+	maxHitPoints := monster.HitPoints
+
+	full := CaptureValue(descriptor, monster, maxHitPoints, 1)
+
+	monster.HitPoints = 1
+	low := CaptureValue(descriptor, monster, maxHitPoints, 1)
+
+	monster.StatusCondition = game.Asleep
+	asleep := CaptureValue(descriptor, monster, maxHitPoints, 1)
+
+	if !(full < low && low < asleep) {
+		t.Errorf("expected %v < %v < %v", full, low, asleep)
+	}
+
+	if asleep != low*2 {
+		t.Errorf("expected sleep to double the capture value of %v but was %v", low, asleep)
+	}
+}
+
+func TestRollCapture_IsDeterministic(t *testing.T) {
+	for seed := int64(0); seed < 16; seed++ {
+		first := RollCapture(rand.New(rand.NewSource(seed)), 30)
+		second := RollCapture(rand.New(rand.NewSource(seed)), 30)
+
+		if first != second {
+			t.Fatalf("expected seed %v to roll %v shakes twice but rolled %v", seed, first, second)
+		}
+
+		if first < 0 || first > ShakeChecks {
+			t.Fatalf("rolled an invalid amount of %v shakes", first)
+		}
+	}
+
+	if shakes := RollCapture(rand.New(rand.NewSource(1)), guaranteedCapture); shakes != ShakeChecks {
+		t.Errorf("expected a guaranteed capture but the monster broke free after %v shakes", shakes)
+	}
+}
+
+func TestBattle_ThrowBall(t *testing.T) {
+	config := newTestConfig()
+
+	challenger := NewSide([]*game.MonsterData{newTestMonster(config, rattata, 5, splash)})
+	opponent := NewSide([]*game.MonsterData{newTestMonster(config, slowpoke, 5, splash)})
+
+	battle, err := New(config, WildBattle, 1, challenger, opponent)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	events, err := battle.Turn(ThrowBall{Modifier: 255}, UseMove{Slot: 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(eventsOf(events, MonsterCaptured)) != 1 || battle.Result().Outcome != Captured {
+		t.Error("expected the monster to have been captured")
+	}
+
+	if len(eventsOf(events, MoveUsed)) != 0 {
+		t.Error("expected the captured monster to not have moved")
+	}
+}
+
+func TestBattle_CanNotCaptureFromTrainer(t *testing.T) {
+	config := newTestConfig()
+
+	challenger := NewSide([]*game.MonsterData{newTestMonster(config, rattata, 5, splash)})
+	opponent := NewSide([]*game.MonsterData{newTestMonster(config, slowpoke, 5, splash)})
+
+	battle, err := New(config, TrainerBattle, 1, challenger, opponent)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := battle.Turn(ThrowBall{Modifier: 255}, UseMove{Slot: 0}); err != ErrCannotCapture {
+		t.Errorf("expected %v but was %v", ErrCannotCapture, err)
+	}
+}
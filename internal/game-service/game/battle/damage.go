@@ -0,0 +1,152 @@
+package battle
+
+import "gitlab.com/pokesync/game-service/internal/game-service/game"
+
+const (
+	// Accuracy is the stat that stages of accuracy are kept under,
+	// next to the Stat's of a monster.
+	Accuracy game.Stat = game.StatCount
+
+	// MinStage is the lowest stage a stat can be lowered to.
+	MinStage = -6
+
+	// MaxStage is the highest stage a stat can be raised to.
+	MaxStage = 6
+
+	// criticalHitOdds are the odds of a move landing a critical hit,
+	// as one in criticalHitOdds.
+	criticalHitOdds = 24
+)
+
+// TypeChart tells how effective a move of one type is against a
+// monster of a pair of types. Implemented by game.TypeChart.
+type TypeChart interface {
+	EffectivenessAgainst(attacking string, defending game.TypePair) float64
+}
+
+// NeutralTypeChart is a TypeChart in which every type is neutral
+// against every other type.
+type NeutralTypeChart struct{}
+
+// Stages are the stages each stat of an active monster is raised or
+// lowered by, indexed by its game.Stat.
+type Stages [game.StatCount + 1]int
+
+// damage is the outcome of calculating the damage a move deals.
+type damage struct {
+	amount        int
+	effectiveness float64
+	critical      bool
+}
+
+// EffectivenessAgainst returns the neutral multiplier for every type.
+func (chart NeutralTypeChart) EffectivenessAgainst(attacking string, defending game.TypePair) float64 {
+	return 1
+}
+
+// hasType returns whether either type of the given TypePair is the
+// specified type.
+func hasType(types game.TypePair, kind string) bool {
+	return types.First == kind || (types.Second != "" && types.Second == kind)
+}
+
+// raise raises or lowers the specified stat by the given amount of
+// stages, bound between MinStage and MaxStage. Returns the amount of
+// stages the stat actually changed by.
+func (stages *Stages) raise(stat game.Stat, amount int) int {
+	before := stages[stat]
+
+	stages[stat] += amount
+	if stages[stat] < MinStage {
+		stages[stat] = MinStage
+	} else if stages[stat] > MaxStage {
+		stages[stat] = MaxStage
+	}
+
+	return stages[stat] - before
+}
+
+// applyStage applies the multiplier of the given stage to the given
+// value of a stat.
+func applyStage(value, stage int) int {
+	if stage >= 0 {
+		return value * (2 + stage) / 2
+	}
+
+	return value * 2 / (2 - stage)
+}
+
+// applyAccuracyStage applies the multiplier of the given stage of
+// accuracy to the given accuracy of a move.
+func applyAccuracyStage(accuracy, stage int) int {
+	if stage >= 0 {
+		return accuracy * (3 + stage) / 3
+	}
+
+	return accuracy * 3 / (3 - stage)
+}
+
+// calculateDamage calculates the damage the attacking combatant deals to
+// the defending combatant with the described move.
+func (battle *Battle) calculateDamage(attacker, defender *combatant, move *game.MoveDescriptor) damage {
+	attackStat, defenceStat := game.Attack, game.Defence
+	if move.Category == game.Special {
+		attackStat, defenceStat = game.SpecialAttack, game.SpecialDefence
+	}
+
+	critical := battle.random.Intn(criticalHitOdds) == 0
+
+	// a critical hit ignores the stages that are to the disadvantage
+	// of the attacking monster
+	attackStage, defenceStage := attacker.side.stages[attackStat], defender.side.stages[defenceStat]
+	if critical && attackStage < 0 {
+		attackStage = 0
+	}
+
+	if critical && defenceStage > 0 {
+		defenceStage = 0
+	}
+
+	attack := applyStage(attacker.stats[attackStat], attackStage)
+	defence := applyStage(defender.stats[defenceStat], defenceStage)
+	if defence < 1 {
+		defence = 1
+	}
+
+	level := attacker.monster.Level
+	base := (2*level/5+2)*move.Power*attack/defence/50 + 2
+
+	modifier := float64(85+battle.random.Intn(16)) / 100
+	if hasType(attacker.descriptor.Types, move.Type) {
+		modifier *= 1.5
+	}
+
+	effectiveness := battle.config.TypeChart.EffectivenessAgainst(move.Type, defender.descriptor.Types)
+	modifier *= effectiveness
+
+	if critical {
+		modifier *= 1.5
+	}
+
+	if attacker.monster.StatusCondition == game.Burnt && move.Category == game.Physical {
+		modifier *= 0.5
+	}
+
+	amount := int(float64(base) * modifier)
+	if effectiveness > 0 && amount < 1 {
+		amount = 1
+	}
+
+	return damage{amount: amount, effectiveness: effectiveness, critical: critical}
+}
+
+// hits rolls whether the described move used by the attacking combatant
+// hits its target. Moves without accuracy never miss.
+func (battle *Battle) hits(attacker *combatant, move *game.MoveDescriptor) bool {
+	if move.Accuracy <= 0 {
+		return true
+	}
+
+	accuracy := applyAccuracyStage(move.Accuracy, attacker.side.stages[Accuracy])
+	return battle.random.Intn(100) < accuracy
+}
@@ -0,0 +1,64 @@
+package battle
+
+import "gitlab.com/pokesync/game-service/internal/game-service/game"
+
+// These are the kinds of Event's that can occur in a Battle.
+const (
+	SwitchedIn       EventKind = 0
+	MoveUsed         EventKind = 1
+	MoveMissed       EventKind = 2
+	Damaged          EventKind = 3
+	Fainted          EventKind = 4
+	StatusInflicted  EventKind = 5
+	StatusCured      EventKind = 6
+	StatusDamaged    EventKind = 7
+	CannotMove       EventKind = 8
+	Flinched         EventKind = 9
+	StatRaised       EventKind = 10
+	StatLowered      EventKind = 11
+	RecoilDamaged    EventKind = 12
+	FleeSucceeded    EventKind = 13
+	FleeFailed       EventKind = 14
+	ExperienceGained EventKind = 15
+	LevelledUp       EventKind = 16
+	MoveLearnt       EventKind = 17
+	MoveNotLearnt    EventKind = 18
+	Forfeited        EventKind = 19
+	BallThrown       EventKind = 20
+	MonsterCaptured  EventKind = 21
+)
+
+// EventKind is a kind of Event.
+type EventKind int
+
+// Event is an entry in the log of a Battle, describing something that
+// happened to the monster in the specified party slot of the specified
+// Side. Which of the other fields are of relevance depends on the Kind.
+type Event struct {
+	Kind EventKind
+	Side SideID
+	Slot int
+
+	// Move is the move that was used or learnt.
+	Move game.MoveID
+
+	// Amount is the amount of damage that was dealt, experience that was
+	// gained, stages a stat was raised or lowered by, the level that was
+	// reached, or the shake checks a monster failed at breaking free from
+	// a ball.
+	Amount int
+
+	// Effectiveness is the multiplier of the type effectiveness of the
+	// move the damage was dealt with.
+	Effectiveness float64
+
+	// Critical is whether the damage was dealt with a critical hit.
+	Critical bool
+
+	// Status is the StatusCondition that was inflicted or cured, or that
+	// prevented the monster from moving.
+	Status game.StatusCondition
+
+	// Stat is the stat that was raised or lowered.
+	Stat game.Stat
+}
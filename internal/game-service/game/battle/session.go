@@ -0,0 +1,308 @@
+package battle
+
+import (
+	"errors"
+
+	"gitlab.com/pokesync/game-service/internal/game-service/game"
+	"gitlab.com/pokesync/game-service/internal/game-service/game/transport"
+)
+
+// ErrUnusableItem is returned when attempting to use an item that can not
+// be used in a Battle.
+var ErrUnusableItem = errors.New("item can not be used in battle")
+
+// Receiver hands a monster the Player captured over to the Player.
+type Receiver func(plr *game.Player, monster game.MonsterData) error
+
+// Session is a game.BattleSession in which a Player battles with the
+// monsters of its PartyBelt against a computer controlled opponent, which
+// uses a random move every turn. Turns are played out as soon as the
+// Player has chosen its action, and their Event's are reported back to
+// the Player's Client.
+type Session struct {
+	config Config
+	battle *Battle
+	player *game.Player
+
+	// wild is the wild monster the Player battles against, which is
+	// handed to the receiver if the Player captures it.
+	wild     *game.MonsterData
+	receiver Receiver
+
+	// members are the monsters of the Player's PartyBelt that take part
+	// in the Battle, in the order of the challenging Side's party.
+	members []*game.Monster
+
+	// partySlots are the slots of the members on the Player's PartyBelt.
+	partySlots []int
+
+	// levels are the levels the members were at as the Battle started.
+	levels []int
+
+	// unlearnt are the moves the members could not learn by levelling up
+	// for a lack of room, which the Player is offered once the Battle ends.
+	unlearnt []Event
+}
+
+// StartWild starts a wild Battle between the monsters of the given
+// Player's PartyBelt and the given wild monster, of which the outcome is
+// determined by the given seed. The Player is locked into the Battle
+// until it has finished. If the Player captures the wild monster, it is
+// handed over to the given Receiver.
+func StartWild(config Config, plr *game.Player, wild *game.MonsterData, seed int64, receiver Receiver) (*Session, error) {
+	session := &Session{config: config, player: plr, wild: wild, receiver: receiver}
+
+	belt := plr.PartyBelt()
+	for slot := 0; slot < belt.Size; slot++ {
+		if monster, err := belt.Get(slot); err == nil && monster != nil {
+			session.members = append(session.members, monster)
+			session.partySlots = append(session.partySlots, slot)
+			session.levels = append(session.levels, monster.Level)
+		}
+	}
+
+	party := make([]*game.MonsterData, len(session.members))
+	for i, member := range session.members {
+		party[i] = member.MonsterData
+	}
+
+	challenger := NewSide(party)
+	challenger.EarnsExperience = true
+
+	battle, err := New(config, WildBattle, seed, challenger, NewSide([]*game.MonsterData{wild}))
+	if err != nil {
+		return nil, err
+	}
+
+	session.battle = battle
+	if err := plr.EnterBattle(session); err != nil {
+		return nil, err
+	}
+
+	descriptor := config.Monsters.Get(wild.ModelID)
+
+	plr.QueueEvent(&transport.StartBattle{
+		Kind:            byte(WildBattle),
+		ActivePartySlot: byte(session.partySlots[challenger.Active()]),
+
+		OpponentMonsterID:       uint16(wild.ModelID),
+		OpponentLevel:           byte(wild.Level),
+		OpponentGender:          byte(wild.Gender),
+		OpponentColoration:      byte(wild.Coloration),
+		OpponentStatusCondition: byte(wild.StatusCondition),
+		OpponentHitPoints:       uint16(wild.HitPoints),
+		OpponentMaxHitPoints:    uint16(wild.Stats(descriptor)[game.HitPoints]),
+	})
+
+	return session, nil
+}
+
+// Battle returns the Battle that is fought in the Session.
+func (session *Session) Battle() *Battle {
+	return session.battle
+}
+
+// ChooseMove has the active monster of the Player use the move in the
+// specified slot of its known moves.
+func (session *Session) ChooseMove(plr *game.Player, slot int) error {
+	return session.play(UseMove{Slot: slot})
+}
+
+// SwitchMonster switches the active monster of the Player for the monster
+// in the specified slot of its PartyBelt. If the active monster fainted,
+// it is replaced without a turn being played out.
+func (session *Session) SwitchMonster(plr *game.Player, partySlot int) error {
+	slot := session.memberAt(partySlot)
+	if slot < 0 {
+		return ErrInvalidSwitch
+	}
+
+	if !session.battle.Side(Challenger).MustReplace() {
+		return session.play(Switch{Slot: slot})
+	}
+
+	events, err := session.battle.Replace(Challenger, slot)
+	if err != nil {
+		return err
+	}
+
+	session.report(events)
+	return nil
+}
+
+// UseItem has the Player use the item of the specified id. Only balls can
+// be used in battle, which are thrown at the wild monster in an attempt
+// to capture it. Every ball thrown is taken from the Player's Inventory.
+func (session *Session) UseItem(plr *game.Player, itemID int) error {
+	item := session.config.Items.Get(itemID)
+	if item == nil || item.BallModifier <= 0 {
+		return ErrUnusableItem
+	}
+
+	inventory := plr.Inventory()
+	if inventory.Count(itemID) <= 0 {
+		return game.ErrNotEnoughItems
+	}
+
+	turns := session.battle.Result().Turns
+	err := session.play(ThrowBall{Modifier: item.BallModifier})
+
+	// the ball is only used up if it was actually thrown
+	if session.battle.Result().Turns > turns {
+		inventory.Remove(itemID, 1)
+	}
+
+	return err
+}
+
+// Flee has the Player attempt to flee from the Battle.
+func (session *Session) Flee(plr *game.Player) error {
+	return session.play(Flee{})
+}
+
+// Forfeit ends the Battle in favour of the opponent.
+func (session *Session) Forfeit(plr *game.Player) {
+	events, err := session.battle.Forfeit(Challenger)
+	if err != nil {
+		return
+	}
+
+	session.report(events)
+	session.end()
+}
+
+// play plays out a turn in which the Player performs the given Action and
+// the opponent uses a random move.
+func (session *Session) play(action Action) error {
+	battle := session.battle
+
+	events, err := battle.Turn(action, battle.RandomMove(Opponent))
+	if err != nil {
+		return err
+	}
+
+	// the log is appended to by a replacement, so the events of the turn
+	// are copied before they are added to
+	events = append([]Event(nil), events...)
+
+	opponent := battle.Side(Opponent)
+	if opponent.MustReplace() {
+		replacement, err := battle.Replace(Opponent, opponent.firstUsable())
+		if err != nil {
+			return err
+		}
+
+		events = append(events, replacement...)
+	}
+
+	session.report(events)
+	if battle.Finished() {
+		return session.end()
+	}
+
+	return nil
+}
+
+// report reports the given Event's to the Player.
+func (session *Session) report(events []Event) {
+	result := &transport.BattleTurnResult{
+		Events:      make([]transport.BattleEvent, len(events)),
+		MustReplace: session.battle.Side(Challenger).MustReplace(),
+	}
+
+	for i, event := range events {
+		slot := event.Slot
+		if event.Side == Challenger {
+			slot = session.partySlots[slot]
+
+			if event.Kind == MoveNotLearnt {
+				session.unlearnt = append(session.unlearnt, event)
+			}
+		}
+
+		result.Events[i] = transport.BattleEvent{
+			Kind:   byte(event.Kind),
+			Side:   byte(event.Side),
+			Slot:   byte(slot),
+			MoveID: uint16(event.Move),
+			Amount: uint32(event.Amount),
+
+			// effectiveness is sent in quarters, as it is always a
+			// multiple of one quarter
+			Effectiveness: byte(event.Effectiveness * 4),
+			Critical:      event.Critical,
+
+			Status: byte(event.Status),
+			Stat:   byte(event.Stat),
+		}
+	}
+
+	session.player.QueueEvent(result)
+}
+
+// end brings the monsters of the Player back in sync with the changes the
+// Battle made to them, hands the wild monster over to the Player if it
+// was captured and releases the Player from the Battle. The Player is
+// offered to teach the monsters that levelled up the moves they had no
+// room for, and to evolve them. Returns the error of the receiver, if
+// the captured monster could not be handed over.
+func (session *Session) end() error {
+	belt := session.player.PartyBelt()
+	for i, member := range session.members {
+		member.SyncHealth(session.config.Monsters.Get(member.ModelID))
+		belt.Set(session.partySlots[i], member)
+	}
+
+	var err error
+	if session.battle.Result().Outcome == Captured {
+		captured := *session.wild
+		captured.OriginalTrainer = session.player.DisplayName()
+
+		err = session.receiver(session.player, captured)
+	}
+
+	session.player.LeaveBattle()
+	session.player.QueueEvent(&transport.EndBattle{Outcome: byte(session.battle.Result().Outcome)})
+
+	for _, event := range session.unlearnt {
+		session.player.OfferMove(session.partySlots[event.Slot], event.Move)
+	}
+
+	if session.config.Evolutions != nil {
+		for i, member := range session.members {
+			if member.Level > session.levels[i] {
+				session.config.Evolutions.OfferAfterLevelUp(session.player, session.partySlots[i])
+			}
+		}
+	}
+
+	if session.config.Respawn != nil && session.allFainted() {
+		session.config.Respawn(session.player)
+	}
+
+	return err
+}
+
+// allFainted returns whether every monster of the Player's party fainted.
+func (session *Session) allFainted() bool {
+	for _, member := range session.members {
+		if member.HitPoints > 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// memberAt returns the slot of the challenging Side's party the monster
+// in the specified PartyBelt slot takes part in the Battle with, or -1 if
+// it does not take part.
+func (session *Session) memberAt(partySlot int) int {
+	for slot, member := range session.partySlots {
+		if member == partySlot {
+			return slot
+		}
+	}
+
+	return -1
+}
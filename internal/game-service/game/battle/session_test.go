@@ -0,0 +1,173 @@
+package battle
+
+import (
+	"errors"
+	"testing"
+
+	"gitlab.com/pokesync/game-service/internal/game-service/character"
+	"gitlab.com/pokesync/game-service/internal/game-service/game"
+	"gitlab.com/pokesync/game-service/internal/game-service/game/entity"
+)
+
+func newTestPlayer(config Config, party ...*game.MonsterData) *game.Player {
+	factory := game.NewEntityFactory(entity.NewWorld(len(party)+1), &game.AssetBundle{Monsters: config.Monsters, Moves: config.Moves, Items: config.Items})
+
+	plr := game.PlayerBy(factory.CreatePlayer(game.Position{}, game.Man, character.DisplayName("Sino"), character.Regular))
+	for _, data := range party {
+		plr.PartyBelt().Add(game.MonsterBy(factory.CreateMonster(game.Position{}, data), *data))
+	}
+
+	return plr
+}
+
+func rejectMonster(plr *game.Player, monster game.MonsterData) error {
+	return errors.New("did not expect a monster to be captured")
+}
+
+func TestSession_EndsByFleeing(t *testing.T) {
+	config := newTestConfig()
+
+	plr := newTestPlayer(config, newTestMonster(config, rattata, 50, tackle))
+	wild := newTestMonster(config, slowpoke, 5, quickAttack)
+
+	session, err := StartWild(config, plr, wild, 5, rejectMonster)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if plr.Battle() != session {
+		t.Fatal("expected the player to have been locked into the battle")
+	}
+
+	if _, err := StartWild(config, plr, wild, 5, rejectMonster); err != game.ErrInBattle {
+		t.Error("expected to not be able to start a second battle")
+	}
+
+	if err := session.Flee(plr); err != nil {
+		t.Fatal(err)
+	}
+
+	if plr.InBattle() || session.Battle().Result().Outcome != Fled {
+		t.Error("expected the player to have fled from the battle")
+	}
+}
+
+func TestSession_SyncsHealthOnForfeit(t *testing.T) {
+	config := newTestConfig()
+
+	plr := newTestPlayer(config, newTestMonster(config, rattata, 5, splash))
+	wild := newTestMonster(config, charizard, 50, tackle)
+
+	session, err := StartWild(config, plr, wild, 3, rejectMonster)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := session.ChooseMove(plr, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	plr.ForfeitBattle()
+	if plr.InBattle() {
+		t.Fatal("expected the player to have been released from the battle")
+	}
+
+	monster, _ := plr.PartyBelt().Get(0)
+	health := monster.GetComponent(game.HealthTag).(*game.HealthComponent)
+	if health.Current != monster.HitPoints || health.Current == health.Max {
+		t.Errorf("expected health of %v but was %v instead", monster.HitPoints, health.Current)
+	}
+}
+
+func TestSession_GivesCapturedMonster(t *testing.T) {
+	config := newTestConfig()
+
+	plr := newTestPlayer(config, newTestMonster(config, rattata, 5, splash))
+	wild := newTestMonster(config, slowpoke, 5, splash)
+
+	var received []game.MonsterData
+	receiver := func(plr *game.Player, monster game.MonsterData) error {
+		received = append(received, monster)
+		return nil
+	}
+
+	session, err := StartWild(config, plr, wild, 7, receiver)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := session.UseItem(plr, potion); err != ErrUnusableItem {
+		t.Errorf("expected a potion to be unusable but was %v", err)
+	}
+
+	if err := session.UseItem(plr, masterBall); err != game.ErrNotEnoughItems {
+		t.Errorf("expected to not be able to throw a ball the player does not hold but was %v", err)
+	}
+
+	plr.Inventory().Add(masterBall, 2)
+	if err := session.UseItem(plr, masterBall); err != nil {
+		t.Fatal(err)
+	}
+
+	if count := plr.Inventory().Count(masterBall); count != 1 {
+		t.Errorf("expected the thrown ball to have been used up but held %v", count)
+	}
+
+	if plr.InBattle() || session.Battle().Result().Outcome != Captured {
+		t.Fatal("expected the wild monster to have been captured")
+	}
+
+	if len(received) != 1 {
+		t.Fatalf("expected one monster to have been received but was %v", len(received))
+	}
+
+	if received[0].ModelID != slowpoke || received[0].OriginalTrainer != plr.DisplayName() {
+		t.Errorf("expected a slowpoke of %v but was %+v", plr.DisplayName(), received[0])
+	}
+}
+
+func TestSession_RespawnsAfterEveryMonsterFainted(t *testing.T) {
+	config := newTestConfig()
+
+	var respawned int
+	config.Respawn = func(plr *game.Player) {
+		respawned++
+	}
+
+	plr := newTestPlayer(config, newTestMonster(config, rattata, 5, splash))
+	wild := newTestMonster(config, charizard, 50, tackle)
+
+	session, err := StartWild(config, plr, wild, 3, rejectMonster)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for plr.InBattle() {
+		if err := session.ChooseMove(plr, 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if session.Battle().Result().Outcome != OpponentWon || respawned != 1 {
+		t.Errorf("expected the player to have respawned once after losing but was %v", respawned)
+	}
+}
+
+func TestSession_DoesNotRespawnAfterForfeit(t *testing.T) {
+	config := newTestConfig()
+
+	var respawned int
+	config.Respawn = func(plr *game.Player) {
+		respawned++
+	}
+
+	plr := newTestPlayer(config, newTestMonster(config, rattata, 5, splash))
+	if _, err := StartWild(config, plr, newTestMonster(config, slowpoke, 5, splash), 3, rejectMonster); err != nil {
+		t.Fatal(err)
+	}
+
+	plr.ForfeitBattle()
+	if respawned != 0 {
+		t.Error("expected the player to not respawn whilst its monsters have not fainted")
+	}
+}
@@ -0,0 +1,124 @@
+package game
+
+import "errors"
+
+var (
+	// ErrInBattle is returned when a Player attempts to do something it
+	// can not do whilst in a battle, such as moving around.
+	ErrInBattle = errors.New("player is in a battle")
+
+	// ErrNotInBattle is returned when a Player attempts to perform a
+	// battle action whilst not in a battle.
+	ErrNotInBattle = errors.New("player is not in a battle")
+)
+
+// BattleSession is a battle a Player is locked into. For as long as the
+// Player is in a BattleSession, the Player's battle commands are handed
+// to it and the Player can not move around. A BattleSession releases the
+// Player through Player.LeaveBattle once the battle is over.
+type BattleSession interface {
+	// ChooseMove has the active monster of the Player use the move in the
+	// specified slot of its known moves.
+	ChooseMove(plr *Player, slot int) error
+
+	// SwitchMonster switches the active monster of the Player for the
+	// monster in the specified slot of its PartyBelt.
+	SwitchMonster(plr *Player, partySlot int) error
+
+	// UseItem has the Player use the item of the specified id.
+	UseItem(plr *Player, itemID int) error
+
+	// Flee has the Player attempt to flee from the battle.
+	Flee(plr *Player) error
+
+	// Forfeit ends the battle right away in favour of the other side, as
+	// the Player is no longer able to take part in it.
+	Forfeit(plr *Player)
+}
+
+// EnterBattle locks the Player into the given BattleSession, dropping any
+// steps the Player had yet to take. Returns ErrInBattle if the Player is
+// already in a battle, or ErrInTrade if the Player is in a trade.
+func (plr *Player) EnterBattle(session BattleSession) error {
+	component := plr.GetComponent(BattleTag).(*BattleComponent)
+	if component.Session != nil {
+		return ErrInBattle
+	}
+
+	if plr.InTrade() {
+		return ErrInTrade
+	}
+
+	component.Session = session
+	plr.GetComponent(TransformTag).(*TransformComponent).MovementQueue.Clear()
+
+	return nil
+}
+
+// LeaveBattle releases the Player from its BattleSession.
+func (plr *Player) LeaveBattle() {
+	plr.GetComponent(BattleTag).(*BattleComponent).Session = nil
+}
+
+// Battle returns the BattleSession the Player is locked into. May return
+// nil if the Player is not in a battle.
+func (plr *Player) Battle() BattleSession {
+	return plr.GetComponent(BattleTag).(*BattleComponent).Session
+}
+
+// InBattle returns whether the Player is locked into a BattleSession.
+func (plr *Player) InBattle() bool {
+	return plr.Battle() != nil
+}
+
+// ForfeitBattle forfeits the battle the Player is in, if any.
+func (plr *Player) ForfeitBattle() {
+	if session := plr.Battle(); session != nil {
+		session.Forfeit(plr)
+		plr.LeaveBattle()
+	}
+}
+
+func chooseBattleMove() chooseBattleMoveHandler {
+	return func(plr *Player, slot int) error {
+		session := plr.Battle()
+		if session == nil {
+			return ErrNotInBattle
+		}
+
+		return session.ChooseMove(plr, slot)
+	}
+}
+
+func switchBattleMonster() switchBattleMonsterHandler {
+	return func(plr *Player, partySlot int) error {
+		session := plr.Battle()
+		if session == nil {
+			return ErrNotInBattle
+		}
+
+		return session.SwitchMonster(plr, partySlot)
+	}
+}
+
+func useBattleItem() useBattleItemHandler {
+	return func(plr *Player, itemID int) error {
+		session := plr.Battle()
+		if session == nil {
+			return ErrNotInBattle
+		}
+
+		return session.UseItem(plr, itemID)
+	}
+}
+
+func fleeBattle() fleeBattleHandler {
+	return func(plr *Player) error {
+		session := plr.Battle()
+		if session == nil {
+			return ErrNotInBattle
+		}
+
+		return session.Flee(plr)
+	}
+}
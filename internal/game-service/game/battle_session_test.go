@@ -0,0 +1,84 @@
+package game
+
+import (
+	"testing"
+
+	"gitlab.com/pokesync/game-service/internal/game-service/character"
+	"gitlab.com/pokesync/game-service/internal/game-service/game/entity"
+)
+
+// forfeitingBattle is a BattleSession that only keeps track of whether
+// it was forfeited.
+type forfeitingBattle struct {
+	forfeited bool
+}
+
+func (battle *forfeitingBattle) ChooseMove(plr *Player, slot int) error         { return nil }
+func (battle *forfeitingBattle) SwitchMonster(plr *Player, partySlot int) error { return nil }
+func (battle *forfeitingBattle) UseItem(plr *Player, itemID int) error          { return nil }
+func (battle *forfeitingBattle) Flee(plr *Player) error                         { return nil }
+func (battle *forfeitingBattle) Forfeit(plr *Player)                            { battle.forfeited = true }
+
+func newTestPlayer() *Player {
+	factory := NewEntityFactory(entity.NewWorld(1), &AssetBundle{})
+	return PlayerBy(factory.CreatePlayer(Position{}, Man, character.DisplayName("Sino"), character.Regular))
+}
+
+func TestPlayer_CanNotMoveWhilstInBattle(t *testing.T) {
+	plr := newTestPlayer()
+
+	battle := &forfeitingBattle{}
+	if err := plr.EnterBattle(battle); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := plr.EnterBattle(&forfeitingBattle{}); err != ErrInBattle {
+		t.Error("expected to not be able to enter two battles at once")
+	}
+
+	if err := moveAvatar()(plr, North); err != ErrInBattle {
+		t.Error("expected to not be able to move whilst in battle")
+	}
+
+	if err := clickTeleport()(plr, 0, 0, 1, 1); err != ErrInBattle {
+		t.Error("expected to not be able to teleport whilst in battle")
+	}
+
+	plr.ForfeitBattle()
+	if !battle.forfeited || plr.InBattle() {
+		t.Error("expected the battle to have been forfeited")
+	}
+
+	if err := moveAvatar()(plr, North); err != nil {
+		t.Error("expected to be able to move once the battle is over")
+	}
+
+	if err := fleeBattle()(plr); err != ErrNotInBattle {
+		t.Error("expected to not be able to flee whilst not in battle")
+	}
+}
+
+func TestPlayer_StandsStillWhilstInBattle(t *testing.T) {
+	plr := newTestPlayer()
+	queue := plr.GetComponent(TransformTag).(*TransformComponent).MovementQueue
+
+	queue.AddStep(East)
+	queue.AddStep(East)
+
+	if err := plr.EnterBattle(&forfeitingBattle{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(queue.stepsToTake) != 0 {
+		t.Errorf("expected the queued steps to be dropped when entering a battle but were %v", queue.stepsToTake)
+	}
+
+	queue.AddStep(East)
+	if err := takeMovementSimulationStep(plr.Entity, queue, nil, NewGrid(1, 1), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if queue.Position != (Position{}) {
+		t.Errorf("expected the player to stand still whilst in battle but was at %v", queue.Position)
+	}
+}
@@ -79,6 +79,14 @@ func (bag *CoinBag) WithdrawDonatorPoints(amount int) {
 	bag.notifyDonatorPointsUpdated(bag.DonatorPoints)
 }
 
+// exchangePokeDollars withdraws the given amount of pokedollars from this
+// bag and adds the received amount in its place, as a single update. Both
+// amounts must already be known to be valid.
+func (bag *CoinBag) exchangePokeDollars(given, received int) {
+	bag.Dollars += received - given
+	bag.notifyPokeDollarsUpdated(bag.Dollars)
+}
+
 // notifyPokeDollarsUpdated notifies every subscribed listener
 // of the CoinBag's new amount of pokedollars.
 func (bag *CoinBag) notifyPokeDollarsUpdated(value int) {
@@ -21,6 +21,15 @@ const (
 	PartyBeltTag  entity.ComponentTag = 12
 	CoinBagTag    entity.ComponentTag = 13
 	WaryOfTimeTag entity.ComponentTag = 14
+	GenderTag     entity.ComponentTag = 15
+	PCStorageTag  entity.ComponentTag = 16
+	BattleTag     entity.ComponentTag = 17
+	EncounterTag  entity.ComponentTag = 18
+	EvolutionTag  entity.ComponentTag = 19
+	TradeTag      entity.ComponentTag = 20
+	RespawnTag    entity.ComponentTag = 21
+	InventoryTag  entity.ComponentTag = 22
+	LearnMoveTag  entity.ComponentTag = 23
 )
 
 // ModelIDComponent holds a model id of an entity.
@@ -38,6 +47,11 @@ type UsernameComponent struct {
 	DisplayName character.DisplayName
 }
 
+// GenderComponent holds the Gender of an entity.
+type GenderComponent struct {
+	Gender Gender
+}
+
 // HealthComponent keeps track of how much health an entity has left.
 type HealthComponent struct {
 	Max     int
@@ -89,6 +103,58 @@ type PartyBeltComponent struct {
 	PartyBelt *PartyBelt
 }
 
+// PCStorageComponent is an entity Component that holds the PCStorage,
+// along with the PC object through which the entity is accessing it, if
+// any.
+type PCStorageComponent struct {
+	PCStorage *PCStorage
+	Terminal  *entity.Entity
+}
+
+// BattleComponent is an entity Component that holds the BattleSession
+// the entity is locked into, if any.
+type BattleComponent struct {
+	Session BattleSession
+}
+
+// EncounterComponent is an entity Component that holds the modifiers of
+// the odds of the entity encountering wild monsters.
+type EncounterComponent struct {
+	Modifiers []EncounterModifier
+}
+
+// EvolutionComponent is an entity Component that holds the evolutions
+// that were offered to the entity, which it has yet to answer to.
+type EvolutionComponent struct {
+	Pending []PendingEvolution
+}
+
+// LearnMoveComponent is an entity Component that holds the moves that
+// were offered to the entity to teach its monsters, which it has yet to
+// answer to.
+type LearnMoveComponent struct {
+	Pending []PendingMove
+}
+
+// TradeComponent is an entity Component that holds the TradeSession the
+// entity is in, if any, along with the player the entity has requested to
+// trade with, if any.
+type TradeComponent struct {
+	Session   *TradeSession
+	Requested *Player
+}
+
+// InventoryComponent is an entity Component that holds the Inventory.
+type InventoryComponent struct {
+	Inventory *Inventory
+}
+
+// RespawnComponent is an entity Component that holds the position of the
+// healing point the entity was last healed at, if any.
+type RespawnComponent struct {
+	Point *Position
+}
+
 // CoinBagComponent is an entity Component that holds the CoinBag.
 type CoinBagComponent struct {
 	CoinBag *CoinBag
@@ -187,3 +253,57 @@ func (component *CoinBagComponent) Tag() entity.ComponentTag {
 func (component *WaryOfTimeComponent) Tag() entity.ComponentTag {
 	return WaryOfTimeTag
 }
+
+// Tag returns the tag of a Component instance for identification
+// and storage purposes.
+func (component *GenderComponent) Tag() entity.ComponentTag {
+	return GenderTag
+}
+
+// Tag returns the tag of a Component instance for identification
+// and storage purposes.
+func (component *PCStorageComponent) Tag() entity.ComponentTag {
+	return PCStorageTag
+}
+
+// Tag returns the tag of a Component instance for identification
+// and storage purposes.
+func (component *BattleComponent) Tag() entity.ComponentTag {
+	return BattleTag
+}
+
+// Tag returns the tag of a Component instance for identification
+// and storage purposes.
+func (component *EncounterComponent) Tag() entity.ComponentTag {
+	return EncounterTag
+}
+
+// Tag returns the tag of a Component instance for identification
+// and storage purposes.
+func (component *EvolutionComponent) Tag() entity.ComponentTag {
+	return EvolutionTag
+}
+
+// Tag returns the tag of a Component instance for identification
+// and storage purposes.
+func (component *LearnMoveComponent) Tag() entity.ComponentTag {
+	return LearnMoveTag
+}
+
+// Tag returns the tag of a Component instance for identification
+// and storage purposes.
+func (component *TradeComponent) Tag() entity.ComponentTag {
+	return TradeTag
+}
+
+// Tag returns the tag of a Component instance for identification
+// and storage purposes.
+func (component *RespawnComponent) Tag() entity.ComponentTag {
+	return RespawnTag
+}
+
+// Tag returns the tag of a Component instance for identification
+// and storage purposes.
+func (component *InventoryComponent) Tag() entity.ComponentTag {
+	return InventoryTag
+}
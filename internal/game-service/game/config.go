@@ -2,17 +2,21 @@ package game
 
 import (
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"strconv"
 )
 
 // AssetConfig holds configurations specific to game assets.
 type AssetConfig struct {
-	ItemDirectory    string
-	NpcDirectory     string
-	MonsterDirectory string
-	ObjectDirectory  string
-	WorldDirectory   string
+	ItemDirectory      string
+	NpcDirectory       string
+	MonsterDirectory   string
+	MoveDirectory      string
+	TypeDirectory      string
+	EncounterDirectory string
+	ObjectDirectory    string
+	WorldDirectory     string
 }
 
 // ItemDescriptor describes an item.
@@ -21,6 +25,22 @@ type ItemDescriptor struct {
 	Name         string `json:"name"`
 	NitroLabelID int    `json:"nitroLabelId"`
 	StorePrice   int    `json:"storePrice"`
+
+	// BallModifier is the modifier of the odds of capturing a monster
+	// with the item, if it is a ball. It is zero for any other item.
+	BallModifier float64 `json:"ballModifier"`
+
+	// RepelSteps is the amount of steps through the grass the item keeps
+	// weaker wild monsters away for, if it is a repel. It is zero for any
+	// other item.
+	RepelSteps int `json:"repelSteps"`
+
+	// Pocket is the pocket of an Inventory the item is held in.
+	Pocket Pocket `json:"pocket"`
+
+	// StackLimit is the amount of the item an Inventory can hold. Defaults
+	// to DefaultStackLimit if zero.
+	StackLimit int `json:"stackLimit"`
 }
 
 // ItemConfig contains a collection of item config entries.
@@ -61,6 +81,26 @@ type MonsterDescriptor struct {
 	GenderRate    int       `json:"genderRate"`
 	CaptureRate   int       `json:"captureRate"`
 	BaseHappiness int       `json:"baseHappiness"`
+
+	GrowthRate GrowthRate `json:"growthRate"`
+
+	// Evolution is the EvolutionMethod by which the monster is evolved
+	// into from its PreviousEvo, if it has one.
+	Evolution *EvolutionMethod `json:"evolution"`
+
+	// Evolutions are the monsters the monster can evolve into. They are
+	// either given explicitly or computed from the PreviousEvo links of
+	// the monsters that evolve from it.
+	Evolutions []Evolution `json:"evolutions"`
+
+	LevelUpMoves []LevelUpMove `json:"levelUpMoves"`
+	TutorMoves   []MoveID      `json:"tutorMoves"`
+}
+
+// LevelUpMove is a move a monster learns upon reaching a specific level.
+type LevelUpMove struct {
+	Level int    `json:"level"`
+	Move  MoveID `json:"move"`
 }
 
 // MonsterConfig contains a collection of monster descriptors.
@@ -68,6 +108,25 @@ type MonsterConfig struct {
 	Descriptors []MonsterDescriptor
 }
 
+// MoveDescriptor describes a move.
+type MoveDescriptor struct {
+	ID           MoveID       `json:"id"`
+	Name         string       `json:"name"`
+	Type         string       `json:"type"`
+	Category     MoveCategory `json:"category"`
+	Power        int          `json:"power"`
+	Accuracy     int          `json:"accuracy"`
+	PP           int          `json:"pp"`
+	Priority     int          `json:"priority"`
+	EffectID     MoveEffect   `json:"effectId"`
+	EffectChance int          `json:"effectChance"`
+}
+
+// MoveConfig contains a collection of move descriptors.
+type MoveConfig struct {
+	Descriptors []MoveDescriptor
+}
+
 // ObjectDescriptor describes an object.
 type ObjectDescriptor struct {
 	ID   int    `json:"id"`
@@ -102,12 +161,15 @@ type WorldConfig struct {
 
 // AssetBundle hold all of the assets the game will need.
 type AssetBundle struct {
-	Items    *ItemConfig
-	Npcs     *NpcConfig
-	Monsters *MonsterConfig
-	Objects  *ObjectConfig
-	World    *WorldConfig
-	Grid     *Grid
+	Items      *ItemConfig
+	Npcs       *NpcConfig
+	Monsters   *MonsterConfig
+	Moves      *MoveConfig
+	Types      *TypeChart
+	Encounters *EncounterConfig
+	Objects    *ObjectConfig
+	World      *WorldConfig
+	Grid       *Grid
 }
 
 // LoadAssetBundle loads all of the assets that the game requires.
@@ -127,6 +189,37 @@ func LoadAssetBundle(config AssetConfig) (*AssetBundle, error) {
 		return nil, err
 	}
 
+	moveConfig, err := LoadMoveConfigsAt(config.MoveDirectory)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateLearnsets(monsterConfig, moveConfig); err != nil {
+		return nil, err
+	}
+
+	if err := linkEvolutions(monsterConfig, itemConfig); err != nil {
+		return nil, err
+	}
+
+	typeChart, err := LoadTypeChartAt(config.TypeDirectory + "/chart.json")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateTypes(typeChart, monsterConfig, moveConfig); err != nil {
+		return nil, err
+	}
+
+	encounterConfig, err := LoadEncounterConfigsAt(config.EncounterDirectory)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateEncounters(encounterConfig, monsterConfig); err != nil {
+		return nil, err
+	}
+
 	worldConfig, err := LoadWorldConfigAt(config.WorldDirectory + "/world.json")
 	if err != nil {
 		return nil, err
@@ -138,11 +231,14 @@ func LoadAssetBundle(config AssetConfig) (*AssetBundle, error) {
 	}
 
 	return &AssetBundle{
-		Items:    itemConfig,
-		Npcs:     npcConfig,
-		Monsters: monsterConfig,
-		World:    worldConfig,
-		Grid:     grid,
+		Items:      itemConfig,
+		Npcs:       npcConfig,
+		Monsters:   monsterConfig,
+		Moves:      moveConfig,
+		Types:      typeChart,
+		Encounters: encounterConfig,
+		World:      worldConfig,
+		Grid:       grid,
 	}, nil
 }
 
@@ -211,6 +307,90 @@ func (config *MonsterConfig) Count() int {
 	return len(config.Descriptors)
 }
 
+// Get looks up the MonsterDescriptor of the specified ModelID. Returns
+// nil if there is no such monster.
+func (config *MonsterConfig) Get(modelID ModelID) *MonsterDescriptor {
+	if modelID < 0 || int(modelID) >= len(config.Descriptors) {
+		return nil
+	}
+
+	return &config.Descriptors[modelID]
+}
+
+// Stats returns the base Stats of the described monster.
+func (descriptor *MonsterDescriptor) Stats() Stats {
+	var stats Stats
+	copy(stats[:], descriptor.BaseStats[:])
+
+	return stats
+}
+
+// LoadMoveConfigsAt loads all of the move config files found within the
+// specified directory. May return an error.
+func LoadMoveConfigsAt(directory string) (*MoveConfig, error) {
+	fileInfo, err := ioutil.ReadDir(directory)
+	if err != nil {
+		return nil, err
+	}
+
+	moveCount := len(fileInfo)
+
+	config := new(MoveConfig)
+	config.Descriptors = make([]MoveDescriptor, moveCount)
+
+	for i := 0; i < moveCount; i++ {
+		fileBytes, err := ioutil.ReadFile(directory + "/" + strconv.Itoa(i) + ".json")
+		if err != nil {
+			return nil, err
+		}
+
+		descriptor := &MoveDescriptor{}
+		if err := json.Unmarshal(fileBytes, descriptor); err != nil {
+			return nil, err
+		}
+
+		config.Descriptors[i] = *descriptor
+	}
+
+	return config, nil
+}
+
+// Count returns the amount of loaded descriptors this MoveConfig holds.
+func (config *MoveConfig) Count() int {
+	return len(config.Descriptors)
+}
+
+// Get looks up the MoveDescriptor of the specified MoveID. Returns nil
+// if there is no such move.
+func (config *MoveConfig) Get(id MoveID) *MoveDescriptor {
+	if id < 0 || int(id) >= len(config.Descriptors) {
+		return nil
+	}
+
+	return &config.Descriptors[id]
+}
+
+// validateLearnsets checks if every move that is learnt by a monster of
+// the given MonsterConfig exists in the given MoveConfig. If not, it
+// returns an error.
+func validateLearnsets(monsters *MonsterConfig, moves *MoveConfig) error {
+	for _, monster := range monsters.Descriptors {
+		for _, levelUpMove := range monster.LevelUpMoves {
+			if moves.Get(levelUpMove.Move) == nil {
+				return fmt.Errorf("monster %v learns unknown move %v at level %v", monster.Name, levelUpMove.Move, levelUpMove.Level)
+			}
+		}
+
+		for _, tutorMove := range monster.TutorMoves {
+			if moves.Get(tutorMove) == nil {
+				return fmt.Errorf("monster %v can be tutored unknown move %v", monster.Name, tutorMove)
+			}
+		}
+	}
+
+	return nil
+}
+
 // LoadNpcConfigsAt loads the given amount of item config files from
 // the specified directory. May return an error.
 func LoadNpcConfigsAt(directory string) (*NpcConfig, error) {
@@ -266,6 +446,16 @@ func (config *ItemConfig) Count() int {
 	return len(config.Descriptors)
 }
 
+// Get looks up the ItemDescriptor of the specified id. Returns nil if there
+// is no such item.
+func (config *ItemConfig) Get(id int) *ItemDescriptor {
+	if id < 0 || id >= len(config.Descriptors) {
+		return nil
+	}
+
+	return &config.Descriptors[id]
+}
+
 // Count returns the amount of loaded npc descriptors this NpcConfig holds.
 func (config *NpcConfig) Count() int {
 	if config.Descriptors == nil {
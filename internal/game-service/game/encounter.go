@@ -0,0 +1,417 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"path/filepath"
+
+	"gitlab.com/pokesync/game-service/internal/game-service/game/collision"
+	"go.uber.org/zap"
+)
+
+// These are the times of day wild monsters can be encountered at.
+const (
+	Morning TimeOfDay = 0
+	Day     TimeOfDay = 1
+	Night   TimeOfDay = 2
+)
+
+const (
+	// morningHour is the hour of the day at which the morning starts.
+	morningHour = 4
+
+	// dayHour is the hour of the day at which the day starts.
+	dayHour = 10
+
+	// nightHour is the hour of the day at which the night starts.
+	nightHour = 20
+
+	// MaxEncounterRate is the encounter rate at which a wild monster is
+	// encountered on every step.
+	MaxEncounterRate = 100
+)
+
+// timesOfDayByName maps the names of TimeOfDay's in asset files.
+var timesOfDayByName = map[string]TimeOfDay{
+	"MORNING": Morning,
+	"DAY":     Day,
+	"NIGHT":   Night,
+}
+
+// TimeOfDay is a period of the day.
+type TimeOfDay int
+
+// EncounterEntry is a monster that can be encountered through an
+// EncounterTable. The odds of encountering it are its Weight relative to
+// the total weight of the entries that can be encountered at the time.
+type EncounterEntry struct {
+	Monster  ModelID `json:"monster"`
+	MinLevel int     `json:"minLevel"`
+	MaxLevel int     `json:"maxLevel"`
+	Weight   int     `json:"weight"`
+
+	// Times are the times of day the monster can be encountered at. The
+	// monster can be encountered at any time of day if empty.
+	Times []TimeOfDay `json:"times"`
+}
+
+// EncounterTable is the table of wild monsters that can be encountered in
+// the grass of a single map. Rate is the chance, out of MaxEncounterRate,
+// of encountering a wild monster on every step taken in the grass.
+type EncounterTable struct {
+	MapX    int              `json:"mapX"`
+	MapZ    int              `json:"mapZ"`
+	Rate    int              `json:"rate"`
+	Entries []EncounterEntry `json:"entries"`
+}
+
+// EncounterConfig contains the EncounterTable's of every map that has any.
+type EncounterConfig struct {
+	Tables []EncounterTable
+}
+
+// EncounterModifier modifies the odds of a Player encountering wild
+// monsters, for as long as it has not expired.
+type EncounterModifier interface {
+	// ModifyRate returns the given encounter rate, modified.
+	ModifyRate(rate int) int
+
+	// Permits returns whether the Player may encounter a wild monster of
+	// the given level.
+	Permits(plr *Player, level int) bool
+
+	// Step is called for every step the Player takes in the grass.
+	// Returns false once the EncounterModifier has expired.
+	Step() bool
+}
+
+// Repel is an EncounterModifier that keeps away wild monsters of a lower
+// level than the first monster of the Player's party that has not
+// fainted, for a number of steps.
+type Repel struct {
+	Steps int
+}
+
+// Encounters rolls wild encounters for players that step into the grass
+// and hands the wild monsters that are encountered to the subscribed
+// callbacks.
+type Encounters struct {
+	config    *EncounterConfig
+	assets    *AssetBundle
+	factory   *EntityFactory
+	generator *MonsterGenerator
+	dayNight  *DayNightProcessor
+	random    *rand.Rand
+	logger    *zap.SugaredLogger
+	callbacks []func(plr *Player, wild *Monster) error
+}
+
+// UnmarshalJSON unmarshals a TimeOfDay from its name.
+func (time *TimeOfDay) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return err
+	}
+
+	timeOfDay, ok := timesOfDayByName[name]
+	if !ok {
+		return fmt.Errorf("unknown time of day %q", name)
+	}
+
+	*time = timeOfDay
+	return nil
+}
+
+// TimeOfDayAt returns the TimeOfDay at the specified hour of the day.
+func TimeOfDayAt(hour int) TimeOfDay {
+	switch {
+	case hour >= morningHour && hour < dayHour:
+		return Morning
+	case hour >= dayHour && hour < nightHour:
+		return Day
+	default:
+		return Night
+	}
+}
+
+// LoadEncounterConfigsAt loads all of the encounter table config files
+// found within the specified directory. May return an error.
+func LoadEncounterConfigsAt(directory string) (*EncounterConfig, error) {
+	fileInfo, err := ioutil.ReadDir(directory)
+	if err != nil {
+		return nil, err
+	}
+
+	config := new(EncounterConfig)
+	for _, file := range fileInfo {
+		fileBytes, err := ioutil.ReadFile(filepath.Join(directory, file.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		table := EncounterTable{}
+		if err := json.Unmarshal(fileBytes, &table); err != nil {
+			return nil, err
+		}
+
+		config.Tables = append(config.Tables, table)
+	}
+
+	return config, nil
+}
+
+// Count returns the amount of loaded tables this EncounterConfig holds.
+func (config *EncounterConfig) Count() int {
+	return len(config.Tables)
+}
+
+// Get looks up the EncounterTable of the map at the specified coordinates.
+// Returns nil if there are no encounters on the map.
+func (config *EncounterConfig) Get(mapX, mapZ int) *EncounterTable {
+	for i, table := range config.Tables {
+		if table.MapX == mapX && table.MapZ == mapZ {
+			return &config.Tables[i]
+		}
+	}
+
+	return nil
+}
+
+// validateEncounters checks that every EncounterTable is sound and only
+// holds monsters that exist.
+func validateEncounters(encounters *EncounterConfig, monsters *MonsterConfig) error {
+	seen := make(map[[2]int]bool)
+	for _, table := range encounters.Tables {
+		key := [2]int{table.MapX, table.MapZ}
+		if seen[key] {
+			return fmt.Errorf("map %v %v has more than one encounter table", table.MapX, table.MapZ)
+		}
+
+		seen[key] = true
+
+		if table.Rate < 0 || table.Rate > MaxEncounterRate {
+			return fmt.Errorf("encounter rate of map %v %v must be between 0 and %v", table.MapX, table.MapZ, MaxEncounterRate)
+		}
+
+		for _, entry := range table.Entries {
+			if monsters.Get(entry.Monster) == nil {
+				return fmt.Errorf("map %v %v has encounters with unknown monster %v", table.MapX, table.MapZ, entry.Monster)
+			}
+
+			if entry.MinLevel < MinLevel || entry.MaxLevel > MaxLevel || entry.MinLevel > entry.MaxLevel {
+				return fmt.Errorf("map %v %v has encounters with monster %v of invalid level range %v-%v", table.MapX, table.MapZ, entry.Monster, entry.MinLevel, entry.MaxLevel)
+			}
+
+			if entry.Weight <= 0 {
+				return fmt.Errorf("map %v %v has encounters with monster %v of non-positive weight", table.MapX, table.MapZ, entry.Monster)
+			}
+		}
+	}
+
+	return nil
+}
+
+// availableAt returns whether the monster of the EncounterEntry can be
+// encountered at the given TimeOfDay.
+func (entry EncounterEntry) availableAt(timeOfDay TimeOfDay) bool {
+	if len(entry.Times) == 0 {
+		return true
+	}
+
+	for _, time := range entry.Times {
+		if time == timeOfDay {
+			return true
+		}
+	}
+
+	return false
+}
+
+// pick picks an entry of the EncounterTable that can be encountered at the
+// given TimeOfDay, by weight. Returns nil if there is no such entry.
+func (table *EncounterTable) pick(random *rand.Rand, timeOfDay TimeOfDay) *EncounterEntry {
+	totalWeight := 0
+	for _, entry := range table.Entries {
+		if entry.availableAt(timeOfDay) {
+			totalWeight += entry.Weight
+		}
+	}
+
+	if totalWeight == 0 {
+		return nil
+	}
+
+	roll := random.Intn(totalWeight)
+	for i, entry := range table.Entries {
+		if !entry.availableAt(timeOfDay) {
+			continue
+		}
+
+		if roll < entry.Weight {
+			return &table.Entries[i]
+		}
+
+		roll -= entry.Weight
+	}
+
+	return nil
+}
+
+// NewRepel constructs a new Repel that lasts for the given amount of steps.
+func NewRepel(steps int) *Repel {
+	return &Repel{Steps: steps}
+}
+
+// ModifyRate leaves the given encounter rate untouched.
+func (repel *Repel) ModifyRate(rate int) int {
+	return rate
+}
+
+// Permits returns whether the given level is at least the level of the
+// first monster of the Player's party that has not fainted.
+func (repel *Repel) Permits(plr *Player, level int) bool {
+	belt := plr.PartyBelt()
+	for slot := 0; slot < belt.Size; slot++ {
+		monster, err := belt.Get(slot)
+		if err != nil || monster == nil || monster.HitPoints <= 0 {
+			continue
+		}
+
+		return level >= monster.Level
+	}
+
+	return true
+}
+
+// Step counts down the steps the Repel lasts for.
+func (repel *Repel) Step() bool {
+	repel.Steps--
+	return repel.Steps > 0
+}
+
+// AddEncounterModifier adds the given EncounterModifier to the Player,
+// to modify its odds of encountering wild monsters until it expires.
+func (plr *Player) AddEncounterModifier(modifier EncounterModifier) {
+	component := plr.GetComponent(EncounterTag).(*EncounterComponent)
+	component.Modifiers = append(component.Modifiers, modifier)
+}
+
+// NewEncounters constructs a new instance of Encounters, which generates
+// wild monsters through the given MonsterGenerator and looks up the time
+// of day from the given DayNightProcessor.
+func NewEncounters(assets *AssetBundle, factory *EntityFactory, generator *MonsterGenerator, dayNight *DayNightProcessor, random *rand.Rand, logger *zap.SugaredLogger) *Encounters {
+	config := assets.Encounters
+	if config == nil {
+		config = new(EncounterConfig)
+	}
+
+	return &Encounters{
+		config:    config,
+		assets:    assets,
+		factory:   factory,
+		generator: generator,
+		dayNight:  dayNight,
+		random:    random,
+		logger:    logger,
+	}
+}
+
+// OnEncounter subscribes the given callback to wild encounters.
+func (encounters *Encounters) OnEncounter(callback func(plr *Player, wild *Monster) error) {
+	encounters.callbacks = append(encounters.callbacks, callback)
+}
+
+// Step rolls whether the Player encounters a wild monster, now that it
+// has taken a step onto its current Position. A callback that fails to
+// start the encounter is logged rather than returned, as it should not
+// hold up the movement of other entities.
+func (encounters *Encounters) Step(plr *Player, grid *Grid) error {
+	if plr.InBattle() || plr.InTrade() || !hasUsableMonster(plr.PartyBelt()) {
+		return nil
+	}
+
+	position := plr.Position()
+
+	tileMap, err := grid.GetMap(position.MapX, position.MapZ)
+	if err != nil || tileMap == nil {
+		return err
+	}
+
+	if inGrass, _ := tileMap.CollisionMatrix.Contains(position.LocalX, position.LocalZ, collision.Grass); !inGrass {
+		return nil
+	}
+
+	table := encounters.config.Get(position.MapX, position.MapZ)
+	if table == nil {
+		return nil
+	}
+
+	modifiers := encounters.stepModifiers(plr)
+
+	rate := table.Rate
+	for _, modifier := range modifiers {
+		rate = modifier.ModifyRate(rate)
+	}
+
+	if encounters.random.Intn(MaxEncounterRate) >= rate {
+		return nil
+	}
+
+	entry := table.pick(encounters.random, encounters.dayNight.TimeOfDay())
+	if entry == nil {
+		return nil
+	}
+
+	level := entry.MinLevel + encounters.random.Intn(entry.MaxLevel-entry.MinLevel+1)
+	for _, modifier := range modifiers {
+		if !modifier.Permits(plr, level) {
+			return nil
+		}
+	}
+
+	wild := encounters.generate(position, entry.Monster, level)
+	for _, callback := range encounters.callbacks {
+		if err := callback(plr, wild); err != nil {
+			encounters.logger.Errorf("failed to start encounter of %v with %v: %v", plr.DisplayName(), wild.ModelID, err)
+		}
+	}
+
+	return nil
+}
+
+// stepModifiers steps the EncounterModifier's of the Player, removing those
+// that expire. Returns the modifiers that apply to the step.
+func (encounters *Encounters) stepModifiers(plr *Player) []EncounterModifier {
+	component := plr.GetComponent(EncounterTag).(*EncounterComponent)
+
+	modifiers := component.Modifiers
+	component.Modifiers = nil
+
+	for _, modifier := range modifiers {
+		if modifier.Step() {
+			component.Modifiers = append(component.Modifiers, modifier)
+		}
+	}
+
+	return modifiers
+}
+
+// generate generates a wild Monster of the specified ModelID and level.
+func (encounters *Encounters) generate(position Position, modelID ModelID, level int) *Monster {
+	data := encounters.generator.Generate(encounters.assets.Monsters.Get(modelID), level)
+	return MonsterBy(encounters.factory.CreateMonster(position, &data), data)
+}
+
+// hasUsableMonster returns whether the given PartyBelt holds a monster
+// that has not fainted.
+func hasUsableMonster(belt *PartyBelt) bool {
+	for slot := 0; slot < belt.Size; slot++ {
+		if monster, err := belt.Get(slot); err == nil && monster != nil && monster.HitPoints > 0 {
+			return true
+		}
+	}
+
+	return false
+}
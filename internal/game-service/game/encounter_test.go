@@ -0,0 +1,204 @@
+package game
+
+import (
+	"math/rand"
+	"testing"
+
+	"gitlab.com/pokesync/game-service/internal/game-service/game/collision"
+	"go.uber.org/zap"
+)
+
+func TestLoadEncounterConfigsAt(t *testing.T) {
+	encounters, err := LoadEncounterConfigsAt("../../../assets/config/encounter")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	monsters, err := LoadMonsterConfigsAt("../../../assets/config/monster")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := validateEncounters(encounters, monsters); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestTimeOfDayAt(t *testing.T) {
+	tests := []struct {
+		hour     int
+		expected TimeOfDay
+	}{
+		{0, Night}, {4, Morning}, {9, Morning}, {10, Day}, {19, Day}, {20, Night},
+	}
+
+	for _, test := range tests {
+		if timeOfDay := TimeOfDayAt(test.hour); timeOfDay != test.expected {
+			t.Errorf("expected time of day %v at hour %v but was %v instead", test.expected, test.hour, timeOfDay)
+		}
+	}
+}
+
+func TestEncounterTable_PicksByTimeOfDay(t *testing.T) {
+	table := &EncounterTable{Entries: []EncounterEntry{
+		{Monster: 1, Weight: 1, Times: []TimeOfDay{Night}},
+		{Monster: 2, Weight: 1, Times: []TimeOfDay{Morning, Day}},
+	}}
+
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 10; i++ {
+		if entry := table.pick(random, Night); entry.Monster != 1 {
+			t.Fatalf("expected monster %v to be picked at night but was %v instead", 1, entry.Monster)
+		}
+	}
+}
+
+// newEncounterTestSetup sets up a single map of which only the tile at
+// 1,1 is grass, on which every step leads to an encounter.
+func newEncounterTestSetup() (*Encounters, *Grid, *Player) {
+	assets := &AssetBundle{
+		Monsters: &MonsterConfig{Descriptors: []MonsterDescriptor{testRattata}},
+		Moves:    &MoveConfig{},
+		Items:    &ItemConfig{Descriptors: []ItemDescriptor{{ID: 0, Name: "repel", Pocket: ItemsPocket, RepelSteps: 2}}},
+		Encounters: &EncounterConfig{Tables: []EncounterTable{
+			{Rate: MaxEncounterRate, Entries: []EncounterEntry{{Monster: 0, MinLevel: 3, MaxLevel: 3, Weight: 1}}},
+		}},
+	}
+
+	matrix := collision.NewMatrix(2, 2)
+	matrix.Add(1, 1, collision.Grass)
+
+	grid := NewGrid(1, 1)
+	grid.TileMaps[0][0] = &TileMap{CollisionMatrix: matrix}
+
+	fixture := newTestFixture(assets, 4)
+	encounters := NewEncounters(assets, fixture.factory, NewMonsterGenerator(assets.Moves, 0, rand.New(rand.NewSource(1))), NewDayNightProcessor(nil), rand.New(rand.NewSource(1)), zap.NewNop().Sugar())
+
+	plr := fixture.newPlayer("Sino", Position{LocalX: 1, LocalZ: 1})
+	fixture.fillParty(plr, 5, 0)
+
+	return encounters, grid, plr
+}
+
+func TestEncounters_StepInGrass(t *testing.T) {
+	encounters, grid, plr := newEncounterTestSetup()
+
+	var encountered *Monster
+	encounters.OnEncounter(func(plr *Player, wild *Monster) error {
+		encountered = wild
+		return nil
+	})
+
+	if err := encounters.Step(plr, grid); err != nil {
+		t.Fatal(err)
+	}
+
+	if encountered == nil || encountered.Level != 3 {
+		t.Fatal("expected a wild monster of level 3 to have been encountered")
+	}
+
+	if encountered.HitPoints != encountered.Stats(&MonsterDescriptor{BaseStats: [7]int{30, 56, 35, 25, 35, 72}})[HitPoints] {
+		t.Error("expected the wild monster to be at full health")
+	}
+
+	encountered = nil
+
+	plr.GetComponent(TransformTag).(*TransformComponent).MovementQueue.Position = Position{}
+	encounters.Step(plr, grid)
+
+	if encountered != nil {
+		t.Error("expected no wild monster to be encountered outside of the grass")
+	}
+}
+
+func TestEncounters_Repel(t *testing.T) {
+	encounters, grid, plr := newEncounterTestSetup()
+
+	encounterCount := 0
+	encounters.OnEncounter(func(plr *Player, wild *Monster) error {
+		encounterCount++
+		return nil
+	})
+
+	plr.AddEncounterModifier(NewRepel(2))
+
+	encounters.Step(plr, grid)
+	encounters.Step(plr, grid)
+	if encounterCount != 0 {
+		t.Fatal("expected the repel to keep away weaker wild monsters")
+	}
+
+	encounters.Step(plr, grid)
+	if encounterCount != 1 {
+		t.Error("expected the repel to have worn off")
+	}
+}
+
+func TestEncounters_WalkWithRepelItem(t *testing.T) {
+	encounters, grid, plr := newEncounterTestSetup()
+
+	encounterCount := 0
+	encounters.OnEncounter(func(plr *Player, wild *Monster) error {
+		encounterCount++
+		return nil
+	})
+
+	if err := plr.Inventory().Add(0, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := useItem()(plr, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	if plr.Inventory().Count(0) != 0 {
+		t.Error("expected the repel to have been taken out of the inventory")
+	}
+
+	queue := plr.GetComponent(TransformTag).(*TransformComponent).MovementQueue
+	walkIntoGrass := func() {
+		queue.AddStep(West)
+		queue.AddStep(East)
+
+		for i := 0; i < 2; i++ {
+			if err := takeMovementSimulationStep(plr.Entity, queue, nil, grid, encounters); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	walkIntoGrass()
+	walkIntoGrass()
+	if encounterCount != 0 {
+		t.Fatal("expected the repel to keep away weaker wild monsters whilst walking through the grass")
+	}
+
+	walkIntoGrass()
+	if encounterCount != 1 {
+		t.Error("expected the repel to have worn off")
+	}
+
+	if err := useItem()(plr, 0); err != ErrNotEnoughItems {
+		t.Errorf("expected %v but was %v", ErrNotEnoughItems, err)
+	}
+}
+
+func TestEncounters_StepWhileTrading(t *testing.T) {
+	encounters, grid, plr := newEncounterTestSetup()
+
+	encountered := false
+	encounters.OnEncounter(func(plr *Player, wild *Monster) error {
+		encountered = true
+		return ErrInTrade
+	})
+
+	plr.GetComponent(TradeTag).(*TradeComponent).Session = &TradeSession{}
+	if err := encounters.Step(plr, grid); err != nil || encountered {
+		t.Errorf("expected a trading player to not encounter anything but was %v", err)
+	}
+
+	plr.GetComponent(TradeTag).(*TradeComponent).Session = nil
+	if err := encounters.Step(plr, grid); err != nil || !encountered {
+		t.Errorf("expected the failed encounter to be logged rather than returned but was %v", err)
+	}
+}
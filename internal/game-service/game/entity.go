@@ -46,6 +46,7 @@ func (factory *EntityFactory) CreatePlayer(position Position, gender Gender, dis
 		With(&TransformComponent{MovementQueue: NewMovementQueue(position)}).
 		With(&UsernameComponent{DisplayName: displayName}).
 		With(&RankComponent{UserGroup: userGroup}).
+		With(&GenderComponent{Gender: gender}).
 		With(&TrackingComponent{}).
 		With(&MapViewComponent{MapView: NewMapView()}).
 		With(&BicycleComponent{BicycleType: NoBike}).
@@ -53,6 +54,14 @@ func (factory *EntityFactory) CreatePlayer(position Position, gender Gender, dis
 		With(&KindComponent{Kind: PlayerKind}).
 		With(&CoinBagComponent{CoinBag: NewCoinBag()}).
 		With(&PartyBeltComponent{PartyBelt: NewPartyBelt()}).
+		With(&PCStorageComponent{PCStorage: NewPCStorage()}).
+		With(&BattleComponent{}).
+		With(&EncounterComponent{}).
+		With(&EvolutionComponent{}).
+		With(&LearnMoveComponent{}).
+		With(&TradeComponent{}).
+		With(&RespawnComponent{}).
+		With(&InventoryComponent{Inventory: NewInventory(factory.assets.Items)}).
 		With(&WaryOfTimeComponent{}).
 		Build()
 }
@@ -70,21 +79,58 @@ func (factory *EntityFactory) CreateNpc(position Position, modelID ModelID) *ent
 }
 
 // CreateMonster creates the set of Component's to create a Monster-like Entity from.
-func (factory *EntityFactory) CreateMonster(position Position, modelID ModelID) *entity.Entity {
+// Its health is derived from the stats of the given MonsterData.
+func (factory *EntityFactory) CreateMonster(position Position, data *MonsterData) *entity.Entity {
+	maxHitPoints := 1
+	if descriptor := factory.assets.Monsters.Get(data.ModelID); descriptor != nil {
+		maxHitPoints = data.Stats(descriptor)[HitPoints]
+	}
+
 	return factory.world.
 		CreateEntity().
-		With(&ModelIDComponent{ModelID: modelID}).
+		With(&ModelIDComponent{ModelID: data.ModelID}).
 		With(&TransformComponent{MovementQueue: NewMovementQueue(position)}).
 		With(&TrackingComponent{}).
-		With(&HealthComponent{Max: 1, Current: 1}). // TODO
+		With(&HealthComponent{Max: maxHitPoints, Current: data.HitPoints}).
 		With(&KindComponent{Kind: MonsterKind}).
 		Build()
 }
 
 // CreateObject creates the set of Component's to create a Object-like Entity from.
-func (factory *EntityFactory) CreateObject(position Position) *entity.Entity {
+func (factory *EntityFactory) CreateObject(position Position, modelID ModelID) *entity.Entity {
 	return factory.world.
 		CreateEntity().
+		With(&ModelIDComponent{ModelID: modelID}).
 		With(&TransformComponent{MovementQueue: NewMovementQueue(position)}).
+		With(&KindComponent{Kind: ObjectKind}).
 		Build()
 }
+
+// EntityIndex indexes the entities that are in the game world by their
+// ID, so they can be looked up when a Player refers to one.
+type EntityIndex struct {
+	entities map[entity.ID]*entity.Entity
+}
+
+// NewEntityIndex constructs a new instance of an empty EntityIndex.
+func NewEntityIndex() *EntityIndex {
+	return &EntityIndex{entities: make(map[entity.ID]*entity.Entity)}
+}
+
+// Put indexes the given Entity by its ID.
+func (index *EntityIndex) Put(e *entity.Entity) {
+	index.entities[e.ID] = e
+}
+
+// Remove removes the given Entity from the index, unless its ID has
+// already been reassigned to another Entity.
+func (index *EntityIndex) Remove(e *entity.Entity) {
+	if index.entities[e.ID] == e {
+		delete(index.entities, e.ID)
+	}
+}
+
+// Get looks up the Entity of the specified ID. May return nil.
+func (index *EntityIndex) Get(id entity.ID) *entity.Entity {
+	return index.entities[id]
+}
@@ -0,0 +1,359 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"gitlab.com/pokesync/game-service/internal/game-service/game/transport"
+)
+
+// These are the triggers that evolve a monster.
+const (
+	LevelTrigger     EvolutionTrigger = 0
+	ItemTrigger      EvolutionTrigger = 1
+	TradeTrigger     EvolutionTrigger = 2
+	HappinessTrigger EvolutionTrigger = 3
+)
+
+// DefaultEvolutionHappiness is the happiness a monster has to have reached
+// to evolve through a HappinessTrigger that does not specify any.
+const DefaultEvolutionHappiness = 220
+
+var (
+	// ErrCannotEvolve is returned when attempting to evolve a monster
+	// that can not evolve in the attempted way.
+	ErrCannotEvolve = errors.New("monster can not evolve")
+
+	// ErrNoPendingEvolution is returned when a Player answers to an
+	// evolution that was never offered.
+	ErrNoPendingEvolution = errors.New("monster has no pending evolution")
+)
+
+// evolutionTriggersByName maps the names of EvolutionTrigger's in asset
+// files.
+var evolutionTriggersByName = map[string]EvolutionTrigger{
+	"LEVEL":     LevelTrigger,
+	"ITEM":      ItemTrigger,
+	"TRADE":     TradeTrigger,
+	"HAPPINESS": HappinessTrigger,
+}
+
+// EvolutionTrigger is what sets an evolution in motion.
+type EvolutionTrigger int
+
+// EvolutionMethod describes what it takes for a monster to evolve. Level
+// is the lowest level at which a monster evolves through a LevelTrigger
+// or a HappinessTrigger, both of which are triggered by levelling up.
+// Item is the item that has to be used on a monster for an ItemTrigger.
+type EvolutionMethod struct {
+	Trigger   EvolutionTrigger `json:"trigger"`
+	Level     int              `json:"level"`
+	Item      int              `json:"item"`
+	Happiness int              `json:"happiness"`
+
+	// Times are the times of day at which the monster can evolve. The
+	// monster can evolve at any time of day if empty.
+	Times []TimeOfDay `json:"times"`
+}
+
+// Evolution is a monster another monster can evolve into, along with the
+// EvolutionMethod by which it does.
+type Evolution struct {
+	Into ModelID `json:"into"`
+	EvolutionMethod
+}
+
+// PendingEvolution is an evolution that was offered to a Player, which it
+// has yet to either accept or cancel.
+type PendingEvolution struct {
+	Monster *Monster
+	Into    ModelID
+}
+
+// Evolutions evolves the monsters of players.
+type Evolutions struct {
+	monsters *MonsterConfig
+	dayNight *DayNightProcessor
+}
+
+// UnmarshalJSON unmarshals an EvolutionTrigger from its name.
+func (trigger *EvolutionTrigger) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return err
+	}
+
+	evolutionTrigger, ok := evolutionTriggersByName[name]
+	if !ok {
+		return fmt.Errorf("unknown evolution trigger %q", name)
+	}
+
+	*trigger = evolutionTrigger
+	return nil
+}
+
+// linkEvolutions computes the Evolutions of every monster from the
+// PreviousEvo links of the monsters that evolve from it, unless they were
+// given explicitly, and checks that every Evolution is valid. Links to
+// monsters that are not loaded are ignored.
+func linkEvolutions(monsters *MonsterConfig, items *ItemConfig) error {
+	for i := range monsters.Descriptors {
+		monster := &monsters.Descriptors[i]
+		if monster.PreviousEvo <= 0 {
+			continue
+		}
+
+		previous := monsters.Get(ModelID(monster.PreviousEvo))
+		if previous == nil {
+			continue
+		}
+
+		if monster.Evolution == nil {
+			return fmt.Errorf("monster %v evolves from %v but has no evolution method", monster.Name, previous.Name)
+		}
+
+		if previous.EvolutionInto(ModelID(monster.ID)) == nil {
+			previous.Evolutions = append(previous.Evolutions, Evolution{Into: ModelID(monster.ID), EvolutionMethod: *monster.Evolution})
+		}
+	}
+
+	for _, monster := range monsters.Descriptors {
+		for _, evolution := range monster.Evolutions {
+			if monsters.Get(evolution.Into) == nil {
+				return fmt.Errorf("monster %v evolves into unknown monster %v", monster.Name, evolution.Into)
+			}
+
+			switch evolution.Trigger {
+			case LevelTrigger:
+				if evolution.Level < MinLevel || evolution.Level > MaxLevel {
+					return fmt.Errorf("monster %v evolves at invalid level %v", monster.Name, evolution.Level)
+				}
+
+			case ItemTrigger:
+				if items.Get(evolution.Item) == nil {
+					return fmt.Errorf("monster %v evolves with unknown item %v", monster.Name, evolution.Item)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// EvolutionInto returns the Evolution of the described monster into the
+// specified monster. Returns nil if it does not evolve into it.
+func (descriptor *MonsterDescriptor) EvolutionInto(modelID ModelID) *Evolution {
+	for i := range descriptor.Evolutions {
+		if descriptor.Evolutions[i].Into == modelID {
+			return &descriptor.Evolutions[i]
+		}
+	}
+
+	return nil
+}
+
+// permits returns whether the given monster can evolve through the
+// EvolutionMethod as it is set in motion by the given trigger, at the
+// given TimeOfDay. The item is only of relevance to an ItemTrigger.
+func (method EvolutionMethod) permits(data *MonsterData, trigger EvolutionTrigger, item int, timeOfDay TimeOfDay) bool {
+	switch method.Trigger {
+	case LevelTrigger:
+		if trigger != LevelTrigger || data.Level < method.Level {
+			return false
+		}
+
+	case HappinessTrigger:
+		happiness := method.Happiness
+		if happiness == 0 {
+			happiness = DefaultEvolutionHappiness
+		}
+
+		if trigger != LevelTrigger || data.Level < method.Level || data.Happiness < happiness {
+			return false
+		}
+
+	case ItemTrigger:
+		if trigger != ItemTrigger || item != method.Item {
+			return false
+		}
+
+	case TradeTrigger:
+		if trigger != TradeTrigger {
+			return false
+		}
+
+	default:
+		return false
+	}
+
+	if len(method.Times) == 0 {
+		return true
+	}
+
+	for _, time := range method.Times {
+		if time == timeOfDay {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Evolve evolves the monster from the monster of the first descriptor
+// into the monster of the second descriptor. Its identity, such as its
+// nickname, original trainer, individual values and effort values, is
+// kept. The hit points it gains in maximum health are also added to its
+// current health.
+func (data *MonsterData) Evolve(from, into *MonsterDescriptor) {
+	maxHitPointsBefore := data.Stats(from)[HitPoints]
+
+	data.ModelID = ModelID(into.ID)
+	if data.HitPoints > 0 {
+		data.HitPoints += data.Stats(into)[HitPoints] - maxHitPointsBefore
+	}
+}
+
+// Evolve evolves the Monster from the monster of the first descriptor
+// into the monster of the second descriptor, keeping its HealthComponent
+// in sync with its new maximum health.
+func (mon *Monster) Evolve(from, into *MonsterDescriptor) {
+	mon.MonsterData.Evolve(from, into)
+
+	mon.GetComponent(ModelIDTag).(*ModelIDComponent).ModelID = mon.ModelID
+	mon.SyncHealth(into)
+}
+
+// NewEvolutions constructs a new instance of Evolutions.
+func NewEvolutions(monsters *MonsterConfig, dayNight *DayNightProcessor) *Evolutions {
+	return &Evolutions{monsters: monsters, dayNight: dayNight}
+}
+
+// Find looks up the Evolution the given monster goes through as it is set
+// in motion by the given trigger. The item is only of relevance to an
+// ItemTrigger. Returns nil if the monster does not evolve.
+func (evolutions *Evolutions) Find(data *MonsterData, trigger EvolutionTrigger, item int) *Evolution {
+	descriptor := evolutions.monsters.Get(data.ModelID)
+	if descriptor == nil {
+		return nil
+	}
+
+	timeOfDay := evolutions.dayNight.TimeOfDay()
+	for i, evolution := range descriptor.Evolutions {
+		if evolution.permits(data, trigger, item, timeOfDay) {
+			return &descriptor.Evolutions[i]
+		}
+	}
+
+	return nil
+}
+
+// OfferAfterLevelUp offers the Player to evolve the monster in the
+// specified slot of its PartyBelt, if the monster evolves now that it
+// has levelled up. The Player may either accept or cancel the evolution.
+// Returns whether an evolution was offered.
+func (evolutions *Evolutions) OfferAfterLevelUp(plr *Player, slot int) bool {
+	monster, err := plr.PartyBelt().Get(slot)
+	if err != nil || monster == nil {
+		return false
+	}
+
+	evolution := evolutions.Find(monster.MonsterData, LevelTrigger, 0)
+	if evolution == nil {
+		return false
+	}
+
+	// a monster that levels up again before its offer is answered, replaces
+	// its earlier offer rather than piling up another one
+	plr.takePendingEvolution(monster)
+
+	component := plr.GetComponent(EvolutionTag).(*EvolutionComponent)
+	component.Pending = append(component.Pending, PendingEvolution{Monster: monster, Into: evolution.Into})
+
+	plr.QueueEvent(&transport.OfferEvolution{PartySlot: byte(slot), IntoMonsterID: uint16(evolution.Into)})
+	return true
+}
+
+// EvolveByItem evolves the monster in the specified slot of the Player's
+// PartyBelt with the specified item. Returns ErrCannotEvolve if the item
+// has no effect on the monster.
+func (evolutions *Evolutions) EvolveByItem(plr *Player, slot int, item int) error {
+	monster, err := plr.PartyBelt().Get(slot)
+	if err != nil {
+		return err
+	}
+
+	if monster == nil {
+		return ErrCannotEvolve
+	}
+
+	evolution := evolutions.Find(monster.MonsterData, ItemTrigger, item)
+	if evolution == nil {
+		return ErrCannotEvolve
+	}
+
+	return evolutions.evolve(plr, slot, monster, evolution.Into)
+}
+
+// EvolveByTrade evolves the given monster if it evolves by being traded.
+// Returns whether it evolved.
+func (evolutions *Evolutions) EvolveByTrade(data *MonsterData) bool {
+	evolution := evolutions.Find(data, TradeTrigger, 0)
+	if evolution == nil {
+		return false
+	}
+
+	data.Evolve(evolutions.monsters.Get(data.ModelID), evolutions.monsters.Get(evolution.Into))
+	return true
+}
+
+// evolve evolves the given Monster in the specified slot of the Player's
+// PartyBelt into the specified monster and lets the Player know.
+func (evolutions *Evolutions) evolve(plr *Player, slot int, monster *Monster, into ModelID) error {
+	monster.Evolve(evolutions.monsters.Get(monster.ModelID), evolutions.monsters.Get(into))
+
+	_, err := plr.PartyBelt().Set(slot, monster)
+	return err
+}
+
+// takePendingEvolution removes the PendingEvolution of the given Monster
+// from the Player, if it has one.
+func (plr *Player) takePendingEvolution(monster *Monster) (PendingEvolution, bool) {
+	component := plr.GetComponent(EvolutionTag).(*EvolutionComponent)
+	for i, pending := range component.Pending {
+		if pending.Monster == monster {
+			component.Pending = append(component.Pending[:i], component.Pending[i+1:]...)
+			return pending, true
+		}
+	}
+
+	return PendingEvolution{}, false
+}
+
+func answerEvolution(evolutions *Evolutions) answerEvolutionHandler {
+	return func(plr *Player, partySlot int, accept bool) error {
+		if plr.InBattle() {
+			return ErrInBattle
+		}
+
+		if plr.InTrade() {
+			return ErrInTrade
+		}
+
+		monster, err := plr.PartyBelt().Get(partySlot)
+		if err != nil {
+			return err
+		}
+
+		pending, ok := plr.takePendingEvolution(monster)
+		if !ok {
+			return ErrNoPendingEvolution
+		}
+
+		if !accept {
+			return nil
+		}
+
+		return evolutions.evolve(plr, partySlot, monster, pending.Into)
+	}
+}
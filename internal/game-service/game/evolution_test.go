@@ -0,0 +1,172 @@
+package game
+
+import "testing"
+
+func TestLinkEvolutions(t *testing.T) {
+	monsters, err := LoadMonsterConfigsAt("../../../assets/config/monster")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	items, err := LoadItemConfigsAt("../../../assets/config/item")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := linkEvolutions(monsters, items); err != nil {
+		t.Fatal(err)
+	}
+
+	// bulbasaur evolves into ivysaur at level 16
+	evolution := monsters.Get(1).EvolutionInto(2)
+	if evolution == nil || evolution.Trigger != LevelTrigger || evolution.Level != 16 {
+		t.Errorf("expected bulbasaur to evolve into ivysaur at level 16 but was %+v", evolution)
+	}
+
+	// eevee evolves into vaporeon, jolteon and flareon
+	if count := len(monsters.Get(133).Evolutions); count != 3 {
+		t.Errorf("expected eevee to have 3 evolutions but had %v", count)
+	}
+}
+
+func TestLinkEvolutions_KeepsExplicitEvolutions(t *testing.T) {
+	monsters := &MonsterConfig{Descriptors: []MonsterDescriptor{
+		{ID: 0, Name: "MISSINGNO"},
+		{ID: 1, Name: "EEVEE", Evolutions: []Evolution{{Into: 2, EvolutionMethod: EvolutionMethod{Trigger: HappinessTrigger, Times: []TimeOfDay{Night}}}}},
+		{ID: 2, Name: "UMBREON", PreviousEvo: 1, Evolution: &EvolutionMethod{Trigger: LevelTrigger, Level: 5}},
+		{ID: 3, Name: "FLAREON", PreviousEvo: 1, Evolution: &EvolutionMethod{Trigger: ItemTrigger, Item: 0}},
+	}}
+
+	if err := linkEvolutions(monsters, &ItemConfig{Descriptors: []ItemDescriptor{{ID: 0, Name: "fire-stone"}}}); err != nil {
+		t.Fatal(err)
+	}
+
+	evolutions := monsters.Get(1).Evolutions
+	if len(evolutions) != 2 || evolutions[0].Trigger != HappinessTrigger || evolutions[1].Into != 3 {
+		t.Errorf("expected the explicit evolution to be kept alongside the linked one but was %+v", evolutions)
+	}
+}
+
+func TestLinkEvolutions_RequiresMethod(t *testing.T) {
+	monsters := &MonsterConfig{Descriptors: []MonsterDescriptor{
+		{ID: 0, Name: "MISSINGNO"},
+		{ID: 1, Name: "CATERPIE"},
+		{ID: 2, Name: "METAPOD", PreviousEvo: 1},
+	}}
+
+	if err := linkEvolutions(monsters, &ItemConfig{}); err == nil {
+		t.Error("expected an evolution without a method to be rejected")
+	}
+}
+
+func TestEvolutionMethod_Permits(t *testing.T) {
+	data := &MonsterData{Level: 20, Happiness: 100}
+
+	tests := []struct {
+		method    EvolutionMethod
+		trigger   EvolutionTrigger
+		item      int
+		timeOfDay TimeOfDay
+		expected  bool
+	}{
+		{EvolutionMethod{Trigger: LevelTrigger, Level: 16}, LevelTrigger, 0, Day, true},
+		{EvolutionMethod{Trigger: LevelTrigger, Level: 21}, LevelTrigger, 0, Day, false},
+		{EvolutionMethod{Trigger: LevelTrigger, Level: 16}, TradeTrigger, 0, Day, false},
+		{EvolutionMethod{Trigger: ItemTrigger, Item: 4}, ItemTrigger, 4, Day, true},
+		{EvolutionMethod{Trigger: ItemTrigger, Item: 4}, ItemTrigger, 5, Day, false},
+		{EvolutionMethod{Trigger: TradeTrigger}, TradeTrigger, 0, Day, true},
+		{EvolutionMethod{Trigger: HappinessTrigger}, LevelTrigger, 0, Day, false},
+		{EvolutionMethod{Trigger: HappinessTrigger, Happiness: 100}, LevelTrigger, 0, Day, true},
+		{EvolutionMethod{Trigger: HappinessTrigger, Happiness: 100, Times: []TimeOfDay{Night}}, LevelTrigger, 0, Day, false},
+		{EvolutionMethod{Trigger: HappinessTrigger, Happiness: 100, Times: []TimeOfDay{Night}}, LevelTrigger, 0, Night, true},
+	}
+
+	for i, test := range tests {
+		if permits := test.method.permits(data, test.trigger, test.item, test.timeOfDay); permits != test.expected {
+			t.Errorf("expected test %v to be %v but was %v", i, test.expected, permits)
+		}
+	}
+}
+
+func newEvolutionTestSetup() (*Evolutions, *Player) {
+	assets := &AssetBundle{
+		Monsters: &MonsterConfig{Descriptors: []MonsterDescriptor{
+			{ID: 0, Name: "CHARMANDER", BaseStats: [7]int{39, 52, 43, 60, 50, 65}, Evolutions: []Evolution{
+				{Into: 1, EvolutionMethod: EvolutionMethod{Trigger: LevelTrigger, Level: 16}},
+			}},
+			{ID: 1, Name: "CHARMELEON", BaseStats: [7]int{58, 64, 58, 80, 65, 80}},
+		}},
+		Moves: &MoveConfig{},
+	}
+
+	fixture := newTestFixture(assets, 4)
+	plr := fixture.newPlayer("Sino", Position{})
+
+	data := NewMonsterData(assets.Monsters.Get(0), assets.Moves, 16)
+	data.Nickname = "Blaze"
+	fixture.addToParty(plr, data)
+
+	return NewEvolutions(assets.Monsters, NewDayNightProcessor(nil)), plr
+}
+
+func TestEvolutions_AcceptOffer(t *testing.T) {
+	evolutions, plr := newEvolutionTestSetup()
+
+	if !evolutions.OfferAfterLevelUp(plr, 0) {
+		t.Fatal("expected an evolution to have been offered")
+	}
+
+	monster, _ := plr.PartyBelt().Get(0)
+	if monster.ModelID != 0 {
+		t.Fatal("expected the monster to not evolve before the offer is accepted")
+	}
+
+	if err := answerEvolution(evolutions)(plr, 0, true); err != nil {
+		t.Fatal(err)
+	}
+
+	if monster.ModelID != 1 || monster.Nickname != "Blaze" {
+		t.Errorf("expected Blaze to have evolved but was %+v", monster.MonsterData)
+	}
+
+	health := monster.GetComponent(HealthTag).(*HealthComponent)
+	if health.Current != health.Max || health.Max != monster.Stats(&MonsterDescriptor{BaseStats: [7]int{58, 64, 58, 80, 65, 80}})[HitPoints] {
+		t.Errorf("expected full health of the evolved monster but was %v/%v", health.Current, health.Max)
+	}
+
+	if err := answerEvolution(evolutions)(plr, 0, true); err != ErrNoPendingEvolution {
+		t.Errorf("expected the offer to have been taken but was %v", err)
+	}
+}
+
+func TestEvolutions_CancelOffer(t *testing.T) {
+	evolutions, plr := newEvolutionTestSetup()
+
+	evolutions.OfferAfterLevelUp(plr, 0)
+	if err := answerEvolution(evolutions)(plr, 0, false); err != nil {
+		t.Fatal(err)
+	}
+
+	if monster, _ := plr.PartyBelt().Get(0); monster.ModelID != 0 {
+		t.Error("expected the monster to not have evolved")
+	}
+}
+
+func TestEvolutions_RepeatedOfferReplacesPending(t *testing.T) {
+	evolutions, plr := newEvolutionTestSetup()
+
+	evolutions.OfferAfterLevelUp(plr, 0)
+	evolutions.OfferAfterLevelUp(plr, 0)
+
+	if pending := plr.GetComponent(EvolutionTag).(*EvolutionComponent).Pending; len(pending) != 1 {
+		t.Fatalf("expected %v pending evolution but was %v instead", 1, len(pending))
+	}
+
+	if err := answerEvolution(evolutions)(plr, 0, false); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := answerEvolution(evolutions)(plr, 0, true); err != ErrNoPendingEvolution {
+		t.Errorf("expected no offer to be left but was %v", err)
+	}
+}
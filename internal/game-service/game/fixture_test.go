@@ -0,0 +1,54 @@
+package game
+
+import (
+	"gitlab.com/pokesync/game-service/internal/game-service/character"
+	"gitlab.com/pokesync/game-service/internal/game-service/game/entity"
+)
+
+// testRattata is the monster that most tests fill the party of a Player
+// with.
+var testRattata = MonsterDescriptor{ID: 0, Name: "RATTATA", BaseStats: [7]int{30, 56, 35, 25, 35, 72}}
+
+// testFixture holds the AssetBundle and the EntityFactory that tests
+// create their players and monsters with.
+type testFixture struct {
+	assets  *AssetBundle
+	factory *EntityFactory
+}
+
+// newTestFixture constructs a testFixture of the given AssetBundle, in a
+// world with room for the specified amount of entities. A nil AssetBundle
+// defaults to one that only holds testRattata.
+func newTestFixture(assets *AssetBundle, entityLimit int) *testFixture {
+	if assets == nil {
+		assets = &AssetBundle{
+			Monsters: &MonsterConfig{Descriptors: []MonsterDescriptor{testRattata}},
+			Moves:    &MoveConfig{},
+		}
+	}
+
+	return &testFixture{assets: assets, factory: NewEntityFactory(entity.NewWorld(entityLimit), assets)}
+}
+
+// newPlayer creates a regular Player of the given name at the specified
+// Position.
+func (fixture *testFixture) newPlayer(name string, position Position) *Player {
+	return PlayerBy(fixture.factory.CreatePlayer(position, Man, character.DisplayName(name), character.Regular))
+}
+
+// addToParty creates a monster of the given MonsterData and adds it to the
+// PartyBelt of the Player.
+func (fixture *testFixture) addToParty(plr *Player, data MonsterData) *Monster {
+	monster := MonsterBy(fixture.factory.CreateMonster(Position{}, &data), data)
+	plr.PartyBelt().Add(monster)
+
+	return monster
+}
+
+// fillParty adds a monster of each of the given ModelID's, at the specified
+// level, to the PartyBelt of the Player.
+func (fixture *testFixture) fillParty(plr *Player, level int, models ...ModelID) {
+	for _, model := range models {
+		fixture.addToParty(plr, NewMonsterData(fixture.assets.Monsters.Get(model), fixture.assets.Moves, level))
+	}
+}
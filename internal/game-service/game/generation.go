@@ -0,0 +1,74 @@
+package game
+
+import "math/rand"
+
+const (
+	// DefaultShinyOdds is the amount of monsters out of which one is
+	// generated shiny, unless configured otherwise.
+	DefaultShinyOdds = 8192
+
+	// GenderlessRate is the GenderRate of monsters that have no gender.
+	GenderlessRate = -1
+
+	// MaxGenderRate is the GenderRate of monsters that are always female.
+	// Any GenderRate in between is the chance, out of MaxGenderRate, of
+	// a monster being female.
+	MaxGenderRate = 8
+)
+
+// MonsterGenerator generates new monsters, rolling their gender, whether
+// they are shiny, their Nature and their individual values. Every monster
+// that comes into the world, whether wild, gifted, hatched or spawned by
+// staff, is generated through it, so monsters are consistent everywhere.
+type MonsterGenerator struct {
+	moves     *MoveConfig
+	shinyOdds int
+	random    *rand.Rand
+}
+
+// NewMonsterGenerator constructs a new instance of a MonsterGenerator
+// that generates one out of every shinyOdds monsters shiny. Defaults to
+// DefaultShinyOdds if shinyOdds is not positive.
+func NewMonsterGenerator(moves *MoveConfig, shinyOdds int, random *rand.Rand) *MonsterGenerator {
+	if shinyOdds <= 0 {
+		shinyOdds = DefaultShinyOdds
+	}
+
+	return &MonsterGenerator{moves: moves, shinyOdds: shinyOdds, random: random}
+}
+
+// Generate generates a new monster of the given descriptor at the
+// specified level, at full health and knowing the latest moves it would
+// have learnt by levelling up to the specified level.
+func (generator *MonsterGenerator) Generate(descriptor *MonsterDescriptor, level int) MonsterData {
+	data := NewMonsterData(descriptor, generator.moves, level)
+
+	data.Gender = RollGender(generator.random, descriptor.GenderRate)
+	if generator.random.Intn(generator.shinyOdds) == 0 {
+		data.Coloration = ShinyColour
+	}
+
+	data.Nature = Nature(generator.random.Intn(NatureCount))
+	for stat := range data.IndividualValues {
+		data.IndividualValues[stat] = generator.random.Intn(MaxIndividualValue + 1)
+	}
+
+	// the monster is at full health with its individual values applied
+	data.HitPoints = data.Stats(descriptor)[HitPoints]
+
+	return data
+}
+
+// RollGender rolls the Gender of a monster of the given GenderRate.
+func RollGender(random *rand.Rand, genderRate int) Gender {
+	switch {
+	case genderRate <= GenderlessRate:
+		return Genderless
+	case genderRate >= MaxGenderRate:
+		return Woman
+	case random.Intn(MaxGenderRate) < genderRate:
+		return Woman
+	default:
+		return Man
+	}
+}
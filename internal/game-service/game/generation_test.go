@@ -0,0 +1,56 @@
+package game
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestRollGender(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 100; i++ {
+		if gender := RollGender(random, GenderlessRate); gender != Genderless {
+			t.Fatalf("expected a genderless monster but was %v", gender)
+		}
+
+		if gender := RollGender(random, 0); gender != Man {
+			t.Fatalf("expected a male monster but was %v", gender)
+		}
+
+		if gender := RollGender(random, MaxGenderRate); gender != Woman {
+			t.Fatalf("expected a female monster but was %v", gender)
+		}
+	}
+
+	genders := make(map[Gender]int)
+	for i := 0; i < 1000; i++ {
+		genders[RollGender(random, MaxGenderRate/2)]++
+	}
+
+	if genders[Man] == 0 || genders[Woman] == 0 || genders[Genderless] != 0 {
+		t.Errorf("expected both males and females to be rolled but was %v", genders)
+	}
+}
+
+func TestMonsterGenerator_Generate(t *testing.T) {
+	descriptor := &MonsterDescriptor{ID: 1, Name: "NIDORAN-F", GenderRate: MaxGenderRate, BaseStats: [7]int{55, 47, 52, 40, 40, 41}}
+
+	// shiny odds of one guarantee a shiny monster
+	generator := NewMonsterGenerator(&MoveConfig{}, 1, rand.New(rand.NewSource(1)))
+	data := generator.Generate(descriptor, 10)
+
+	if data.ModelID != 1 || data.Level != 10 || data.Gender != Woman || data.Coloration != ShinyColour {
+		t.Errorf("expected a shiny female monster of level 10 but was %+v", data)
+	}
+
+	if data.HitPoints != data.Stats(descriptor)[HitPoints] {
+		t.Error("expected the monster to be at full health")
+	}
+
+	first := NewMonsterGenerator(&MoveConfig{}, 0, rand.New(rand.NewSource(7))).Generate(descriptor, 10)
+	second := NewMonsterGenerator(&MoveConfig{}, 0, rand.New(rand.NewSource(7))).Generate(descriptor, 10)
+
+	if first.Nature != second.Nature || first.IndividualValues != second.IndividualValues {
+		t.Error("expected monsters generated from the same seed to be identical")
+	}
+}
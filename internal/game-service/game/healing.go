@@ -0,0 +1,112 @@
+package game
+
+import "gitlab.com/pokesync/game-service/internal/game-service/game/transport"
+
+// Healer restores the monsters of players at healing points, such as the
+// counter of a nurse or a healing machine.
+type Healer struct {
+	monsters *MonsterConfig
+	moves    *MoveConfig
+
+	// spawn is where players that have yet to visit a healing point are
+	// sent back to.
+	spawn Position
+}
+
+// NewHealer constructs a new instance of a Healer that sends players that
+// have yet to visit a healing point back to the given spawn Position.
+func NewHealer(monsters *MonsterConfig, moves *MoveConfig, spawn Position) *Healer {
+	return &Healer{monsters: monsters, moves: moves, spawn: spawn}
+}
+
+// Heal fully restores the health of the monster, cures it of its
+// StatusCondition and restores the power points of every move it knows.
+func (data *MonsterData) Heal(descriptor *MonsterDescriptor, moves *MoveConfig) {
+	data.HitPoints = data.Stats(descriptor)[HitPoints]
+	data.StatusCondition = Healthy
+
+	for i, known := range data.Moves {
+		if move := moves.Get(known.MoveID); move != nil {
+			data.Moves[i].PP = move.PP
+		}
+	}
+}
+
+// Heal fully restores the Monster, keeping its HealthComponent in sync.
+func (mon *Monster) Heal(descriptor *MonsterDescriptor, moves *MoveConfig) {
+	mon.MonsterData.Heal(descriptor, moves)
+	mon.SyncHealth(descriptor)
+}
+
+// HealParty fully restores every monster in the Player's PartyBelt and
+// lets the Player know.
+func (healer *Healer) HealParty(plr *Player) {
+	belt := plr.PartyBelt()
+	for slot := 0; slot < belt.Size; slot++ {
+		monster, err := belt.Get(slot)
+		if err != nil || monster == nil {
+			continue
+		}
+
+		descriptor := healer.monsters.Get(monster.ModelID)
+		if descriptor == nil {
+			continue
+		}
+
+		monster.Heal(descriptor, healer.moves)
+		belt.Set(slot, monster)
+	}
+}
+
+// Visit heals the party of the Player at the healing point the Player is
+// standing at, which becomes the point the Player respawns at.
+func (healer *Healer) Visit(plr *Player) {
+	healer.HealParty(plr)
+	plr.SetRespawnPoint(plr.Position())
+}
+
+// Respawn sends the Player back to the healing point it last visited, or
+// to the spawn if it has yet to visit one, and heals its party there.
+func (healer *Healer) Respawn(plr *Player) {
+	point := healer.spawn
+	if visited := plr.RespawnPoint(); visited != nil {
+		point = *visited
+	}
+
+	plr.Teleport(point)
+	healer.HealParty(plr)
+}
+
+// SetRespawnPoint sets the position the Player respawns at.
+func (plr *Player) SetRespawnPoint(position Position) {
+	plr.GetComponent(RespawnTag).(*RespawnComponent).Point = &position
+}
+
+// RespawnPoint returns the position the Player respawns at. May return
+// nil if the Player has yet to visit a healing point.
+func (plr *Player) RespawnPoint() *Position {
+	return plr.GetComponent(RespawnTag).(*RespawnComponent).Point
+}
+
+// Teleport instantly moves the Player to the given Position, dropping any
+// steps it had yet to take.
+func (plr *Player) Teleport(position Position) {
+	queue := plr.GetComponent(TransformTag).(*TransformComponent).MovementQueue
+
+	before := queue.Position
+	queue.Position = position
+	queue.Clear()
+
+	if mapView, ok := plr.GetComponent(MapViewTag).(*MapViewComponent); ok {
+		if before.MapX != position.MapX || before.MapZ != position.MapZ {
+			mapView.MapView.Refresh(position.MapX, position.MapZ)
+		}
+	}
+
+	plr.QueueEvent(&transport.TeleportAvatar{
+		MapX:   uint16(position.MapX),
+		MapZ:   uint16(position.MapZ),
+		LocalX: uint16(position.LocalX),
+		LocalZ: uint16(position.LocalZ),
+	})
+}
@@ -0,0 +1,71 @@
+package game
+
+import "testing"
+
+func newHealingTestSetup() (*Healer, *Player) {
+	assets := &AssetBundle{
+		Monsters: &MonsterConfig{Descriptors: []MonsterDescriptor{testRattata}},
+		Moves:    &MoveConfig{Descriptors: []MoveDescriptor{{ID: 0, Name: "tackle", PP: 35}}},
+	}
+
+	fixture := newTestFixture(assets, 4)
+	plr := fixture.newPlayer("Sino", Position{LocalX: 4, LocalZ: 4})
+
+	data := NewMonsterData(assets.Monsters.Get(0), assets.Moves, 10)
+	data.HitPoints = 0
+	data.StatusCondition = Poisoned
+	data.Moves = []KnownMove{{MoveID: 0, PP: 3}}
+
+	fixture.addToParty(plr, data)
+
+	return NewHealer(assets.Monsters, assets.Moves, Position{MapZ: 2, LocalX: 60, LocalZ: 40}), plr
+}
+
+func TestHealer_Visit(t *testing.T) {
+	healer, plr := newHealingTestSetup()
+
+	healer.Visit(plr)
+
+	monster, _ := plr.PartyBelt().Get(0)
+	health := monster.GetComponent(HealthTag).(*HealthComponent)
+	if monster.HitPoints == 0 || health.Current != health.Max || health.Current != monster.HitPoints {
+		t.Errorf("expected the monster to be at full health but was %v/%v", health.Current, health.Max)
+	}
+
+	if monster.StatusCondition != Healthy {
+		t.Errorf("expected the monster to have been cured but was %v", monster.StatusCondition)
+	}
+
+	if monster.Moves[0].PP != 35 {
+		t.Errorf("expected the power points of the move to be restored but was %v", monster.Moves[0].PP)
+	}
+
+	if point := plr.RespawnPoint(); point == nil || *point != (Position{LocalX: 4, LocalZ: 4}) {
+		t.Errorf("expected the healing point to have become the respawn point but was %v", point)
+	}
+}
+
+func TestHealer_Respawn(t *testing.T) {
+	healer, plr := newHealingTestSetup()
+
+	plr.SetRespawnPoint(Position{MapX: 1, LocalX: 8, LocalZ: 2})
+	healer.Respawn(plr)
+
+	if position := plr.Position(); position != (Position{MapX: 1, LocalX: 8, LocalZ: 2}) {
+		t.Errorf("expected the player to be sent back to the respawn point but was at %v", position)
+	}
+
+	if monster, _ := plr.PartyBelt().Get(0); monster.HitPoints == 0 {
+		t.Error("expected the party to have been healed at the respawn point")
+	}
+}
+
+func TestHealer_RespawnWithoutVisitedHealingPoint(t *testing.T) {
+	healer, plr := newHealingTestSetup()
+
+	healer.Respawn(plr)
+
+	if position := plr.Position(); position != (Position{MapZ: 2, LocalX: 60, LocalZ: 40}) {
+		t.Errorf("expected the player to be sent back to the spawn but was at %v", position)
+	}
+}
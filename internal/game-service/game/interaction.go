@@ -1,15 +1,117 @@
 package game
 
-import "gitlab.com/pokesync/game-service/internal/game-service/game/entity"
+import (
+	"errors"
 
-func selectPlayerOption() selectPlayerOptionHandler {
-	return func(plr *Player, entityID entity.ID, slot int) error {
-		return nil
+	"gitlab.com/pokesync/game-service/internal/game-service/game/entity"
+)
+
+// InteractionDistance is the distance in tiles from which a Player can
+// interact with another entity.
+const InteractionDistance = 1
+
+// ErrOutOfReach is returned when a Player attempts to interact with an
+// entity that is not within its reach.
+var ErrOutOfReach = errors.New("entity is out of reach")
+
+// InteractionHandler handles a Player interacting with the given target
+// entity.
+type InteractionHandler func(plr *Player, target *entity.Entity) error
+
+// InteractionRegistry is a registry of InteractionHandler's for entities
+// of a specific EntityKind and ModelID.
+type InteractionRegistry struct {
+	handlers map[interactionKey]InteractionHandler
+}
+
+// interactionKey is the key of an InteractionHandler.
+type interactionKey struct {
+	kind    EntityKind
+	modelID ModelID
+}
+
+// NewInteractionRegistry constructs a new instance of an
+// InteractionRegistry.
+func NewInteractionRegistry() *InteractionRegistry {
+	return &InteractionRegistry{handlers: make(map[interactionKey]InteractionHandler)}
+}
+
+// Put inserts the given InteractionHandler into the registry for entities
+// of the given EntityKind and ModelID.
+func (registry *InteractionRegistry) Put(kind EntityKind, modelID ModelID, handler InteractionHandler) {
+	registry.handlers[interactionKey{kind: kind, modelID: modelID}] = handler
+}
+
+// Get looks up the InteractionHandler for entities of the given
+// EntityKind and ModelID.
+func (registry *InteractionRegistry) Get(kind EntityKind, modelID ModelID) (InteractionHandler, bool) {
+	handler, exists := registry.handlers[interactionKey{kind: kind, modelID: modelID}]
+	return handler, exists
+}
+
+// WithinReach returns whether the given Position is within interaction
+// distance of the Player. Entities on other maps are never within reach.
+func (plr *Player) WithinReach(position Position) bool {
+	own := plr.Position()
+	if own.MapX != position.MapX || own.MapZ != position.MapZ {
+		return false
 	}
+
+	return abs(own.LocalX-position.LocalX) <= InteractionDistance &&
+		abs(own.LocalZ-position.LocalZ) <= InteractionDistance
+}
+
+// positionOf returns the Position of the given entity.
+func positionOf(target *entity.Entity) Position {
+	return target.GetComponent(TransformTag).(*TransformComponent).MovementQueue.Position
+}
+
+// abs returns the absolute value of the given integer.
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+
+	return value
 }
 
-func interactWithEntity() interactWithEntityHandler {
+// interactWithEntity is an interactWithEntityHandler that looks up and
+// calls the InteractionHandler of the kind of entity the Player interacts
+// with, if it is within the Player's reach.
+func interactWithEntity(entities *EntityIndex, registry *InteractionRegistry) interactWithEntityHandler {
 	return func(plr *Player, entityID entity.ID) error {
-		return nil
+		if plr.InBattle() {
+			return ErrInBattle
+		}
+
+		if plr.InTrade() {
+			return ErrInTrade
+		}
+
+		target := entities.Get(entityID)
+		if target == nil {
+			return nil
+		}
+
+		kind, ok := target.GetComponent(KindTag).(*KindComponent)
+		if !ok {
+			return nil
+		}
+
+		model, ok := target.GetComponent(ModelIDTag).(*ModelIDComponent)
+		if !ok {
+			return nil
+		}
+
+		handle, exists := registry.Get(kind.Kind, model.ModelID)
+		if !exists {
+			return nil
+		}
+
+		if !plr.WithinReach(positionOf(target)) {
+			return ErrOutOfReach
+		}
+
+		return handle(plr, target)
 	}
 }
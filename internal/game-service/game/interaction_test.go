@@ -0,0 +1,45 @@
+package game
+
+import (
+	"testing"
+
+	"gitlab.com/pokesync/game-service/internal/game-service/character"
+	"gitlab.com/pokesync/game-service/internal/game-service/game/entity"
+)
+
+func TestInteractWithEntity(t *testing.T) {
+	world := entity.NewWorld(4)
+	factory := NewEntityFactory(world, &AssetBundle{})
+
+	plr := PlayerBy(factory.CreatePlayer(Position{LocalX: 5, LocalZ: 5}, Man, character.DisplayName("Sino"), character.Regular))
+	near := ObjectBy(factory.CreateObject(Position{LocalX: 6, LocalZ: 4}, 4))
+	far := ObjectBy(factory.CreateObject(Position{LocalX: 7, LocalZ: 5}, 4))
+
+	entities := NewEntityIndex()
+	for _, e := range []*entity.Entity{plr.Entity, near.Entity, far.Entity} {
+		world.AddEntity(e)
+		entities.Put(e)
+	}
+
+	var interactedWith *entity.Entity
+
+	registry := NewInteractionRegistry()
+	registry.Put(ObjectKind, 4, func(plr *Player, target *entity.Entity) error {
+		interactedWith = target
+		return nil
+	})
+
+	interact := interactWithEntity(entities, registry)
+	if err := interact(plr, near.ID); err != nil || interactedWith != near.Entity {
+		t.Errorf("expected to interact with the nearby object but was %v", err)
+	}
+
+	interactedWith = nil
+	if err := interact(plr, far.ID); err != ErrOutOfReach || interactedWith != nil {
+		t.Errorf("expected %v but was %v", ErrOutOfReach, err)
+	}
+
+	if err := interact(plr, plr.ID); err != nil {
+		t.Errorf("expected interacting with an entity without a handler to be ignored but was %v", err)
+	}
+}
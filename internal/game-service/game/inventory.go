@@ -0,0 +1,377 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"gitlab.com/pokesync/game-service/internal/game-service/game/transport"
+)
+
+// These are the pockets of an Inventory that items are sorted into.
+const (
+	ItemsPocket    Pocket = 0
+	MedicinePocket Pocket = 1
+	BallsPocket    Pocket = 2
+	KeyItemsPocket Pocket = 3
+)
+
+const (
+	// PocketCount is the amount of pockets of an Inventory.
+	PocketCount = 4
+
+	// PocketCapacity is the amount of different items a single pocket of
+	// an Inventory can hold.
+	PocketCapacity = 64
+
+	// DefaultStackLimit is the amount of a single item an Inventory can
+	// hold, for items that do not specify a limit of their own.
+	DefaultStackLimit = 999
+)
+
+var (
+	// ErrUnknownItem is returned when attempting to hold an item that
+	// does not exist.
+	ErrUnknownItem = errors.New("item does not exist")
+
+	// ErrInvalidQuantity is returned when attempting to add or remove a
+	// quantity of an item that is not positive.
+	ErrInvalidQuantity = errors.New("item quantity must be positive")
+
+	// ErrStackLimitExceeded is returned when adding more of an item than
+	// the Inventory can hold of it.
+	ErrStackLimitExceeded = errors.New("item stack limit exceeded")
+
+	// ErrPocketFull is returned when adding a new item to a pocket that
+	// has no room left.
+	ErrPocketFull = errors.New("inventory pocket is full")
+
+	// ErrNotEnoughItems is returned when removing more of an item than
+	// the Inventory holds.
+	ErrNotEnoughItems = errors.New("not enough of the item")
+
+	// ErrCannotUseItem is returned when attempting to use an item that
+	// has no effect outside of a battle.
+	ErrCannotUseItem = errors.New("item cannot be used")
+)
+
+// pocketsByName maps the names of Pocket's in asset files.
+var pocketsByName = map[string]Pocket{
+	"ITEMS":     ItemsPocket,
+	"MEDICINE":  MedicinePocket,
+	"BALLS":     BallsPocket,
+	"KEY_ITEMS": KeyItemsPocket,
+}
+
+// Pocket is a pocket of an Inventory that holds items of a single kind.
+type Pocket int
+
+// InventoryUpdateListener listens for changes made to the Inventory.
+type InventoryUpdateListener interface {
+	Updated(pocket Pocket, slot int, stack *ItemStack)
+}
+
+// InventorySessionListener is an InventoryUpdateListener that listens for
+// changes made to the Inventory to visually apply these changes to the
+// Client as well.
+type InventorySessionListener struct {
+	session *Session
+}
+
+// ItemStack is a quantity of a single item held in an Inventory.
+type ItemStack struct {
+	ItemID   int
+	Quantity int
+}
+
+// Inventory is a player's bag of items. Every item is held in a single
+// stack, in the pocket of its kind.
+type Inventory struct {
+	items   *ItemConfig
+	pockets [PocketCount][]ItemStack
+
+	listeners []InventoryUpdateListener
+}
+
+// UnmarshalJSON unmarshals a Pocket from its name.
+func (pocket *Pocket) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return err
+	}
+
+	value, ok := pocketsByName[name]
+	if !ok {
+		return fmt.Errorf("unknown pocket %q", name)
+	}
+
+	*pocket = value
+	return nil
+}
+
+// NewInventory constructs a new instance of an Inventory that holds items
+// of the given ItemConfig.
+func NewInventory(items *ItemConfig) *Inventory {
+	return &Inventory{items: items}
+}
+
+// Add adds the specified quantity of the item of the given id. Either the
+// whole quantity is added or, if it does not fit, none of it is.
+func (inventory *Inventory) Add(itemID int, quantity int) error {
+	descriptor, err := inventory.describe(itemID)
+	if err != nil {
+		return err
+	}
+
+	if quantity <= 0 {
+		return ErrInvalidQuantity
+	}
+
+	stacks := inventory.pockets[descriptor.Pocket]
+
+	slot := inventory.slotOf(descriptor.Pocket, itemID)
+	if slot < 0 {
+		if len(stacks) >= PocketCapacity {
+			return ErrPocketFull
+		}
+
+		if quantity > descriptor.Limit() {
+			return ErrStackLimitExceeded
+		}
+
+		inventory.pockets[descriptor.Pocket] = append(stacks, ItemStack{ItemID: itemID, Quantity: quantity})
+		inventory.notifySlotUpdated(descriptor.Pocket, len(stacks))
+
+		return nil
+	}
+
+	if stacks[slot].Quantity+quantity > descriptor.Limit() {
+		return ErrStackLimitExceeded
+	}
+
+	stacks[slot].Quantity += quantity
+	inventory.notifySlotUpdated(descriptor.Pocket, slot)
+
+	return nil
+}
+
+// Remove removes the specified quantity of the item of the given id. The
+// items after an emptied stack each shift down a slot.
+func (inventory *Inventory) Remove(itemID int, quantity int) error {
+	descriptor, err := inventory.describe(itemID)
+	if err != nil {
+		return err
+	}
+
+	if quantity <= 0 {
+		return ErrInvalidQuantity
+	}
+
+	pocket := descriptor.Pocket
+
+	slot := inventory.slotOf(pocket, itemID)
+	if slot < 0 || inventory.pockets[pocket][slot].Quantity < quantity {
+		return ErrNotEnoughItems
+	}
+
+	stacks := inventory.pockets[pocket]
+	stacks[slot].Quantity -= quantity
+	if stacks[slot].Quantity > 0 {
+		inventory.notifySlotUpdated(pocket, slot)
+		return nil
+	}
+
+	inventory.pockets[pocket] = append(stacks[:slot], stacks[slot+1:]...)
+	for shifted := slot; shifted <= len(inventory.pockets[pocket]); shifted++ {
+		inventory.notifySlotUpdated(pocket, shifted)
+	}
+
+	return nil
+}
+
+// Move moves the stack in the first slot of the given pocket to the
+// second slot, swapping places with the stack in it.
+func (inventory *Inventory) Move(pocket Pocket, slotFrom, slotTo int) error {
+	if err := inventory.checkBoundaries(pocket, slotFrom); err != nil {
+		return err
+	}
+
+	if err := inventory.checkBoundaries(pocket, slotTo); err != nil {
+		return err
+	}
+
+	stacks := inventory.pockets[pocket]
+	stacks[slotFrom], stacks[slotTo] = stacks[slotTo], stacks[slotFrom]
+
+	inventory.notifySlotUpdated(pocket, slotFrom)
+	inventory.notifySlotUpdated(pocket, slotTo)
+
+	return nil
+}
+
+// Count returns the quantity of the item of the given id the Inventory
+// holds.
+func (inventory *Inventory) Count(itemID int) int {
+	descriptor, err := inventory.describe(itemID)
+	if err != nil {
+		return 0
+	}
+
+	slot := inventory.slotOf(descriptor.Pocket, itemID)
+	if slot < 0 {
+		return 0
+	}
+
+	return inventory.pockets[descriptor.Pocket][slot].Quantity
+}
+
+// Get looks up the ItemStack in the specified slot of the given pocket.
+// May return nil if the slot is empty.
+func (inventory *Inventory) Get(pocket Pocket, slot int) *ItemStack {
+	if inventory.checkBoundaries(pocket, slot) != nil {
+		return nil
+	}
+
+	return &inventory.pockets[pocket][slot]
+}
+
+// Size returns the amount of different items held in the given pocket.
+func (inventory *Inventory) Size(pocket Pocket) int {
+	if pocket < 0 || pocket >= PocketCount {
+		return 0
+	}
+
+	return len(inventory.pockets[pocket])
+}
+
+// ForEach calls the given function for every ItemStack, pocket by pocket
+// in the order of their slots.
+func (inventory *Inventory) ForEach(f func(pocket Pocket, slot int, stack ItemStack)) {
+	for pocket, stacks := range inventory.pockets {
+		for slot, stack := range stacks {
+			f(Pocket(pocket), slot, stack)
+		}
+	}
+}
+
+// describe looks up the ItemDescriptor of the item of the given id.
+// Returns ErrUnknownItem if there is no such item.
+func (inventory *Inventory) describe(itemID int) (*ItemDescriptor, error) {
+	if inventory.items == nil {
+		return nil, ErrUnknownItem
+	}
+
+	descriptor := inventory.items.Get(itemID)
+	if descriptor == nil || descriptor.Pocket < 0 || descriptor.Pocket >= PocketCount {
+		return nil, ErrUnknownItem
+	}
+
+	return descriptor, nil
+}
+
+// slotOf looks up the slot of the given pocket that holds the item of the
+// given id. Returns -1 if the item is not held.
+func (inventory *Inventory) slotOf(pocket Pocket, itemID int) int {
+	for slot, stack := range inventory.pockets[pocket] {
+		if stack.ItemID == itemID {
+			return slot
+		}
+	}
+
+	return -1
+}
+
+// checkBoundaries checks if the given pocket and slot hold an ItemStack.
+// If not, it returns an error.
+func (inventory *Inventory) checkBoundaries(pocket Pocket, slot int) error {
+	if pocket < 0 || pocket >= PocketCount {
+		return errors.New("given pocket is out of bounds")
+	}
+
+	if slot < 0 || slot >= len(inventory.pockets[pocket]) {
+		return errors.New("given slot is out of bounds of the pocket")
+	}
+
+	return nil
+}
+
+// notifySlotUpdated notifies every subscribed listener of the Inventory's
+// updated slot.
+func (inventory *Inventory) notifySlotUpdated(pocket Pocket, slot int) {
+	stack := inventory.Get(pocket, slot)
+	for _, listener := range inventory.listeners {
+		listener.Updated(pocket, slot, stack)
+	}
+}
+
+// AddListener subscribes the given listener to receive notifications.
+func (inventory *Inventory) AddListener(listener InventoryUpdateListener) {
+	inventory.listeners = append(inventory.listeners, listener)
+}
+
+// RemoveListener unsubscribes the given listener from receiving notifications.
+func (inventory *Inventory) RemoveListener(listener InventoryUpdateListener) {
+	for i, l := range inventory.listeners {
+		if l == listener {
+			inventory.listeners = append(inventory.listeners[:i], inventory.listeners[i+1:]...)
+			break
+		}
+	}
+}
+
+// Updated sends a visual update to the Session. An empty slot is sent as
+// an item id of 65535.
+func (listener *InventorySessionListener) Updated(pocket Pocket, slot int, stack *ItemStack) {
+	message := &transport.SetInventorySlot{Pocket: byte(pocket), Slot: byte(slot), ItemID: 65535}
+	if stack != nil {
+		message.ItemID = uint16(stack.ItemID)
+		message.Quantity = uint16(stack.Quantity)
+	}
+
+	listener.session.QueueEvent(message)
+}
+
+// Limit returns the amount of the described item an Inventory can hold.
+func (descriptor *ItemDescriptor) Limit() int {
+	if descriptor.StackLimit <= 0 {
+		return DefaultStackLimit
+	}
+
+	return descriptor.StackLimit
+}
+
+// UseItem uses a single one of the specified item from the Player's
+// Inventory. Only repels can currently be used outside of a battle.
+func (plr *Player) UseItem(itemID int) error {
+	if plr.InBattle() {
+		return ErrInBattle
+	}
+
+	descriptor, err := plr.Inventory().describe(itemID)
+	if err != nil {
+		return err
+	}
+
+	if descriptor.RepelSteps <= 0 {
+		return ErrCannotUseItem
+	}
+
+	if err := plr.Inventory().Remove(itemID, 1); err != nil {
+		return err
+	}
+
+	plr.AddEncounterModifier(NewRepel(descriptor.RepelSteps))
+	return nil
+}
+
+func useItem() useItemHandler {
+	return func(plr *Player, itemID int) error {
+		return plr.UseItem(itemID)
+	}
+}
+
+func moveInventoryItem() moveInventoryItemHandler {
+	return func(plr *Player, pocket, slotFrom, slotTo int) error {
+		return plr.Inventory().Move(Pocket(pocket), slotFrom, slotTo)
+	}
+}
@@ -0,0 +1,116 @@
+package game
+
+import "testing"
+
+type inventoryRecorder struct {
+	updates []ItemStack
+}
+
+func (recorder *inventoryRecorder) Updated(pocket Pocket, slot int, stack *ItemStack) {
+	if stack == nil {
+		recorder.updates = append(recorder.updates, ItemStack{})
+		return
+	}
+
+	recorder.updates = append(recorder.updates, *stack)
+}
+
+func newTestInventory() *Inventory {
+	return NewInventory(&ItemConfig{Descriptors: []ItemDescriptor{
+		{ID: 0, Name: "poke-ball", Pocket: BallsPocket},
+		{ID: 1, Name: "great-ball", Pocket: BallsPocket},
+		{ID: 2, Name: "fire-stone", Pocket: ItemsPocket, StackLimit: 3},
+		{ID: 3, Name: "ultra-ball", Pocket: BallsPocket},
+	}})
+}
+
+func TestInventory_Add(t *testing.T) {
+	inventory := newTestInventory()
+
+	inventory.Add(0, 5)
+	inventory.Add(0, 10)
+	inventory.Add(2, 1)
+
+	if inventory.Count(0) != 15 || inventory.Size(BallsPocket) != 1 {
+		t.Errorf("expected the poke balls to be stacked but were %+v", inventory.pockets[BallsPocket])
+	}
+
+	if stack := inventory.Get(ItemsPocket, 0); stack == nil || stack.ItemID != 2 {
+		t.Errorf("expected the fire stone to be held in the items pocket but was %v", stack)
+	}
+
+	if err := inventory.Add(2, 3); err != ErrStackLimitExceeded {
+		t.Errorf("expected %v but was %v", ErrStackLimitExceeded, err)
+	}
+
+	if inventory.Count(2) != 1 {
+		t.Errorf("expected a failed addition to not add anything but held %v", inventory.Count(2))
+	}
+
+	if err := inventory.Add(0, DefaultStackLimit); err != ErrStackLimitExceeded {
+		t.Errorf("expected %v but was %v", ErrStackLimitExceeded, err)
+	}
+
+	if err := inventory.Add(42, 1); err != ErrUnknownItem {
+		t.Errorf("expected %v but was %v", ErrUnknownItem, err)
+	}
+
+	if err := inventory.Add(0, 0); err != ErrInvalidQuantity {
+		t.Errorf("expected %v but was %v", ErrInvalidQuantity, err)
+	}
+}
+
+func TestInventory_RemoveShiftsSlots(t *testing.T) {
+	inventory := newTestInventory()
+	inventory.Add(0, 2)
+	inventory.Add(1, 2)
+	inventory.Add(3, 2)
+
+	if err := inventory.Remove(0, 3); err != ErrNotEnoughItems {
+		t.Errorf("expected %v but was %v", ErrNotEnoughItems, err)
+	}
+
+	recorder := &inventoryRecorder{}
+	inventory.AddListener(recorder)
+
+	if err := inventory.Remove(0, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	if inventory.Size(BallsPocket) != 2 || inventory.Get(BallsPocket, 0).ItemID != 1 || inventory.Get(BallsPocket, 1).ItemID != 3 {
+		t.Errorf("expected the remaining balls to have shifted down but were %+v", inventory.pockets[BallsPocket])
+	}
+
+	expected := []ItemStack{{ItemID: 1, Quantity: 2}, {ItemID: 3, Quantity: 2}, {}}
+	if len(recorder.updates) != len(expected) {
+		t.Fatalf("expected updates %+v but were %+v", expected, recorder.updates)
+	}
+
+	for i, update := range recorder.updates {
+		if update != expected[i] {
+			t.Errorf("expected update %+v but was %+v", expected[i], update)
+		}
+	}
+}
+
+func TestInventory_Move(t *testing.T) {
+	inventory := newTestInventory()
+	inventory.Add(0, 1)
+	inventory.Add(1, 1)
+
+	if err := inventory.Move(BallsPocket, 0, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	if inventory.Get(BallsPocket, 0).ItemID != 1 || inventory.Get(BallsPocket, 1).ItemID != 0 {
+		t.Errorf("expected the balls to have swapped places but were %+v", inventory.pockets[BallsPocket])
+	}
+
+	if err := inventory.Move(BallsPocket, 0, 2); err == nil {
+		t.Error("expected moving to an empty slot to fail")
+	}
+
+	if err := inventory.Move(PocketCount, 0, 1); err == nil {
+		t.Error("expected moving within a non-existent pocket to fail")
+	}
+}
@@ -0,0 +1,236 @@
+package game
+
+import (
+	"time"
+
+	"gitlab.com/pokesync/game-service/internal/game-service/account"
+	"gitlab.com/pokesync/game-service/internal/game-service/chat"
+	"gitlab.com/pokesync/game-service/internal/game-service/client"
+	"gitlab.com/pokesync/game-service/internal/game-service/game/transport"
+	"gitlab.com/pokesync/game-service/internal/game-service/token"
+)
+
+// LingerRegistry keeps track of Session's that have been detached from their
+// Client but whose Player is kept in the game world for a grace period, in
+// anticipation of the user reconnecting.
+type LingerRegistry struct {
+	sessions map[account.Email]*lingeringSession
+}
+
+// lingeringSession is a detached Session that lingers until it expires.
+type lingeringSession struct {
+	session   *Session
+	expiresAt time.Time
+}
+
+// NewLingerRegistry constructs a new instance of a LingerRegistry.
+func NewLingerRegistry() *LingerRegistry {
+	return &LingerRegistry{sessions: make(map[account.Email]*lingeringSession)}
+}
+
+// Put puts the given Session into the registry, to linger until the
+// specified point in time.
+func (registry *LingerRegistry) Put(session *Session, expiresAt time.Time) {
+	registry.sessions[session.Email] = &lingeringSession{session: session, expiresAt: expiresAt}
+}
+
+// Remove removes any lingering Session that belongs to the account of the
+// specified Email, from this registry. May return nil.
+func (registry *LingerRegistry) Remove(email account.Email) *Session {
+	lingering, exists := registry.sessions[email]
+	if !exists {
+		return nil
+	}
+
+	delete(registry.sessions, email)
+	return lingering.session
+}
+
+// RemoveExpired removes and returns every Session that has lingered past
+// its expiration, relative to the given point in time.
+func (registry *LingerRegistry) RemoveExpired(now time.Time) []*Session {
+	var expired []*Session
+	for email, lingering := range registry.sessions {
+		if now.Before(lingering.expiresAt) {
+			continue
+		}
+
+		delete(registry.sessions, email)
+		expired = append(expired, lingering.session)
+	}
+
+	return expired
+}
+
+// RemoveAll removes and returns every lingering Session.
+func (registry *LingerRegistry) RemoveAll() []*Session {
+	sessions := make([]*Session, 0, len(registry.sessions))
+	for email, lingering := range registry.sessions {
+		sessions = append(sessions, lingering.session)
+		delete(registry.sessions, email)
+	}
+
+	return sessions
+}
+
+// Size returns the amount of Session's that are currently lingering.
+func (registry *LingerRegistry) Size() int {
+	return len(registry.sessions)
+}
+
+// onTerminated reacts to the Client of the specified ID having its
+// connection terminated. If a linger duration is configured, the Client's
+// Session is detached and its Player is kept in the game world, awaiting
+// the user to reconnect. Otherwise the user is logged out right away.
+func (service *Service) onTerminated(id client.ID) {
+	// a user that never made it into the game world has no further use
+	// for the session token it was issued upon authentication
+	if entry := service.lobby.Remove(id); entry != nil {
+		go service.revokeToken(entry.Token)
+	}
+
+	if login := service.loginQueue.Remove(id); login != nil {
+		go service.revokeToken(login.Token)
+	}
+
+	session := service.sessions.Remove(id)
+	if session == nil {
+		return
+	}
+
+	// neither a battle nor a trade can wait for a user to reconnect
+	session.Player.ForfeitBattle()
+	session.Player.DeclineTrade()
+
+	if service.config.LingerDuration <= 0 {
+		service.logout(session)
+		return
+	}
+
+	session.Detach()
+	service.lingering.Put(session, time.Now().Add(service.config.LingerDuration))
+}
+
+// onReconnected reacts to the given Client user logging back in whilst its
+// previous Session is still lingering or still attached to another Client,
+// attaching the Client to the previous Session and resynchronizing the Client with the state of its Player. The
+// session token of the previous connection is replaced by the given one.
+func (service *Service) onReconnected(cl *client.Client, session *Session, sessionToken token.Token) {
+	go service.revokeToken(session.Token)
+
+	session.Attach(cl)
+	session.Token = sessionToken
+
+	service.sessions.Put(cl.ID, session)
+
+	service.resync(session)
+
+	service.routing.Publish(chat.ServiceConnectTopic, client.Mail{
+		Client: cl,
+		Payload: chat.ConnectToChatService{
+			DisplayName: session.Player.DisplayName(),
+			UserGroup:   session.Player.Rank(),
+		},
+	})
+}
+
+// takeOverSession takes the Session of the account of the specified Email
+// away from the Client it is still attached to, hanging up on that Client,
+// or from the LingerRegistry, for the Session to be attached to the Client
+// that has just logged in to the account. May return nil.
+func (service *Service) takeOverSession(email account.Email) *Session {
+	session := service.sessions.GetByEmail(email)
+	if session == nil {
+		return service.lingering.Remove(email)
+	}
+
+	previous := session.Client()
+	service.sessions.Remove(previous.ID)
+
+	session.Player.ForfeitBattle()
+	session.Player.DeclineTrade()
+
+	session.Detach()
+	previous.Terminate()
+
+	return session
+}
+
+// hangUpPendingLogin hangs up on any Client of the account of the specified
+// Email that has yet to enter the game world, as an account is only ever
+// logged in from one Client at a time.
+func (service *Service) hangUpPendingLogin(email account.Email) {
+	if entry := service.lobby.RemoveByEmail(email); entry != nil {
+		go service.revokeToken(entry.Token)
+		entry.Client.Terminate()
+	}
+
+	if login := service.loginQueue.RemoveByEmail(email); login != nil {
+		go service.revokeToken(login.Token)
+		login.Client.Terminate()
+	}
+}
+
+// expireLingeringSessions logs out every user whose Session has been
+// lingering for longer than the configured linger duration.
+func (service *Service) expireLingeringSessions() {
+	for _, session := range service.lingering.RemoveExpired(time.Now()) {
+		service.logout(session)
+	}
+}
+
+// logout removes the Player of the given Session from the game world,
+// saves its character profile and revokes its session token.
+func (service *Service) logout(session *Session) {
+	service.game.RemovePlayer(session.Player)
+	service.characterSaver(session.Email, service.transformPlayerToCharacterProfile(session.Player))
+
+	go service.revokeToken(session.Token)
+}
+
+// revokeToken revokes the given session token, for it to no longer be
+// usable to resume a session with.
+func (service *Service) revokeToken(sessionToken token.Token) {
+	if err := service.config.TokenIssuer.Revoke(sessionToken); err != nil {
+		service.logger.Error(err)
+	}
+}
+
+// resync sends the full state of the given Session's Player to its Client,
+// for the Client to pick up right where it left off.
+func (service *Service) resync(session *Session) {
+	plr := session.Player
+	position := plr.Position()
+
+	session.client.SendNow(&transport.LoginSuccess{
+		PID:         uint16(plr.ID),
+		DisplayName: string(plr.DisplayName()),
+
+		Gender:    byte(plr.Gender()),
+		UserGroup: byte(plr.Rank()),
+
+		MapX:   uint16(position.MapX),
+		MapZ:   uint16(position.MapZ),
+		LocalX: uint16(position.LocalX),
+		LocalZ: uint16(position.LocalZ),
+
+		SessionToken: string(session.Token),
+	})
+
+	plr.GetComponent(MapViewTag).(*MapViewComponent).MapView.Refresh(position.MapX, position.MapZ)
+
+	coinBag := plr.CoinBag()
+	coinBag.notifyPokeDollarsUpdated(coinBag.Dollars)
+	coinBag.notifyDonatorPointsUpdated(coinBag.DonatorPoints)
+
+	partyBelt := plr.PartyBelt()
+	for slot := 0; slot < PartyBeltCapacity; slot++ {
+		monster, _ := partyBelt.Get(slot)
+		partyBelt.notifySlotUpdated(slot, monster)
+	}
+
+	inventory := plr.Inventory()
+	inventory.ForEach(func(pocket Pocket, slot int, stack ItemStack) {
+		inventory.notifySlotUpdated(pocket, slot)
+	})
+}
@@ -0,0 +1,103 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"gitlab.com/pokesync/game-service/internal/game-service/account"
+	"gitlab.com/pokesync/game-service/internal/game-service/client"
+	"gitlab.com/pokesync/game-service/internal/game-service/token"
+	"go.uber.org/zap"
+)
+
+func TestLingerRegistry_Remove(t *testing.T) {
+	registry := NewLingerRegistry()
+	registry.Put(&Session{Email: account.Email("sino@gmail.com")}, time.Now().Add(time.Minute))
+
+	if registry.Remove(account.Email("someone@gmail.com")) != nil {
+		t.Error("expected no session to linger for the given e-mail")
+	}
+
+	if registry.Remove(account.Email("sino@gmail.com")) == nil {
+		t.Error("expected a session to linger for the given e-mail")
+	}
+
+	if registry.Size() != 0 {
+		t.Error("expected registry to be empty")
+	}
+}
+
+func TestLingerRegistry_RemoveExpired(t *testing.T) {
+	now := time.Now()
+
+	registry := NewLingerRegistry()
+	registry.Put(&Session{Email: account.Email("sino@gmail.com")}, now.Add(-time.Second))
+	registry.Put(&Session{Email: account.Email("someone@gmail.com")}, now.Add(time.Minute))
+
+	expired := registry.RemoveExpired(now)
+	if len(expired) != 1 || expired[0].Email != account.Email("sino@gmail.com") {
+		t.Error("expected only the session of sino@gmail.com to have expired")
+	}
+
+	if registry.Size() != 1 {
+		t.Errorf("expected %v session to still linger but was %v instead", 1, registry.Size())
+	}
+}
+
+func newLoginTestService() *Service {
+	return &Service{
+		config:  Config{TokenIssuer: token.NewIssuer(token.Config{Secret: []byte("secret"), ExpireAfter: time.Minute}, token.NewInMemoryStore())},
+		logger:  zap.NewNop().Sugar(),
+		routing: client.NewRouter(client.RouterConfig{PublicationTimeout: time.Second}),
+
+		sessions:   NewSessionRegistry(),
+		lingering:  NewLingerRegistry(),
+		lobby:      NewLobby(),
+		loginQueue: NewLoginQueue(1),
+	}
+}
+
+func TestService_LoginWhilstLoggedIn(t *testing.T) {
+	email := account.Email("sino@gmail.com")
+
+	service := newLoginTestService()
+
+	fixture := newTestFixture(nil, 4)
+	plr := fixture.newPlayer("Sino", Position{})
+
+	first := client.NewClient(nil, client.Config{CommandLimit: 16})
+	session := NewInstalledSession(first, SessionConfig{CommandLimit: 16, EventLimit: 16}, email, plr)
+	plr.Add(&SessionComponent{session: session})
+
+	service.sessions.Put(first.ID, session)
+
+	second := client.NewClient(nil, client.Config{CommandLimit: 16})
+	service.handleMail(client.Mail{Client: second, Payload: CharactersLoaded{Account: account.Account{Email: email}}})
+
+	if service.sessions.Get(first.ID) != nil || service.sessions.Get(second.ID) != session {
+		t.Error("expected the session to have been taken over by the second client")
+	}
+
+	if session.Client() != second || service.sessions.GetByEmail(email) != session {
+		t.Error("expected the session to be attached to the second client")
+	}
+
+	if service.lobby.Get(second.ID) != nil {
+		t.Error("expected no second player to be created for the same character")
+	}
+}
+
+func TestService_LoginWhilstInLobby(t *testing.T) {
+	email := account.Email("sino@gmail.com")
+	service := newLoginTestService()
+
+	first := client.NewClient(nil, client.Config{CommandLimit: 16})
+	service.handleMail(client.Mail{Client: first, Payload: CharactersLoaded{Account: account.Account{Email: email}}})
+
+	second := client.NewClient(nil, client.Config{CommandLimit: 16})
+	service.handleMail(client.Mail{Client: second, Payload: CharactersLoaded{Account: account.Account{Email: email}}})
+
+	if service.lobby.Get(first.ID) != nil || service.lobby.Get(second.ID) == nil {
+		t.Error("expected the first client to have been hung up on in favour of the second")
+	}
+}
@@ -0,0 +1,243 @@
+package game
+
+import (
+	"context"
+	"time"
+
+	"gitlab.com/pokesync/game-service/internal/game-service/account"
+	"gitlab.com/pokesync/game-service/internal/game-service/character"
+	"gitlab.com/pokesync/game-service/internal/game-service/client"
+	"gitlab.com/pokesync/game-service/internal/game-service/game/transport"
+	"gitlab.com/pokesync/game-service/internal/game-service/token"
+)
+
+const (
+	DisplayNameInvalid       CreationRejection = 0
+	DisplayNameTaken         CreationRejection = 1
+	CharacterLimitReached    CreationRejection = 2
+	GenderInvalid            CreationRejection = 3
+	CreationFailed           CreationRejection = 4
+	DisplayNameTooShort      CreationRejection = 5
+	DisplayNameTooLong       CreationRejection = 6
+	DisplayNameIllegalChars  CreationRejection = 7
+	DisplayNameReserved      CreationRejection = 8
+	DisplayNameImpersonation CreationRejection = 9
+	DisplayNameDenied        CreationRejection = 10
+)
+
+// nameRejections maps the statuses of invalid display names to the
+// CreationRejection that is reported back to the client.
+var nameRejections = map[character.NameStatus]CreationRejection{
+	character.NameTooShort:      DisplayNameTooShort,
+	character.NameTooLong:       DisplayNameTooLong,
+	character.IllegalCharacters: DisplayNameIllegalChars,
+	character.MalformedSpacing:  DisplayNameInvalid,
+	character.ReservedName:      DisplayNameReserved,
+	character.ImpersonatesStaff: DisplayNameImpersonation,
+	character.DeniedName:        DisplayNameDenied,
+}
+
+// CreationRejection is the reason as to why a request to create a new
+// character was rejected.
+type CreationRejection int
+
+// Lobby keeps track of authenticated users that have yet to select
+// the character they want to enter the game world with.
+type Lobby struct {
+	entries map[client.ID]*LobbyEntry
+}
+
+// LobbyEntry is an authenticated user waiting in the Lobby.
+type LobbyEntry struct {
+	Client     *client.Client
+	Account    account.Account
+	Characters []*character.Profile
+	Token      token.Token
+
+	creating bool
+}
+
+// CharacterCreator attempts to register a new character Profile.
+type CharacterCreator func(email account.Email, profile *character.Profile) <-chan character.CreateResult
+
+// NewLobby constructs a new instance of a Lobby.
+func NewLobby() *Lobby {
+	return &Lobby{entries: make(map[client.ID]*LobbyEntry)}
+}
+
+// Put puts the given LobbyEntry into the Lobby under the specified client ID.
+func (lobby *Lobby) Put(id client.ID, entry *LobbyEntry) {
+	lobby.entries[id] = entry
+}
+
+// Remove removes any LobbyEntry that is associated with the specified
+// client ID, from this Lobby.
+func (lobby *Lobby) Remove(id client.ID) *LobbyEntry {
+	entry, exists := lobby.entries[id]
+	if !exists {
+		return nil
+	}
+
+	delete(lobby.entries, id)
+	return entry
+}
+
+// RemoveByEmail removes any LobbyEntry of the account of the specified
+// Email, from this Lobby.
+func (lobby *Lobby) RemoveByEmail(email account.Email) *LobbyEntry {
+	for id, entry := range lobby.entries {
+		if entry.Account.Email == email {
+			delete(lobby.entries, id)
+			return entry
+		}
+	}
+
+	return nil
+}
+
+// RemoveAll removes and returns every LobbyEntry from this Lobby.
+func (lobby *Lobby) RemoveAll() []*LobbyEntry {
+	entries := make([]*LobbyEntry, 0, len(lobby.entries))
+	for id, entry := range lobby.entries {
+		entries = append(entries, entry)
+		delete(lobby.entries, id)
+	}
+
+	return entries
+}
+
+// Get looks up a LobbyEntry by the given client ID. May return nil.
+func (lobby *Lobby) Get(id client.ID) *LobbyEntry {
+	return lobby.entries[id]
+}
+
+// onCharactersLoaded reacts to the given Client user having the profiles
+// of all of its characters loaded, placing the user in the Lobby along with
+// the session token that was issued for it.
+func (service *Service) onCharactersLoaded(cl *client.Client, account account.Account, characters []*character.Profile, sessionToken token.Token) {
+	entry := &LobbyEntry{Client: cl, Account: account, Characters: characters, Token: sessionToken}
+	service.lobby.Put(cl.ID, entry)
+
+	cl.SendNow(createCharacterList(entry.Characters))
+}
+
+// onCreateCharacter reacts to the given Client user requesting a new
+// character to be created for its account.
+func (service *Service) onCreateCharacter(ctx context.Context, cl *client.Client, request *transport.CreateCharacter) {
+	entry := service.lobby.Get(cl.ID)
+	if entry == nil || entry.creating {
+		return
+	}
+
+	displayName := character.DisplayName(request.DisplayName)
+	if status := service.config.NameRules.Validate(displayName); status != character.ValidName {
+		cl.SendNow(&transport.RejectCharacterCreation{Reason: byte(nameRejections[status])})
+		return
+	}
+
+	gender := Gender(request.Gender)
+	if gender != Man && gender != Woman {
+		cl.SendNow(&transport.RejectCharacterCreation{Reason: byte(GenderInvalid)})
+		return
+	}
+
+	if len(entry.Characters) >= service.config.CharacterLimit {
+		cl.SendNow(&transport.RejectCharacterCreation{Reason: byte(CharacterLimitReached)})
+		return
+	}
+
+	position := service.config.StartPosition
+	profile := &character.Profile{
+		DisplayName: displayName,
+		UserGroup:   character.Regular,
+
+		Gender: int(gender),
+
+		MapX:   position.MapX,
+		MapZ:   position.MapZ,
+		LocalX: position.LocalX,
+		LocalZ: position.LocalZ,
+	}
+
+	entry.creating = true
+	go service.createCharacter(ctx, cl, entry.Account, profile)
+}
+
+// createCharacter attempts to register the given character Profile for the
+// specified Account, notifying the game Service of the character's creation.
+func (service *Service) createCharacter(ctx context.Context, cl *client.Client, account account.Account, profile *character.Profile) {
+	select {
+	case <-ctx.Done():
+		return
+
+	case result := <-service.characterCreator(account.Email, profile):
+		if result.Error == character.ErrDisplayNameTaken {
+			cl.SendNow(&transport.RejectCharacterCreation{Reason: byte(DisplayNameTaken)})
+		} else if result.Error != nil {
+			service.logger.Error(result.Error)
+
+			cl.SendNow(&transport.RejectCharacterCreation{Reason: byte(CreationFailed)})
+		}
+
+		characterCreatedEvent := CharacterCreated{Account: account, Character: result.Profile}
+		mail := client.Mail{Context: ctx, Client: cl, Payload: characterCreatedEvent}
+
+		service.post(mail)
+
+	case <-time.After(service.config.CharacterFetchTimeout):
+		cl.SendNow(&transport.RequestTimedOut{})
+		cl.Terminate()
+	}
+}
+
+// onCharacterCreated reacts to the given Client user's attempt at creating
+// a new character having been completed. A nil character Profile indicates
+// that the attempt was rejected.
+func (service *Service) onCharacterCreated(cl *client.Client, profile *character.Profile) {
+	entry := service.lobby.Get(cl.ID)
+	if entry == nil {
+		return
+	}
+
+	entry.creating = false
+	if profile == nil {
+		return
+	}
+
+	entry.Characters = append(entry.Characters, profile)
+	cl.SendNow(createCharacterList(entry.Characters))
+}
+
+// onSelectCharacter reacts to the given Client user selecting one of its
+// characters to enter the game world with.
+func (service *Service) onSelectCharacter(cl *client.Client, request *transport.SelectCharacter) {
+	entry := service.lobby.Get(cl.ID)
+	if entry == nil || entry.creating {
+		return
+	}
+
+	index := int(request.Index)
+	if index >= len(entry.Characters) {
+		return
+	}
+
+	service.lobby.Remove(cl.ID)
+	service.onCharacterLoaded(cl, entry.Account, entry.Characters[index], entry.Token)
+}
+
+// createCharacterList creates a CharacterList message out of the given
+// character Profile's.
+func createCharacterList(characters []*character.Profile) *transport.CharacterList {
+	list := &transport.CharacterList{}
+	for _, profile := range characters {
+		list.Characters = append(list.Characters, transport.CharacterSummary{
+			DisplayName: string(profile.DisplayName),
+			Gender:      byte(profile.Gender),
+			UserGroup:   byte(profile.UserGroup),
+			MapX:        uint16(profile.MapX),
+			MapZ:        uint16(profile.MapZ),
+		})
+	}
+
+	return list
+}
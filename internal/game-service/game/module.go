@@ -2,12 +2,21 @@ package game
 
 import (
 	"gitlab.com/pokesync/game-service/internal/game-service/character"
+	"gitlab.com/pokesync/game-service/internal/game-service/game/entity"
 	"go.uber.org/zap"
 )
 
 // CommandCallback is a subscribable callback to register for a chat command.
 type CommandCallback func(dk *DependencyKit, plr *Player, arguments []string) error
 
+// InteractionCallback is a subscribable callback to register for a Player
+// interacting with an entity of a specific kind.
+type InteractionCallback func(dk *DependencyKit, plr *Player, target *entity.Entity) error
+
+// WildEncounterCallback is a subscribable callback to register for a Player
+// encountering a wild Monster.
+type WildEncounterCallback func(dk *DependencyKit, plr *Player, wild *Monster) error
+
 // DependencyKit holds a bundle of dependencies a Module may require
 // throughout installation.
 type DependencyKit struct {
@@ -20,6 +29,11 @@ type DependencyKit struct {
 // into the game service core.
 type Module func(dk *DependencyKit)
 
+// Items returns the ItemConfig of every item there is.
+func (dk *DependencyKit) Items() *ItemConfig {
+	return dk.assets.Items
+}
+
 // CreatePlayer creates a new Player-like Entity with the specified details.
 func (dk *DependencyKit) CreatePlayer(position Position, gender Gender, displayName character.DisplayName, userGroup character.UserGroup) *Player {
 	return dk.game.CreatePlayer(position, gender, displayName, userGroup)
@@ -30,6 +44,70 @@ func (dk *DependencyKit) CreateMonster(position Position, data MonsterData) *Mon
 	return dk.game.CreateMonster(position, data)
 }
 
+// MonsterDescriptor looks up the MonsterDescriptor of the specified
+// ModelID. Returns nil if there is no such monster.
+func (dk *DependencyKit) MonsterDescriptor(modelID ModelID) *MonsterDescriptor {
+	return dk.assets.Monsters.Get(modelID)
+}
+
+// Monsters returns the MonsterConfig of every monster there is.
+func (dk *DependencyKit) Monsters() *MonsterConfig {
+	return dk.assets.Monsters
+}
+
+// Moves returns the MoveConfig of every move there is.
+func (dk *DependencyKit) Moves() *MoveConfig {
+	return dk.assets.Moves
+}
+
+// Evolutions returns the Evolutions that evolves the monsters of players.
+func (dk *DependencyKit) Evolutions() *Evolutions {
+	return dk.game.evolutions
+}
+
+// TypeChart returns the TypeChart of every type there is.
+func (dk *DependencyKit) TypeChart() *TypeChart {
+	return dk.assets.Types
+}
+
+// GiveMonster gives the given monster to the Player, adding it to the
+// Player's PartyBelt or depositing it into its PCStorage if the belt is
+// full.
+func (dk *DependencyKit) GiveMonster(plr *Player, data MonsterData) error {
+	return dk.game.GiveMonster(plr, data)
+}
+
+// GenerateMonster generates a new monster of the given descriptor at the
+// specified level, with a rolled gender, coloration, Nature and
+// individual values.
+func (dk *DependencyKit) GenerateMonster(descriptor *MonsterDescriptor, level int) MonsterData {
+	return dk.game.generator.Generate(descriptor, level)
+}
+
+// VisitHealingPoint heals the party of the Player at the healing point it
+// is standing at, which becomes the point the Player respawns at.
+func (dk *DependencyKit) VisitHealingPoint(plr *Player) {
+	dk.game.healer.Visit(plr)
+}
+
+// Respawn sends the Player back to the healing point it last visited and
+// heals its party there.
+func (dk *DependencyKit) Respawn(plr *Player) {
+	dk.game.healer.Respawn(plr)
+}
+
+// PlayerByName looks up the Player in the game world that goes by the
+// specified DisplayName. May return nil.
+func (dk *DependencyKit) PlayerByName(displayName character.DisplayName) *Player {
+	return dk.game.PlayerByName(displayName)
+}
+
+// Ban bans the user of the given Player, disabling its account, revoking
+// its session tokens and logging it out right away.
+func (dk *DependencyKit) Ban(plr *Player) {
+	dk.game.banPlayer(plr)
+}
+
 // CreateNpc creates a new Monster-like Entity with the specified details.
 func (dk *DependencyKit) CreateNpc(id ModelID, position Position) *Npc {
 	return dk.game.CreateNpc(id, position)
@@ -45,6 +123,21 @@ func (dk *DependencyKit) AddNpc(npc *Npc) bool {
 	return dk.game.AddNpc(npc)
 }
 
+// CreateObject creates a new Object-like Entity with the specified details.
+func (dk *DependencyKit) CreateObject(id ModelID, position Position) *Object {
+	return dk.game.CreateObject(id, position)
+}
+
+// AddObject attempts to add the given Object into the game world.
+func (dk *DependencyKit) AddObject(obj *Object) bool {
+	return dk.game.AddObject(obj)
+}
+
+// RemoveObject removes the given Object from the game world.
+func (dk *DependencyKit) RemoveObject(obj *Object) {
+	dk.game.RemoveObject(obj)
+}
+
 // AddMonster attempts to add the given Monster into the game world.
 func (dk *DependencyKit) AddMonster(mon *Monster) bool {
 	return dk.game.AddMonster(mon)
@@ -71,3 +164,27 @@ func (dk *DependencyKit) OnCommand(trigger string, cb CommandCallback) {
 		return cb(dk, plr, arguments)
 	})
 }
+
+// OnObjectInteraction subscribes the given callback to players
+// interacting with objects of the specified ModelID.
+func (dk *DependencyKit) OnObjectInteraction(id ModelID, cb InteractionCallback) {
+	dk.game.interactions.Put(ObjectKind, id, func(plr *Player, target *entity.Entity) error {
+		return cb(dk, plr, target)
+	})
+}
+
+// OnNpcInteraction subscribes the given callback to players interacting
+// with npc's of the specified ModelID.
+func (dk *DependencyKit) OnNpcInteraction(id ModelID, cb InteractionCallback) {
+	dk.game.interactions.Put(NpcKind, id, func(plr *Player, target *entity.Entity) error {
+		return cb(dk, plr, target)
+	})
+}
+
+// OnWildEncounter subscribes the given callback to players encountering
+// wild monsters.
+func (dk *DependencyKit) OnWildEncounter(cb WildEncounterCallback) {
+	dk.game.encounters.OnEncounter(func(plr *Player, wild *Monster) error {
+		return cb(dk, plr, wild)
+	})
+}
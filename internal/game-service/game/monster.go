@@ -1,6 +1,9 @@
 package game
 
-import "gitlab.com/pokesync/game-service/internal/game-service/game/entity"
+import (
+	"gitlab.com/pokesync/game-service/internal/game-service/character"
+	"gitlab.com/pokesync/game-service/internal/game-service/game/entity"
+)
 
 const (
 	RegularColour MonsterColoration = 0
@@ -30,6 +33,21 @@ type MonsterData struct {
 	Gender          Gender
 	StatusCondition StatusCondition
 	Coloration      MonsterColoration
+
+	Nickname        string
+	OriginalTrainer character.DisplayName
+
+	Level      int
+	Experience int
+
+	IndividualValues Stats
+	EffortValues     Stats
+	Nature           Nature
+
+	HitPoints int
+	Happiness int
+
+	Moves []KnownMove
 }
 
 // Monster is a type of Entity.
@@ -43,6 +61,134 @@ func MonsterBy(entity *entity.Entity, data MonsterData) *Monster {
 	return &Monster{entity, &data}
 }
 
+// NewMonsterData constructs MonsterData of the described monster at the
+// specified level, at full health and with neutral individual values,
+// effort values and Nature. It knows the latest moves it would have
+// learnt by levelling up to the specified level.
+func NewMonsterData(descriptor *MonsterDescriptor, moves *MoveConfig, level int) MonsterData {
+	data := MonsterData{
+		ModelID:         ModelID(descriptor.ID),
+		StatusCondition: Healthy,
+		Coloration:      RegularColour,
+
+		Level:      level,
+		Experience: descriptor.GrowthRate.ExperienceAt(level),
+		Nature:     Hardy,
+		Happiness:  descriptor.BaseHappiness,
+	}
+
+	data.HitPoints = data.Stats(descriptor)[HitPoints]
+	data.learnInitialMoves(descriptor, moves)
+
+	return data
+}
+
+// Stats calculates the current Stats of the monster, derived from the
+// base stats of its MonsterDescriptor.
+func (data *MonsterData) Stats(descriptor *MonsterDescriptor) Stats {
+	return CalculateStats(descriptor.Stats(), data.Level, data.IndividualValues, data.EffortValues, data.Nature)
+}
+
+// GainExperience adds the given amount of experience to the monster,
+// levelling it up according to the GrowthRate of its MonsterDescriptor.
+// The hit points the monster gains in maximum health by levelling up are
+// also added to its current health, and it learns the moves of the levels
+// it reached for as long as it has room for them.
+func (data *MonsterData) GainExperience(descriptor *MonsterDescriptor, moves *MoveConfig, amount int) LevelUp {
+	growthRate := descriptor.GrowthRate
+
+	data.Experience += amount
+	if maxExperience := growthRate.ExperienceAt(MaxLevel); data.Experience > maxExperience {
+		data.Experience = maxExperience
+	}
+
+	level := growthRate.LevelAt(data.Experience)
+	if level <= data.Level {
+		return LevelUp{}
+	}
+
+	maxHitPointsBefore := data.Stats(descriptor)[HitPoints]
+
+	levelUp := LevelUp{LevelsGained: level - data.Level}
+	levelUp.Learnt, levelUp.Pending = data.learnLevelUpMoves(descriptor, moves, data.Level, level)
+
+	data.Level = level
+
+	if data.HitPoints > 0 {
+		data.HitPoints += data.Stats(descriptor)[HitPoints] - maxHitPointsBefore
+	}
+
+	return levelUp
+}
+
+// GainEffortValues adds the given effort values to those of the monster,
+// for as long as neither the limit per Stat nor the total limit is reached.
+func (data *MonsterData) GainEffortValues(values Stats) {
+	for stat, value := range values {
+		remaining := MaxTotalEffortValue - data.EffortValues.Total()
+		if value > remaining {
+			value = remaining
+		}
+
+		if data.EffortValues[stat]+value > MaxEffortValue {
+			value = MaxEffortValue - data.EffortValues[stat]
+		}
+
+		if value > 0 {
+			data.EffortValues[stat] += value
+		}
+	}
+}
+
+// ChangeHappiness adds the given, possibly negative, amount to the
+// happiness of the monster, bound between zero and MaxHappiness.
+func (data *MonsterData) ChangeHappiness(amount int) {
+	data.Happiness += amount
+	if data.Happiness < 0 {
+		data.Happiness = 0
+	} else if data.Happiness > MaxHappiness {
+		data.Happiness = MaxHappiness
+	}
+}
+
+// SetHitPoints sets the current health of the Monster, keeping its
+// HealthComponent in sync with its MonsterData.
+func (mon *Monster) SetHitPoints(hitPoints int) {
+	health := mon.GetComponent(HealthTag).(*HealthComponent)
+	if hitPoints < 0 {
+		hitPoints = 0
+	} else if hitPoints > health.Max {
+		hitPoints = health.Max
+	}
+
+	mon.HitPoints = hitPoints
+	health.Current = hitPoints
+}
+
+// SyncHealth brings the HealthComponent of the Monster back in sync with
+// its MonsterData, after the MonsterData was changed directly, such as by
+// a battle.
+func (mon *Monster) SyncHealth(descriptor *MonsterDescriptor) {
+	health := mon.GetComponent(HealthTag).(*HealthComponent)
+
+	health.Max = mon.Stats(descriptor)[HitPoints]
+	health.Current = mon.HitPoints
+}
+
+// GainExperience adds the given amount of experience to the Monster,
+// keeping its HealthComponent in sync with the levels it gains.
+func (mon *Monster) GainExperience(descriptor *MonsterDescriptor, moves *MoveConfig, amount int) LevelUp {
+	levelUp := mon.MonsterData.GainExperience(descriptor, moves, amount)
+	if levelUp.LevelsGained > 0 {
+		health := mon.GetComponent(HealthTag).(*HealthComponent)
+
+		health.Max = mon.Stats(descriptor)[HitPoints]
+		health.Current = mon.HitPoints
+	}
+
+	return levelUp
+}
+
 // Face updates the Monster's sprite to face the specified direction.
 func (mon *Monster) Face(direction Direction) {
 	transform := mon.GetComponent(TransformTag).(*TransformComponent)
@@ -0,0 +1,256 @@
+package game
+
+import (
+	"errors"
+
+	"gitlab.com/pokesync/game-service/internal/game-service/game/transport"
+)
+
+const (
+	Physical MoveCategory = "PHYSICAL"
+	Special  MoveCategory = "SPECIAL"
+	Status   MoveCategory = "STATUS"
+)
+
+const (
+	NoEffect       MoveEffect = 0
+	LowerAttack    MoveEffect = 1
+	LowerDefence   MoveEffect = 2
+	LowerSpeed     MoveEffect = 3
+	LowerAccuracy  MoveEffect = 4
+	RaiseAttack    MoveEffect = 5
+	RaiseDefence   MoveEffect = 6
+	BurnEffect     MoveEffect = 7
+	PoisonEffect   MoveEffect = 8
+	ParalyzeEffect MoveEffect = 9
+	SleepEffect    MoveEffect = 10
+	FlinchEffect   MoveEffect = 11
+	RecoilEffect   MoveEffect = 12
+)
+
+// MaxKnownMoves is the maximum amount of moves a monster can know at once.
+const MaxKnownMoves = 4
+
+// DefaultMove is the move a monster knows when it would not know any move
+// by levelling up, such as for species without a learnset.
+const DefaultMove MoveID = 2
+
+var (
+	// ErrNoPendingMove is returned when a Player answers to a move that
+	// was never offered for its monster to learn.
+	ErrNoPendingMove = errors.New("monster has no pending move")
+
+	// ErrCannotForgetMove is returned when a Player attempts to have its
+	// monster forget a move it can not forget for the offered move.
+	ErrCannotForgetMove = errors.New("monster can not forget the move")
+)
+
+// MoveID is the id of a move.
+type MoveID int
+
+// MoveCategory determines which of the Stat's of a monster a move
+// deals damage with, if any.
+type MoveCategory string
+
+// MoveEffect is the id of a secondary effect a move may have.
+type MoveEffect int
+
+// KnownMove is a move that is known by a monster, along with the
+// amount of power points it has left to use the move with.
+type KnownMove struct {
+	MoveID MoveID
+	PP     int
+}
+
+// PendingMove is a move a monster could not learn by levelling up for a
+// lack of room, which its Player has yet to either teach it in place of
+// one of its known moves or give up on.
+type PendingMove struct {
+	Monster *Monster
+	Move    MoveID
+}
+
+// LevelUp describes what happened to a monster as it gained experience.
+type LevelUp struct {
+	// LevelsGained is the amount of levels the monster gained.
+	LevelsGained int
+
+	// Learnt are the moves the monster learnt by levelling up.
+	Learnt []MoveID
+
+	// Pending are the moves the monster could have learnt by levelling
+	// up but it already knows MaxKnownMoves moves. One of its known moves
+	// has to be forgotten for it to learn any of these.
+	Pending []MoveID
+}
+
+// KnowsMove returns whether the monster knows the specified move.
+func (data *MonsterData) KnowsMove(id MoveID) bool {
+	for _, move := range data.Moves {
+		if move.MoveID == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+// LearnMove teaches the monster the described move, with full power
+// points. Returns false if the monster already knows the move or
+// already knows MaxKnownMoves moves.
+func (data *MonsterData) LearnMove(move *MoveDescriptor) bool {
+	if data.KnowsMove(move.ID) || len(data.Moves) >= MaxKnownMoves {
+		return false
+	}
+
+	data.Moves = append(data.Moves, KnownMove{MoveID: move.ID, PP: move.PP})
+	return true
+}
+
+// ReplaceMove has the monster forget the move in the specified slot to
+// learn the described move instead, with full power points. Returns false
+// if the slot is out of bounds or if the monster already knows the move.
+func (data *MonsterData) ReplaceMove(slot int, move *MoveDescriptor) bool {
+	if slot < 0 || slot >= len(data.Moves) || data.KnowsMove(move.ID) {
+		return false
+	}
+
+	data.Moves[slot] = KnownMove{MoveID: move.ID, PP: move.PP}
+	return true
+}
+
+// learnLevelUpMoves teaches the monster the moves it learns upon reaching
+// any of the levels after the first level up to and including the last
+// level, for as long as it has room for them. Returns the moves that were
+// learnt and the moves that could not be learnt for a lack of room.
+func (data *MonsterData) learnLevelUpMoves(descriptor *MonsterDescriptor, moves *MoveConfig, first, last int) (learnt []MoveID, pending []MoveID) {
+	for _, levelUpMove := range descriptor.LevelUpMoves {
+		if levelUpMove.Level <= first || levelUpMove.Level > last {
+			continue
+		}
+
+		move := moves.Get(levelUpMove.Move)
+		if move == nil || data.KnowsMove(move.ID) {
+			continue
+		}
+
+		if data.LearnMove(move) {
+			learnt = append(learnt, move.ID)
+		} else {
+			pending = append(pending, move.ID)
+		}
+	}
+
+	return learnt, pending
+}
+
+// learnInitialMoves teaches the monster the latest moves it would have
+// learnt by levelling up to its current level, or the DefaultMove if
+// there are no such moves.
+func (data *MonsterData) learnInitialMoves(descriptor *MonsterDescriptor, moves *MoveConfig) {
+	var known []MoveID
+	for _, levelUpMove := range descriptor.LevelUpMoves {
+		if levelUpMove.Level > data.Level || moves.Get(levelUpMove.Move) == nil {
+			continue
+		}
+
+		known = withoutMove(known, levelUpMove.Move)
+		known = append(known, levelUpMove.Move)
+	}
+
+	if len(known) > MaxKnownMoves {
+		known = known[len(known)-MaxKnownMoves:]
+	}
+
+	if len(known) == 0 && moves.Get(DefaultMove) != nil {
+		known = append(known, DefaultMove)
+	}
+
+	data.Moves = nil
+	for _, id := range known {
+		data.LearnMove(moves.Get(id))
+	}
+}
+
+// withoutMove returns the given moves without the specified move.
+func withoutMove(moves []MoveID, id MoveID) []MoveID {
+	for i, move := range moves {
+		if move == id {
+			return append(moves[:i], moves[i+1:]...)
+		}
+	}
+
+	return moves
+}
+
+// OfferMove offers the Player to teach the monster in the specified slot
+// of its PartyBelt the specified move, in place of one of its known moves.
+// The Player may either have the monster forget a move or give up on the
+// offered move. Returns whether the move was offered.
+func (plr *Player) OfferMove(slot int, move MoveID) bool {
+	monster, err := plr.PartyBelt().Get(slot)
+	if err != nil || monster == nil {
+		return false
+	}
+
+	if plr.pendingMoveOf(monster, move) < 0 {
+		component := plr.GetComponent(LearnMoveTag).(*LearnMoveComponent)
+		component.Pending = append(component.Pending, PendingMove{Monster: monster, Move: move})
+	}
+
+	plr.QueueEvent(&transport.OfferMove{PartySlot: byte(slot), MoveID: uint16(move)})
+	return true
+}
+
+// pendingMoveOf looks up the index of the specified PendingMove of the
+// given Monster. Returns -1 if the Player has no such PendingMove.
+func (plr *Player) pendingMoveOf(monster *Monster, move MoveID) int {
+	component := plr.GetComponent(LearnMoveTag).(*LearnMoveComponent)
+	for i, pending := range component.Pending {
+		if pending.Monster == monster && pending.Move == move {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func answerMove(moves *MoveConfig) answerMoveHandler {
+	return func(plr *Player, partySlot int, move MoveID, learn bool, forgetSlot int) error {
+		if plr.InBattle() {
+			return ErrInBattle
+		}
+
+		if plr.InTrade() {
+			return ErrInTrade
+		}
+
+		monster, err := plr.PartyBelt().Get(partySlot)
+		if err != nil {
+			return err
+		}
+
+		index := plr.pendingMoveOf(monster, move)
+		if index < 0 {
+			return ErrNoPendingMove
+		}
+
+		// the offer stands until the Player answers it with a move the
+		// monster can forget, or gives up on it
+		if learn {
+			descriptor := moves.Get(move)
+			if descriptor == nil || !monster.ReplaceMove(forgetSlot, descriptor) {
+				return ErrCannotForgetMove
+			}
+
+			if _, err := plr.PartyBelt().Set(partySlot, monster); err != nil {
+				return err
+			}
+		}
+
+		component := plr.GetComponent(LearnMoveTag).(*LearnMoveComponent)
+		component.Pending = append(component.Pending[:index], component.Pending[index+1:]...)
+
+		return nil
+	}
+}
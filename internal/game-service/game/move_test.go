@@ -0,0 +1,150 @@
+package game
+
+import "testing"
+
+func newTestMoves() *MoveConfig {
+	return &MoveConfig{Descriptors: []MoveDescriptor{
+		{ID: 0, Name: "TACKLE", PP: 35},
+		{ID: 1, Name: "GROWL", PP: 40},
+		{ID: 2, Name: "VINE_WHIP", PP: 25},
+		{ID: 3, Name: "POISON_POWDER", PP: 35},
+		{ID: 4, Name: "SLEEP_POWDER", PP: 15},
+		{ID: 5, Name: "RAZOR_LEAF", PP: 25},
+	}}
+}
+
+func newTestDescriptor() *MonsterDescriptor {
+	return &MonsterDescriptor{
+		ID:        1,
+		BaseStats: [7]int{45, 49, 49, 65, 65, 45},
+		LevelUpMoves: []LevelUpMove{
+			{Level: 1, Move: 0},
+			{Level: 3, Move: 1},
+			{Level: 7, Move: 2},
+			{Level: 13, Move: 3},
+			{Level: 13, Move: 4},
+			{Level: 19, Move: 5},
+		},
+	}
+}
+
+func TestNewMonsterData_LearnsInitialMoves(t *testing.T) {
+	monster := NewMonsterData(newTestDescriptor(), newTestMoves(), 19)
+
+	expected := []MoveID{2, 3, 4, 5}
+	if len(monster.Moves) != len(expected) {
+		t.Fatalf("expected %v moves but was %v instead", len(expected), len(monster.Moves))
+	}
+
+	for i, move := range monster.Moves {
+		if move.MoveID != expected[i] {
+			t.Errorf("expected move %v in slot %v but was %v instead", expected[i], i, move.MoveID)
+		}
+	}
+
+	if monster.Moves[0].PP != 25 {
+		t.Errorf("expected %v pp but was %v instead", 25, monster.Moves[0].PP)
+	}
+}
+
+func TestMonsterData_LearnsMovesOnLevelUp(t *testing.T) {
+	descriptor := newTestDescriptor()
+	moves := newTestMoves()
+
+	monster := NewMonsterData(descriptor, moves, 5)
+	if len(monster.Moves) != 2 {
+		t.Fatalf("expected %v moves but was %v instead", 2, len(monster.Moves))
+	}
+
+	levelUp := monster.GainExperience(descriptor, moves, MediumFast.ExperienceAt(13)-monster.Experience)
+	if len(levelUp.Learnt) != 2 || levelUp.Learnt[0] != 2 || levelUp.Learnt[1] != 3 {
+		t.Errorf("expected moves %v to be learnt but was %v instead", []MoveID{2, 3}, levelUp.Learnt)
+	}
+
+	if len(levelUp.Pending) != 1 || levelUp.Pending[0] != 4 {
+		t.Errorf("expected moves %v to be pending but was %v instead", []MoveID{4}, levelUp.Pending)
+	}
+
+	if !monster.ReplaceMove(0, moves.Get(4)) || monster.KnowsMove(0) {
+		t.Error("expected the first move to be replaced")
+	}
+}
+
+func TestLoadMoveConfigsAt(t *testing.T) {
+	moves, err := LoadMoveConfigsAt("../../../assets/config/move")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	monsters, err := LoadMonsterConfigsAt("../../../assets/config/monster")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := validateLearnsets(monsters, moves); err != nil {
+		t.Error(err)
+	}
+
+	for i, move := range moves.Descriptors {
+		if int(move.ID) != i {
+			t.Errorf("expected move %v to be stored under id %v but was %v instead", move.Name, i, move.ID)
+		}
+	}
+
+	for i := range monsters.Descriptors {
+		descriptor := &monsters.Descriptors[i]
+		if data := NewMonsterData(descriptor, moves, MinLevel); len(data.Moves) == 0 {
+			t.Errorf("expected %v to know a move but it knows none", descriptor.Name)
+		}
+	}
+}
+
+func TestNewMonsterData_LearnsDefaultMove(t *testing.T) {
+	moves := &MoveConfig{Descriptors: []MoveDescriptor{{ID: 0, Name: "POUND"}, {ID: 1, Name: "SCRATCH"}, {ID: DefaultMove, Name: "TACKLE", PP: 35}}}
+	monster := NewMonsterData(&MonsterDescriptor{BaseStats: [7]int{30, 56, 35, 25, 35, 72}}, moves, 5)
+
+	if len(monster.Moves) != 1 || monster.Moves[0] != (KnownMove{MoveID: DefaultMove, PP: 35}) {
+		t.Errorf("expected a monster without a learnset to know the default move but knew %+v", monster.Moves)
+	}
+}
+
+func TestPlayer_AnswersOfferedMove(t *testing.T) {
+	moves := newTestMoves()
+
+	descriptor := newTestDescriptor()
+	descriptor.ID = 0
+
+	fixture := newTestFixture(&AssetBundle{Monsters: &MonsterConfig{Descriptors: []MonsterDescriptor{*descriptor}}, Moves: moves}, 2)
+	plr := fixture.newPlayer("Sino", Position{})
+	fixture.fillParty(plr, 13, 0)
+
+	answer := answerMove(moves)
+	if err := answer(plr, 0, 5, true, 0); err != ErrNoPendingMove {
+		t.Errorf("expected %v but was %v", ErrNoPendingMove, err)
+	}
+
+	plr.OfferMove(0, 5)
+	plr.OfferMove(0, 5)
+
+	if err := answer(plr, 0, 5, true, MaxKnownMoves); err != ErrCannotForgetMove {
+		t.Errorf("expected %v but was %v", ErrCannotForgetMove, err)
+	}
+
+	if err := answer(plr, 0, 5, true, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	monster, _ := plr.PartyBelt().Get(0)
+	if monster.Moves[1] != (KnownMove{MoveID: 5, PP: 25}) {
+		t.Errorf("expected the offered move to have replaced the forgotten move but knew %+v", monster.Moves)
+	}
+
+	if err := answer(plr, 0, 5, true, 2); err != ErrNoPendingMove {
+		t.Errorf("expected a repeated offer to be answered only once but was %v", err)
+	}
+
+	plr.OfferMove(0, 0)
+	if err := answer(plr, 0, 0, false, 0); err != nil || monster.KnowsMove(0) {
+		t.Errorf("expected the offered move to have been given up on but was %v", err)
+	}
+}
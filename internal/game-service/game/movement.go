@@ -57,18 +57,21 @@ type MovementQueue struct {
 type WalkingProcessor struct {
 	grid        *Grid
 	routeFinder RouteFinder
+	encounters  *Encounters
 }
 
 // RunningProcessor processes running steps.
 type RunningProcessor struct {
 	grid        *Grid
 	routeFinder RouteFinder
+	encounters  *Encounters
 }
 
 // CyclingProcessor processes cycling steps.
 type CyclingProcessor struct {
 	grid        *Grid
 	routeFinder RouteFinder
+	encounters  *Encounters
 }
 
 // NewMovementQueue constructs a new instance of a MovementQueue.
@@ -81,45 +84,48 @@ func NewMovementQueue(position Position) *MovementQueue {
 }
 
 // NewWalkingProcessor TODO
-func NewWalkingProcessor(grid *Grid, routeFinder RouteFinder) *WalkingProcessor {
+func NewWalkingProcessor(grid *Grid, routeFinder RouteFinder, encounters *Encounters) *WalkingProcessor {
 	return &WalkingProcessor{
 		grid:        grid,
 		routeFinder: routeFinder,
+		encounters:  encounters,
 	}
 }
 
 // NewRunningProcessor TODO
-func NewRunningProcessor(grid *Grid, routeFinder RouteFinder) *RunningProcessor {
+func NewRunningProcessor(grid *Grid, routeFinder RouteFinder, encounters *Encounters) *RunningProcessor {
 	return &RunningProcessor{
 		grid:        grid,
 		routeFinder: routeFinder,
+		encounters:  encounters,
 	}
 }
 
 // NewCyclingProcessor TODO
-func NewCyclingProcessor(grid *Grid, routeFinder RouteFinder) *CyclingProcessor {
+func NewCyclingProcessor(grid *Grid, routeFinder RouteFinder, encounters *Encounters) *CyclingProcessor {
 	return &CyclingProcessor{
 		grid:        grid,
 		routeFinder: routeFinder,
+		encounters:  encounters,
 	}
 }
 
 // NewWalkingSystem constructs a System that processes walking
 // steps for entities.
-func NewWalkingSystem(grid *Grid, routeFinder RouteFinder) *entity.System {
-	return entity.NewSystem(entity.NewIntervalPolicy(walkingVelocity), NewWalkingProcessor(grid, routeFinder))
+func NewWalkingSystem(grid *Grid, routeFinder RouteFinder, encounters *Encounters) *entity.System {
+	return entity.NewSystem(entity.NewIntervalPolicy(walkingVelocity), NewWalkingProcessor(grid, routeFinder, encounters))
 }
 
 // NewRunningSystem constructs a System that processes running
 // steps for entities.
-func NewRunningSystem(grid *Grid, routeFinder RouteFinder) *entity.System {
-	return entity.NewSystem(entity.NewIntervalPolicy(runningVelocity), NewRunningProcessor(grid, routeFinder))
+func NewRunningSystem(grid *Grid, routeFinder RouteFinder, encounters *Encounters) *entity.System {
+	return entity.NewSystem(entity.NewIntervalPolicy(runningVelocity), NewRunningProcessor(grid, routeFinder, encounters))
 }
 
 // NewCyclingSystem constructs a System that processes cycling
 // steps for entities.
-func NewCyclingSystem(grid *Grid, routeFinder RouteFinder) *entity.System {
-	return entity.NewSystem(entity.NewIntervalPolicy(cyclingVelocity), NewCyclingProcessor(grid, routeFinder))
+func NewCyclingSystem(grid *Grid, routeFinder RouteFinder, encounters *Encounters) *entity.System {
+	return entity.NewSystem(entity.NewIntervalPolicy(cyclingVelocity), NewCyclingProcessor(grid, routeFinder, encounters))
 }
 
 // AddedToWorld is called when the System of this Processor is added
@@ -144,7 +150,7 @@ func (processor *WalkingProcessor) Update(world *entity.World, deltaTime time.Du
 			continue
 		}
 
-		if err := takeMovementSimulationStep(ent, transform.MovementQueue, processor.routeFinder, processor.grid); err != nil {
+		if err := takeMovementSimulationStep(ent, transform.MovementQueue, processor.routeFinder, processor.grid, processor.encounters); err != nil {
 			return err
 		}
 	}
@@ -174,7 +180,7 @@ func (processor *RunningProcessor) Update(world *entity.World, deltaTime time.Du
 			continue
 		}
 
-		if err := takeMovementSimulationStep(ent, transform.MovementQueue, processor.routeFinder, processor.grid); err != nil {
+		if err := takeMovementSimulationStep(ent, transform.MovementQueue, processor.routeFinder, processor.grid, processor.encounters); err != nil {
 			return err
 		}
 	}
@@ -204,7 +210,7 @@ func (processor *CyclingProcessor) Update(world *entity.World, deltaTime time.Du
 			continue
 		}
 
-		if err := takeMovementSimulationStep(ent, transform.MovementQueue, processor.routeFinder, processor.grid); err != nil {
+		if err := takeMovementSimulationStep(ent, transform.MovementQueue, processor.routeFinder, processor.grid, processor.encounters); err != nil {
 			return err
 		}
 	}
@@ -232,7 +238,13 @@ func (processor *CyclingProcessor) Components() entity.ComponentTag {
 
 // takeMovementSimulationStep takes a single movement-based step within
 // the simulation of the game, for the given Entity on the given map Grid.
-func takeMovementSimulationStep(ent *entity.Entity, movementQueue *MovementQueue, routeFinder RouteFinder, grid *Grid) error {
+// Players that step into the grass may encounter wild monsters. Players
+// that are in a battle stand still.
+func takeMovementSimulationStep(ent *entity.Entity, movementQueue *MovementQueue, routeFinder RouteFinder, grid *Grid, encounters *Encounters) error {
+	if battle, ok := ent.GetComponent(BattleTag).(*BattleComponent); ok && battle.Session != nil {
+		return nil
+	}
+
 	direction := movementQueue.PollDirectionToFace()
 	if direction != nil {
 		fmt.Println(*direction)
@@ -268,6 +280,10 @@ func takeMovementSimulationStep(ent *entity.Entity, movementQueue *MovementQueue
 		fmt.Println(newPos)
 
 		// TODO add to tracking
+
+		if kind, ok := ent.GetComponent(KindTag).(*KindComponent); ok && kind.Kind == PlayerKind && encounters != nil {
+			return encounters.Step(PlayerBy(ent), grid)
+		}
 	}
 
 	return nil
@@ -319,8 +335,19 @@ func (queue *MovementQueue) PollStep() *Direction {
 	return &step
 }
 
+// Clear drops the steps that have yet to be taken, along with any point
+// that has yet to be walked to.
+func (queue *MovementQueue) Clear() {
+	queue.stepsToTake = nil
+	queue.targetPoint = nil
+}
+
 func faceDirection() faceDirectionHandler {
 	return func(plr *Player, direction Direction) error {
+		if plr.InBattle() {
+			return ErrInBattle
+		}
+
 		plr.Face(direction)
 
 		return nil
@@ -329,6 +356,10 @@ func faceDirection() faceDirectionHandler {
 
 func changeMovementType() changeMovementTypeHandler {
 	return func(plr *Player, movementType MovementType) error {
+		if plr.InBattle() {
+			return ErrInBattle
+		}
+
 		switch movementType {
 		case Walk:
 			plr.Walk()
@@ -346,6 +377,14 @@ func changeMovementType() changeMovementTypeHandler {
 
 func moveAvatar() moveAvatarHandler {
 	return func(plr *Player, direction Direction) error {
+		if plr.InBattle() {
+			return ErrInBattle
+		}
+
+		if plr.InTrade() {
+			return ErrInTrade
+		}
+
 		plr.Move(direction)
 
 		return nil
@@ -354,6 +393,14 @@ func moveAvatar() moveAvatarHandler {
 
 func clickTeleport() clickTeleportHandler {
 	return func(plr *Player, mapX, mapZ, localX, localZ int) error {
+		if plr.InBattle() {
+			return ErrInBattle
+		}
+
+		if plr.InTrade() {
+			return ErrInTrade
+		}
+
 		return nil
 	}
 }
@@ -38,6 +38,44 @@ type moveAvatarHandler func(plr *Player, direction Direction) error
 
 type interactWithEntityHandler func(plr *Player, entityID entity.ID) error
 
+type chooseBattleMoveHandler func(plr *Player, slot int) error
+
+type switchBattleMonsterHandler func(plr *Player, partySlot int) error
+
+type useBattleItemHandler func(plr *Player, itemID int) error
+
+type fleeBattleHandler func(plr *Player) error
+
+type answerEvolutionHandler func(plr *Player, partySlot int, accept bool) error
+
+type answerMoveHandler func(plr *Player, partySlot int, move MoveID, learn bool, forgetSlot int) error
+
+type browsePCBoxHandler func(plr *Player, box int) error
+
+type depositPCMonsterHandler func(plr *Player, partySlot, box int) error
+
+type withdrawPCMonsterHandler func(plr *Player, box, slot int) error
+
+type movePCMonsterHandler func(plr *Player, boxFrom, slotFrom, boxTo, slotTo int) error
+
+type releasePCMonsterHandler func(plr *Player, box, slot int) error
+
+type closePCHandler func(plr *Player) error
+
+type offerTradeMonsterHandler func(plr *Player, partySlot int) error
+
+type retractTradeOfferHandler func(plr *Player, offerSlot int) error
+
+type offerTradeDollarsHandler func(plr *Player, amount int) error
+
+type acceptTradeHandler func(plr *Player) error
+
+type declineTradeHandler func(plr *Player) error
+
+type moveInventoryItemHandler func(plr *Player, pocket, slotFrom, slotTo int) error
+
+type useItemHandler func(plr *Player, itemID int) error
+
 type commandHandlerOption func(processor *InboundNetworkProcessor)
 
 // InboundNetworkProcessor processes received messages for entities that
@@ -56,6 +94,25 @@ type InboundNetworkProcessor struct {
 	handleMovementTypeChange changeMovementTypeHandler
 	handleAvatarMove         moveAvatarHandler
 	handleEntityInteraction  interactWithEntityHandler
+	handleBattleMoveChoice   chooseBattleMoveHandler
+	handleBattleSwitch       switchBattleMonsterHandler
+	handleBattleItemUse      useBattleItemHandler
+	handleBattleFlee         fleeBattleHandler
+	handleEvolutionAnswer    answerEvolutionHandler
+	handleMoveAnswer         answerMoveHandler
+	handlePCBoxBrowse        browsePCBoxHandler
+	handlePCDeposit          depositPCMonsterHandler
+	handlePCWithdrawal       withdrawPCMonsterHandler
+	handlePCMove             movePCMonsterHandler
+	handlePCRelease          releasePCMonsterHandler
+	handlePCClose            closePCHandler
+	handleTradeMonsterOffer  offerTradeMonsterHandler
+	handleTradeOfferRetract  retractTradeOfferHandler
+	handleTradeDollarsOffer  offerTradeDollarsHandler
+	handleTradeAccept        acceptTradeHandler
+	handleTradeDecline       declineTradeHandler
+	handleInventoryItemMove  moveInventoryItemHandler
+	handleItemUse            useItemHandler
 }
 
 // OutboundNetworkProcessor processes queued messages for entities that
@@ -130,6 +187,120 @@ func withEntityInteraction(handler interactWithEntityHandler) commandHandlerOpti
 	}
 }
 
+func withChooseBattleMoveHandler(handler chooseBattleMoveHandler) commandHandlerOption {
+	return func(processor *InboundNetworkProcessor) {
+		processor.handleBattleMoveChoice = handler
+	}
+}
+
+func withSwitchBattleMonsterHandler(handler switchBattleMonsterHandler) commandHandlerOption {
+	return func(processor *InboundNetworkProcessor) {
+		processor.handleBattleSwitch = handler
+	}
+}
+
+func withUseBattleItemHandler(handler useBattleItemHandler) commandHandlerOption {
+	return func(processor *InboundNetworkProcessor) {
+		processor.handleBattleItemUse = handler
+	}
+}
+
+func withFleeBattleHandler(handler fleeBattleHandler) commandHandlerOption {
+	return func(processor *InboundNetworkProcessor) {
+		processor.handleBattleFlee = handler
+	}
+}
+
+func withAnswerEvolutionHandler(handler answerEvolutionHandler) commandHandlerOption {
+	return func(processor *InboundNetworkProcessor) {
+		processor.handleEvolutionAnswer = handler
+	}
+}
+
+func withAnswerMoveHandler(handler answerMoveHandler) commandHandlerOption {
+	return func(processor *InboundNetworkProcessor) {
+		processor.handleMoveAnswer = handler
+	}
+}
+
+func withBrowsePCBoxHandler(handler browsePCBoxHandler) commandHandlerOption {
+	return func(processor *InboundNetworkProcessor) {
+		processor.handlePCBoxBrowse = handler
+	}
+}
+
+func withDepositPCMonsterHandler(handler depositPCMonsterHandler) commandHandlerOption {
+	return func(processor *InboundNetworkProcessor) {
+		processor.handlePCDeposit = handler
+	}
+}
+
+func withWithdrawPCMonsterHandler(handler withdrawPCMonsterHandler) commandHandlerOption {
+	return func(processor *InboundNetworkProcessor) {
+		processor.handlePCWithdrawal = handler
+	}
+}
+
+func withMovePCMonsterHandler(handler movePCMonsterHandler) commandHandlerOption {
+	return func(processor *InboundNetworkProcessor) {
+		processor.handlePCMove = handler
+	}
+}
+
+func withReleasePCMonsterHandler(handler releasePCMonsterHandler) commandHandlerOption {
+	return func(processor *InboundNetworkProcessor) {
+		processor.handlePCRelease = handler
+	}
+}
+
+func withClosePCHandler(handler closePCHandler) commandHandlerOption {
+	return func(processor *InboundNetworkProcessor) {
+		processor.handlePCClose = handler
+	}
+}
+
+func withOfferTradeMonsterHandler(handler offerTradeMonsterHandler) commandHandlerOption {
+	return func(processor *InboundNetworkProcessor) {
+		processor.handleTradeMonsterOffer = handler
+	}
+}
+
+func withRetractTradeOfferHandler(handler retractTradeOfferHandler) commandHandlerOption {
+	return func(processor *InboundNetworkProcessor) {
+		processor.handleTradeOfferRetract = handler
+	}
+}
+
+func withOfferTradeDollarsHandler(handler offerTradeDollarsHandler) commandHandlerOption {
+	return func(processor *InboundNetworkProcessor) {
+		processor.handleTradeDollarsOffer = handler
+	}
+}
+
+func withAcceptTradeHandler(handler acceptTradeHandler) commandHandlerOption {
+	return func(processor *InboundNetworkProcessor) {
+		processor.handleTradeAccept = handler
+	}
+}
+
+func withDeclineTradeHandler(handler declineTradeHandler) commandHandlerOption {
+	return func(processor *InboundNetworkProcessor) {
+		processor.handleTradeDecline = handler
+	}
+}
+
+func withMoveInventoryItemHandler(handler moveInventoryItemHandler) commandHandlerOption {
+	return func(processor *InboundNetworkProcessor) {
+		processor.handleInventoryItemMove = handler
+	}
+}
+
+func withUseItemHandler(handler useItemHandler) commandHandlerOption {
+	return func(processor *InboundNetworkProcessor) {
+		processor.handleItemUse = handler
+	}
+}
+
 // NewInboundNetworkSystem constructs a new instance of an entity.System with
 // a InboundNetworkProcessor as its internal processor.
 func NewInboundNetworkSystem(logger *zap.SugaredLogger, handlerOptions ...commandHandlerOption) *entity.System {
@@ -223,6 +394,44 @@ func (processor *InboundNetworkProcessor) Update(world *entity.World, deltaTime
 				err = processor.handleContinueDialogue(session.Player)
 			case *transport.InteractWithEntity:
 				err = processor.handleEntityInteraction(session.Player, entity.ID(cmd.PID))
+			case *transport.ChooseBattleMove:
+				err = processor.handleBattleMoveChoice(session.Player, int(cmd.Slot))
+			case *transport.SwitchBattleMonster:
+				err = processor.handleBattleSwitch(session.Player, int(cmd.PartySlot))
+			case *transport.UseBattleItem:
+				err = processor.handleBattleItemUse(session.Player, int(cmd.ItemID))
+			case *transport.FleeBattle:
+				err = processor.handleBattleFlee(session.Player)
+			case *transport.AnswerEvolution:
+				err = processor.handleEvolutionAnswer(session.Player, int(cmd.PartySlot), cmd.Accept)
+			case *transport.AnswerMove:
+				err = processor.handleMoveAnswer(session.Player, int(cmd.PartySlot), MoveID(cmd.MoveID), cmd.Learn, int(cmd.ForgetSlot))
+			case *transport.BrowsePCBox:
+				err = processor.handlePCBoxBrowse(session.Player, int(cmd.Box))
+			case *transport.DepositPCMonster:
+				err = processor.handlePCDeposit(session.Player, int(cmd.PartySlot), int(cmd.Box))
+			case *transport.WithdrawPCMonster:
+				err = processor.handlePCWithdrawal(session.Player, int(cmd.Box), int(cmd.Slot))
+			case *transport.MovePCMonster:
+				err = processor.handlePCMove(session.Player, int(cmd.BoxFrom), int(cmd.SlotFrom), int(cmd.BoxTo), int(cmd.SlotTo))
+			case *transport.ReleasePCMonster:
+				err = processor.handlePCRelease(session.Player, int(cmd.Box), int(cmd.Slot))
+			case *transport.ClosePC:
+				err = processor.handlePCClose(session.Player)
+			case *transport.OfferTradeMonster:
+				err = processor.handleTradeMonsterOffer(session.Player, int(cmd.PartySlot))
+			case *transport.RetractTradeOffer:
+				err = processor.handleTradeOfferRetract(session.Player, int(cmd.OfferSlot))
+			case *transport.OfferTradeDollars:
+				err = processor.handleTradeDollarsOffer(session.Player, int(cmd.Amount))
+			case *transport.AcceptTrade:
+				err = processor.handleTradeAccept(session.Player)
+			case *transport.DeclineTrade:
+				err = processor.handleTradeDecline(session.Player)
+			case *transport.MoveInventoryItem:
+				err = processor.handleInventoryItemMove(session.Player, int(cmd.Pocket), int(cmd.SlotFrom), int(cmd.SlotTo))
+			case *transport.UseItem:
+				err = processor.handleItemUse(session.Player, int(cmd.ItemID))
 			default:
 				processor.Logger.Errorf("Unexpected session command of type %v", reflect.TypeOf(cmd))
 			}
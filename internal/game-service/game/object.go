@@ -0,0 +1,23 @@
+package game
+
+import "gitlab.com/pokesync/game-service/internal/game-service/game/entity"
+
+// Object is a type of Entity.
+type Object struct {
+	*entity.Entity
+}
+
+// ObjectBy wraps the given Entity as an Object.
+func ObjectBy(entity *entity.Entity) *Object {
+	return &Object{entity}
+}
+
+// ModelID returns the object's model id.
+func (obj *Object) ModelID() ModelID {
+	return obj.GetComponent(ModelIDTag).(*ModelIDComponent).ModelID
+}
+
+// Position returns the object's Position on the game map.
+func (obj *Object) Position() Position {
+	return obj.GetComponent(TransformTag).(*TransformComponent).MovementQueue.Position
+}
@@ -89,10 +89,27 @@ func (belt *PartyBelt) Clear(slot int) (*Monster, error) {
 	belt.Size--
 	belt.monsters = append(belt.monsters[:slot], belt.monsters[slot+1:]...)
 
-	belt.notifySlotUpdated(slot, nil)
+	// the monsters after the cleared slot have each shifted down a slot
+	for shifted := slot; shifted < belt.Size; shifted++ {
+		belt.notifySlotUpdated(shifted, belt.monsters[shifted])
+	}
+
+	belt.notifySlotUpdated(belt.Size, nil)
 	return before, nil
 }
 
+// Remove removes the given Monster from this belt, shifting down the
+// monsters after it. Returns false if the Monster is not in this belt.
+func (belt *PartyBelt) Remove(monster *Monster) bool {
+	slot := belt.SlotOf(monster)
+	if slot < 0 {
+		return false
+	}
+
+	_, err := belt.Clear(slot)
+	return err == nil
+}
+
 // Set overrides the specified slot with the given Monster instance. May return
 // an error if the given slot is out of bounds of the party's current size.
 func (belt *PartyBelt) Set(slot int, monster *Monster) (*Monster, error) {
@@ -106,7 +123,6 @@ func (belt *PartyBelt) Set(slot int, monster *Monster) (*Monster, error) {
 
 	before := belt.monsters[slot]
 	belt.monsters[slot] = monster
-	belt.Size++
 
 	belt.notifySlotUpdated(slot, monster)
 	return before, nil
@@ -119,7 +135,7 @@ func (belt *PartyBelt) ClearAll() {
 	}
 
 	belt.monsters = []*Monster{}
-	belt.Size--
+	belt.Size = 0
 }
 
 // Get looks up a Monster in the specified slot. May return an error if
@@ -132,6 +148,18 @@ func (belt *PartyBelt) Get(slot int) (*Monster, error) {
 	return belt.monsters[slot], nil
 }
 
+// SlotOf looks up the slot the given Monster is in. Returns -1 if the
+// Monster is not in this belt.
+func (belt *PartyBelt) SlotOf(monster *Monster) int {
+	for slot, m := range belt.monsters {
+		if m == monster {
+			return slot
+		}
+	}
+
+	return -1
+}
+
 // IsEmpty returns whether there are any monsters in this belt.
 func (belt *PartyBelt) IsEmpty() bool {
 	return belt.Size == 0
@@ -200,6 +228,10 @@ func (listener *PartyBeltSessionListener) Updated(slot int, monster *Monster) {
 // switchPartySlots is a message handler for the SwitchPartySlots command
 func switchPartySlots() switchPartySlotsHandler {
 	return func(plr *Player, slotFrom, slotTo int) error {
+		if plr.InBattle() {
+			return ErrInBattle
+		}
+
 		return plr.PartyBelt().Swap(slotFrom, slotTo)
 	}
 }
@@ -0,0 +1,388 @@
+package game
+
+import (
+	"errors"
+
+	"gitlab.com/pokesync/game-service/internal/game-service/game/entity"
+	"gitlab.com/pokesync/game-service/internal/game-service/game/transport"
+)
+
+var (
+	// ErrPCStorageFull is returned when attempting to deposit a monster
+	// into a PCStorage of which every box is full.
+	ErrPCStorageFull = errors.New("every box of the PC is full")
+
+	// ErrPCBoxFull is returned when attempting to deposit a monster into
+	// a PC box that is full.
+	ErrPCBoxFull = errors.New("PC box is full")
+
+	// ErrPCSlotEmpty is returned when attempting to take a monster out of
+	// an empty PC slot.
+	ErrPCSlotEmpty = errors.New("PC slot is empty")
+
+	// ErrPCNotOpen is returned when a Player attempts to access its PC
+	// without using a PC object.
+	ErrPCNotOpen = errors.New("player is not using a PC")
+
+	// ErrLastPartyMonster is returned when a Player attempts to deposit
+	// the last monster of its PartyBelt.
+	ErrLastPartyMonster = errors.New("can not deposit the last monster of the party")
+
+	// ErrPartyFull is returned when a Player attempts to withdraw a
+	// monster whilst its PartyBelt is full.
+	ErrPartyFull = errors.New("party belt is full")
+)
+
+const (
+	// PCBoxCount is the amount of boxes in a player's PC.
+	PCBoxCount = 32
+
+	// PCBoxCapacity is the amount of monsters a single PC box can hold.
+	PCBoxCapacity = 100
+)
+
+// PCStorageUpdateListener listens for changes made to the PCStorage.
+type PCStorageUpdateListener interface {
+	Updated(box, slot int, monster *MonsterData)
+}
+
+// PCStorageSessionListener is a PCStorageUpdateListener that listens
+// for changes made to the PCStorage to visually apply these changes to
+// the Client as well.
+type PCStorageSessionListener struct {
+	session *Session
+}
+
+// PCStorage is the storage of monsters a player has deposited in the
+// boxes of its PC.
+type PCStorage struct {
+	boxes [PCBoxCount][PCBoxCapacity]*MonsterData
+
+	listeners []PCStorageUpdateListener
+}
+
+// NewPCStorage constructs a new instance of an empty PCStorage.
+func NewPCStorage() *PCStorage {
+	return &PCStorage{}
+}
+
+// Get looks up the monster in the specified slot of the specified box.
+// May return nil if the slot is empty, or an error if either the box or
+// the slot is out of bounds.
+func (storage *PCStorage) Get(box, slot int) (*MonsterData, error) {
+	if err := checkPCBoundaries(box, slot); err != nil {
+		return nil, err
+	}
+
+	return storage.boxes[box][slot], nil
+}
+
+// Set overrides the specified slot of the specified box with the given
+// monster, which may be nil to empty the slot. Returns the monster that
+// was previously stored in the slot, or an error if either the box or the
+// slot is out of bounds.
+func (storage *PCStorage) Set(box, slot int, monster *MonsterData) (*MonsterData, error) {
+	if err := checkPCBoundaries(box, slot); err != nil {
+		return nil, err
+	}
+
+	before := storage.boxes[box][slot]
+	storage.boxes[box][slot] = monster
+
+	storage.notifySlotUpdated(box, slot, monster)
+	return before, nil
+}
+
+// Deposit stores the given monster into the first empty slot of the
+// first box that has any room left. Returns the box and slot the monster
+// was stored in, or ErrPCStorageFull if every box is full.
+func (storage *PCStorage) Deposit(monster *MonsterData) (int, int, error) {
+	for box := range storage.boxes {
+		if slot, err := storage.DepositInto(box, monster); err == nil {
+			return box, slot, nil
+		}
+	}
+
+	return 0, 0, ErrPCStorageFull
+}
+
+// DepositInto stores the given monster into the first empty slot of the
+// specified box. Returns the slot the monster was stored in, ErrPCBoxFull
+// if the box is full or an error if the box is out of bounds.
+func (storage *PCStorage) DepositInto(box int, monster *MonsterData) (int, error) {
+	if err := checkPCBoundaries(box, 0); err != nil {
+		return 0, err
+	}
+
+	for slot, stored := range storage.boxes[box] {
+		if stored == nil {
+			storage.Set(box, slot, monster)
+			return slot, nil
+		}
+	}
+
+	return 0, ErrPCBoxFull
+}
+
+// Withdraw takes the monster out of the specified slot of the specified
+// box. Returns ErrPCSlotEmpty if there is no monster in the slot, or an
+// error if either the box or the slot is out of bounds.
+func (storage *PCStorage) Withdraw(box, slot int) (*MonsterData, error) {
+	monster, err := storage.Get(box, slot)
+	if err != nil {
+		return nil, err
+	}
+
+	if monster == nil {
+		return nil, ErrPCSlotEmpty
+	}
+
+	storage.Set(box, slot, nil)
+	return monster, nil
+}
+
+// Move moves the monster in the first slot into the second slot. If the
+// second slot is occupied, the two monsters swap places. Returns
+// ErrPCSlotEmpty if there is no monster to move, or an error if any of
+// the boxes or slots is out of bounds.
+func (storage *PCStorage) Move(boxFrom, slotFrom, boxTo, slotTo int) error {
+	monster, err := storage.Get(boxFrom, slotFrom)
+	if err != nil {
+		return err
+	}
+
+	if monster == nil {
+		return ErrPCSlotEmpty
+	}
+
+	other, err := storage.Get(boxTo, slotTo)
+	if err != nil {
+		return err
+	}
+
+	storage.Set(boxFrom, slotFrom, other)
+	storage.Set(boxTo, slotTo, monster)
+
+	return nil
+}
+
+// Release releases the monster in the specified slot of the specified
+// box back into the wild, never to be seen again. Returns the released
+// monster, ErrPCSlotEmpty if there is no monster in the slot or an error
+// if either the box or the slot is out of bounds.
+func (storage *PCStorage) Release(box, slot int) (*MonsterData, error) {
+	return storage.Withdraw(box, slot)
+}
+
+// ForEach calls the given function for every monster in the PCStorage,
+// along with the box and slot it is stored in.
+func (storage *PCStorage) ForEach(f func(box, slot int, monster *MonsterData)) {
+	for box := range storage.boxes {
+		for slot, monster := range storage.boxes[box] {
+			if monster != nil {
+				f(box, slot, monster)
+			}
+		}
+	}
+}
+
+// notifySlotUpdated notifies every subscribed listener of the
+// PCStorage's updated slot.
+func (storage *PCStorage) notifySlotUpdated(box, slot int, monster *MonsterData) {
+	for _, listener := range storage.listeners {
+		listener.Updated(box, slot, monster)
+	}
+}
+
+// AddListener subscribes the given listener to receive notifications.
+func (storage *PCStorage) AddListener(listener PCStorageUpdateListener) {
+	storage.listeners = append(storage.listeners, listener)
+}
+
+// RemoveListener unsubscribes the given listener from receiving notifications.
+func (storage *PCStorage) RemoveListener(listener PCStorageUpdateListener) {
+	for i, l := range storage.listeners {
+		if l == listener {
+			storage.listeners = append(storage.listeners[:i], storage.listeners[i+1:]...)
+			break
+		}
+	}
+}
+
+// checkPCBoundaries checks if the given box and slot are valid. If not,
+// it returns an error.
+func checkPCBoundaries(box, slot int) error {
+	if box < 0 || box >= PCBoxCount {
+		return errors.New("given box is out of bounds")
+	}
+
+	if slot < 0 || slot >= PCBoxCapacity {
+		return errors.New("given slot is out of bounds")
+	}
+
+	return nil
+}
+
+// Updated sends a visual update to the Session.
+func (listener *PCStorageSessionListener) Updated(box, slot int, monster *MonsterData) {
+	listener.session.QueueEvent(&transport.SetPCSlot{Box: byte(box), Monster: pcBoxMonsterOf(slot, monster)})
+}
+
+// pcBoxMonsterOf describes the given monster in the specified slot of a
+// PC box to the Client. The monster may be nil if the slot is empty.
+func pcBoxMonsterOf(slot int, monster *MonsterData) transport.PCBoxMonster {
+	if monster == nil {
+		return transport.PCBoxMonster{Slot: byte(slot), MonsterID: 65535}
+	}
+
+	return transport.PCBoxMonster{
+		Slot:       byte(slot),
+		MonsterID:  uint16(monster.ModelID),
+		Gender:     byte(monster.Gender),
+		Coloration: byte(monster.Coloration),
+		Level:      byte(monster.Level),
+	}
+}
+
+// OpenPC gives the Player access to its PCStorage through the given PC
+// object, for as long as the Player stays within reach of it. The Player
+// is shown the first box of its PC.
+func (plr *Player) OpenPC(terminal *entity.Entity) {
+	plr.GetComponent(PCStorageTag).(*PCStorageComponent).Terminal = terminal
+	plr.showPCBox(0)
+}
+
+// ClosePC revokes the Player's access to its PCStorage.
+func (plr *Player) ClosePC() {
+	plr.GetComponent(PCStorageTag).(*PCStorageComponent).Terminal = nil
+}
+
+// usePC checks that the Player has access to its PCStorage, which it
+// loses once it leaves the reach of the PC object it is using.
+func (plr *Player) usePC() (*PCStorage, error) {
+	component := plr.GetComponent(PCStorageTag).(*PCStorageComponent)
+	if component.Terminal == nil {
+		return nil, ErrPCNotOpen
+	}
+
+	if plr.InBattle() {
+		return nil, ErrInBattle
+	}
+
+	if plr.InTrade() {
+		return nil, ErrInTrade
+	}
+
+	if !plr.WithinReach(positionOf(component.Terminal)) {
+		component.Terminal = nil
+		return nil, ErrOutOfReach
+	}
+
+	return component.PCStorage, nil
+}
+
+// showPCBox sends the contents of the specified box of the Player's
+// PCStorage to the Player's Client.
+func (plr *Player) showPCBox(box int) error {
+	if err := checkPCBoundaries(box, 0); err != nil {
+		return err
+	}
+
+	contents := &transport.PCBoxContents{Box: byte(box)}
+	for slot, monster := range plr.PCStorage().boxes[box] {
+		if monster != nil {
+			contents.Monsters = append(contents.Monsters, pcBoxMonsterOf(slot, monster))
+		}
+	}
+
+	plr.QueueEvent(contents)
+	return nil
+}
+
+func browsePCBox() browsePCBoxHandler {
+	return func(plr *Player, box int) error {
+		if _, err := plr.usePC(); err != nil {
+			return err
+		}
+
+		return plr.showPCBox(box)
+	}
+}
+
+func depositPCMonster() depositPCMonsterHandler {
+	return func(plr *Player, partySlot, box int) error {
+		storage, err := plr.usePC()
+		if err != nil {
+			return err
+		}
+
+		belt := plr.PartyBelt()
+		monster, err := belt.Get(partySlot)
+		if err != nil {
+			return err
+		}
+
+		if belt.Size <= 1 {
+			return ErrLastPartyMonster
+		}
+
+		if _, err := storage.DepositInto(box, monster.MonsterData); err != nil {
+			return err
+		}
+
+		_, err = belt.Clear(partySlot)
+		return err
+	}
+}
+
+func withdrawPCMonster(factory *EntityFactory) withdrawPCMonsterHandler {
+	return func(plr *Player, box, slot int) error {
+		storage, err := plr.usePC()
+		if err != nil {
+			return err
+		}
+
+		belt := plr.PartyBelt()
+		if belt.IsFull() {
+			return ErrPartyFull
+		}
+
+		data, err := storage.Withdraw(box, slot)
+		if err != nil {
+			return err
+		}
+
+		belt.Add(MonsterBy(factory.CreateMonster(plr.Position(), data), *data))
+		return nil
+	}
+}
+
+func movePCMonster() movePCMonsterHandler {
+	return func(plr *Player, boxFrom, slotFrom, boxTo, slotTo int) error {
+		storage, err := plr.usePC()
+		if err != nil {
+			return err
+		}
+
+		return storage.Move(boxFrom, slotFrom, boxTo, slotTo)
+	}
+}
+
+func releasePCMonster() releasePCMonsterHandler {
+	return func(plr *Player, box, slot int) error {
+		storage, err := plr.usePC()
+		if err != nil {
+			return err
+		}
+
+		_, err = storage.Release(box, slot)
+		return err
+	}
+}
+
+func closePC() closePCHandler {
+	return func(plr *Player) error {
+		plr.ClosePC()
+		return nil
+	}
+}
@@ -0,0 +1,152 @@
+package game
+
+import "testing"
+
+func TestPCStorage_Deposit(t *testing.T) {
+	storage := NewPCStorage()
+	storage.Set(0, 0, &MonsterData{})
+
+	monster := &MonsterData{ModelID: 1}
+
+	box, slot, err := storage.Deposit(monster)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if box != 0 || slot != 1 {
+		t.Errorf("expected the monster to be deposited into box 0 slot 1 but was box %v slot %v", box, slot)
+	}
+
+	if stored, _ := storage.Get(box, slot); stored != monster {
+		t.Error("expected the monster to have been stored")
+	}
+}
+
+func TestPCStorage_DepositIntoFullStorage(t *testing.T) {
+	storage := NewPCStorage()
+	for box := 0; box < PCBoxCount; box++ {
+		for slot := 0; slot < PCBoxCapacity; slot++ {
+			storage.Set(box, slot, &MonsterData{})
+		}
+	}
+
+	if _, _, err := storage.Deposit(&MonsterData{}); err != ErrPCStorageFull {
+		t.Errorf("expected %v but was %v", ErrPCStorageFull, err)
+	}
+}
+
+// pcRecorder is a PCStorageUpdateListener that records every update.
+type pcRecorder struct {
+	updates int
+}
+
+func (recorder *pcRecorder) Updated(box, slot int, monster *MonsterData) {
+	recorder.updates++
+}
+
+func TestPCStorage_MoveWithdrawAndRelease(t *testing.T) {
+	storage := NewPCStorage()
+
+	recorder := &pcRecorder{}
+	storage.AddListener(recorder)
+
+	first, second := &MonsterData{ModelID: 1}, &MonsterData{ModelID: 2}
+	storage.Set(0, 0, first)
+	storage.Set(3, 7, second)
+
+	if err := storage.Move(0, 0, 3, 7); err != nil {
+		t.Fatal(err)
+	}
+
+	if moved, _ := storage.Get(3, 7); moved != first {
+		t.Error("expected the first monster to have been moved")
+	}
+
+	if swapped, _ := storage.Get(0, 0); swapped != second {
+		t.Error("expected the second monster to have swapped places")
+	}
+
+	if err := storage.Move(1, 0, 0, 0); err != ErrPCSlotEmpty {
+		t.Errorf("expected %v but was %v", ErrPCSlotEmpty, err)
+	}
+
+	if withdrawn, err := storage.Withdraw(3, 7); err != nil || withdrawn != first {
+		t.Errorf("expected to withdraw the first monster but was %v, %v", withdrawn, err)
+	}
+
+	if _, err := storage.Release(3, 7); err != ErrPCSlotEmpty {
+		t.Errorf("expected %v but was %v", ErrPCSlotEmpty, err)
+	}
+
+	if _, err := storage.Release(0, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	if recorder.updates != 6 {
+		t.Errorf("expected 6 updates but was %v", recorder.updates)
+	}
+}
+
+func newPCTestSetup() (*EntityFactory, *Player, *Object) {
+	fixture := newTestFixture(nil, 8)
+
+	plr := fixture.newPlayer("Sino", Position{LocalX: 5, LocalZ: 5})
+	fixture.fillParty(plr, 5, 0, 0)
+
+	terminal := ObjectBy(fixture.factory.CreateObject(Position{LocalX: 5, LocalZ: 6}, 4))
+
+	return fixture.factory, plr, terminal
+}
+
+func TestPC_RequiresTerminal(t *testing.T) {
+	_, plr, terminal := newPCTestSetup()
+
+	if err := depositPCMonster()(plr, 0, 0); err != ErrPCNotOpen {
+		t.Fatalf("expected %v but was %v", ErrPCNotOpen, err)
+	}
+
+	plr.OpenPC(terminal.Entity)
+	if err := depositPCMonster()(plr, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := depositPCMonster()(plr, 0, 0); err != ErrLastPartyMonster {
+		t.Errorf("expected %v but was %v", ErrLastPartyMonster, err)
+	}
+
+	plr.GetComponent(TransformTag).(*TransformComponent).MovementQueue.Position = Position{LocalX: 20, LocalZ: 20}
+
+	if err := browsePCBox()(plr, 0); err != ErrOutOfReach {
+		t.Errorf("expected %v but was %v", ErrOutOfReach, err)
+	}
+}
+
+func TestPC_DepositAndWithdraw(t *testing.T) {
+	factory, plr, terminal := newPCTestSetup()
+	plr.OpenPC(terminal.Entity)
+
+	deposited, _ := plr.PartyBelt().Get(1)
+	if err := depositPCMonster()(plr, 1, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	if stored, _ := plr.PCStorage().Get(2, 0); stored != deposited.MonsterData {
+		t.Fatal("expected the monster to have been deposited into the first slot of box 2")
+	}
+
+	if plr.PartyBelt().Size != 1 {
+		t.Errorf("expected one monster to be left in the party but was %v", plr.PartyBelt().Size)
+	}
+
+	if err := withdrawPCMonster(factory)(plr, 2, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	if withdrawn, _ := plr.PartyBelt().Get(1); withdrawn == nil || withdrawn.ModelID != deposited.ModelID {
+		t.Error("expected the monster to have been withdrawn into the party")
+	}
+
+	if stored, _ := plr.PCStorage().Get(2, 0); stored != nil {
+		t.Error("expected the PC slot to be empty")
+	}
+}
@@ -2,6 +2,7 @@ package game
 
 import (
 	"gitlab.com/pokesync/game-service/internal/game-service/character"
+	"gitlab.com/pokesync/game-service/internal/game-service/client"
 	"gitlab.com/pokesync/game-service/internal/game-service/game/entity"
 )
 
@@ -87,6 +88,11 @@ func (plr *Player) Rank() character.UserGroup {
 	return plr.GetComponent(RankTag).(*RankComponent).UserGroup
 }
 
+// Gender returns the player's Gender.
+func (plr *Player) Gender() Gender {
+	return plr.GetComponent(GenderTag).(*GenderComponent).Gender
+}
+
 // BicycleType returns the type of Bicycle the player owns.
 func (plr *Player) BicycleType() BicycleType {
 	return plr.GetComponent(BicycleTag).(*BicycleComponent).BicycleType
@@ -97,6 +103,11 @@ func (plr *Player) Position() Position {
 	return plr.GetComponent(TransformTag).(*TransformComponent).MovementQueue.Position
 }
 
+// Inventory returns the player's bag of items.
+func (plr *Player) Inventory() *Inventory {
+	return plr.GetComponent(InventoryTag).(*InventoryComponent).Inventory
+}
+
 // CoinBag returns the player's bag of coins.
 func (plr *Player) CoinBag() *CoinBag {
 	return plr.GetComponent(CoinBagTag).(*CoinBagComponent).CoinBag
@@ -106,3 +117,20 @@ func (plr *Player) CoinBag() *CoinBag {
 func (plr *Player) PartyBelt() *PartyBelt {
 	return plr.GetComponent(PartyBeltTag).(*PartyBeltComponent).PartyBelt
 }
+
+// PCStorage returns the player's PCStorage.
+func (plr *Player) PCStorage() *PCStorage {
+	return plr.GetComponent(PCStorageTag).(*PCStorageComponent).PCStorage
+}
+
+// QueueEvent queues the given event to be sent to the Client of the
+// Player. Returns false if the Player has no Session or its queue of
+// events is full.
+func (plr *Player) QueueEvent(event client.Message) bool {
+	component, ok := plr.GetComponent(SessionTag).(*SessionComponent)
+	if !ok {
+		return false
+	}
+
+	return component.session.QueueEvent(event)
+}
@@ -0,0 +1,178 @@
+package game
+
+import (
+	"gitlab.com/pokesync/game-service/internal/game-service/character"
+)
+
+// monsterProfileOf transforms the given MonsterData into a MonsterProfile
+// that can then be persisted.
+func monsterProfileOf(monster *MonsterData) character.MonsterProfile {
+	return character.MonsterProfile{
+		ModelID:         int(monster.ModelID),
+		Gender:          int(monster.Gender),
+		StatusCondition: int(monster.StatusCondition),
+		Coloration:      int(monster.Coloration),
+		Nickname:        monster.Nickname,
+		OriginalTrainer: monster.OriginalTrainer,
+
+		Level:      monster.Level,
+		Experience: monster.Experience,
+
+		IndividualValues: monster.IndividualValues,
+		EffortValues:     monster.EffortValues,
+		Nature:           int(monster.Nature),
+
+		HitPoints: monster.HitPoints,
+		Happiness: monster.Happiness,
+
+		Moves: knownMoveProfilesOf(monster.Moves),
+	}
+}
+
+// knownMoveProfilesOf transforms the given KnownMove's into
+// KnownMoveProfile's that can then be persisted.
+func knownMoveProfilesOf(moves []KnownMove) []character.KnownMoveProfile {
+	profiles := make([]character.KnownMoveProfile, len(moves))
+	for i, move := range moves {
+		profiles[i] = character.KnownMoveProfile{MoveID: int(move.MoveID), PP: move.PP}
+	}
+
+	return profiles
+}
+
+// knownMovesOf transforms the given persisted KnownMoveProfile's back
+// into KnownMove's.
+func knownMovesOf(profiles []character.KnownMoveProfile) []KnownMove {
+	moves := make([]KnownMove, len(profiles))
+	for i, profile := range profiles {
+		moves[i] = KnownMove{MoveID: MoveID(profile.MoveID), PP: profile.PP}
+	}
+
+	return moves
+}
+
+// monsterDataOf transforms the given persisted MonsterProfile back into
+// MonsterData.
+func (service *Service) monsterDataOf(profile character.MonsterProfile) MonsterData {
+	data := MonsterData{
+		ModelID:         ModelID(profile.ModelID),
+		Gender:          Gender(profile.Gender),
+		StatusCondition: StatusCondition(profile.StatusCondition),
+		Coloration:      MonsterColoration(profile.Coloration),
+		Nickname:        profile.Nickname,
+		OriginalTrainer: profile.OriginalTrainer,
+
+		Level:      profile.Level,
+		Experience: profile.Experience,
+
+		IndividualValues: profile.IndividualValues,
+		EffortValues:     profile.EffortValues,
+		Nature:           Nature(profile.Nature),
+
+		HitPoints: profile.HitPoints,
+		Happiness: profile.Happiness,
+
+		Moves: knownMovesOf(profile.Moves),
+	}
+
+	if profile.HitPoints == character.FullHitPoints {
+		data.HitPoints = 1
+		if descriptor := service.assets.Monsters.Get(data.ModelID); descriptor != nil {
+			data.HitPoints = data.Stats(descriptor)[HitPoints]
+		}
+	}
+
+	return data
+}
+
+// partyProfileOf transforms the monsters of the given PartyBelt into
+// MonsterProfile's, in the order of their slots.
+func partyProfileOf(belt *PartyBelt) []character.MonsterProfile {
+	party := make([]character.MonsterProfile, 0, belt.Size)
+	for slot := 0; slot < belt.Size; slot++ {
+		monster, _ := belt.Get(slot)
+		party = append(party, monsterProfileOf(monster.MonsterData))
+	}
+
+	return party
+}
+
+// pcProfileOf transforms the monsters of the given PCStorage into PCEntry's.
+func pcProfileOf(storage *PCStorage) []character.PCEntry {
+	var entries []character.PCEntry
+	storage.ForEach(func(box, slot int, monster *MonsterData) {
+		entries = append(entries, character.PCEntry{Box: box, Slot: slot, Monster: monsterProfileOf(monster)})
+	})
+
+	return entries
+}
+
+// inventoryProfileOf transforms the items of the given Inventory into
+// ItemEntry's.
+func inventoryProfileOf(inventory *Inventory) []character.ItemEntry {
+	var entries []character.ItemEntry
+	inventory.ForEach(func(pocket Pocket, slot int, stack ItemStack) {
+		entries = append(entries, character.ItemEntry{ItemID: stack.ItemID, Quantity: stack.Quantity})
+	})
+
+	return entries
+}
+
+// respawnPointOf transforms the given respawn Position into a RespawnPoint
+// that can then be persisted. Returns nil if there is no respawn Position.
+func respawnPointOf(position *Position) *character.RespawnPoint {
+	if position == nil {
+		return nil
+	}
+
+	return &character.RespawnPoint{
+		MapX:   position.MapX,
+		MapZ:   position.MapZ,
+		LocalX: position.LocalX,
+		LocalZ: position.LocalZ,
+	}
+}
+
+// respawnPositionOf transforms the given persisted RespawnPoint back into
+// a Position.
+func respawnPositionOf(point *character.RespawnPoint) Position {
+	return Position{
+		MapX:   point.MapX,
+		MapZ:   point.MapZ,
+		LocalX: point.LocalX,
+		LocalZ: point.LocalZ,
+	}
+}
+
+// restoreParty rebuilds the PartyBelt of the given Player out of the
+// specified MonsterProfile's.
+func (service *Service) restoreParty(plr *Player, party []character.MonsterProfile) {
+	for _, profile := range party {
+		monster := service.game.CreateMonster(plr.Position(), service.monsterDataOf(profile))
+		if !plr.PartyBelt().Add(monster) {
+			service.logger.Errorf("party of %v exceeds the party belt capacity", plr.DisplayName())
+			return
+		}
+	}
+}
+
+// restorePC restores the given PCEntry's into the PCStorage of the given
+// Player.
+func (service *Service) restorePC(plr *Player, entries []character.PCEntry) {
+	for _, entry := range entries {
+		monster := service.monsterDataOf(entry.Monster)
+		if _, err := plr.PCStorage().Set(entry.Box, entry.Slot, &monster); err != nil {
+			service.logger.Errorf("failed to restore pc entry of %v: %v", plr.DisplayName(), err)
+		}
+	}
+}
+
+// restoreInventory restores the given ItemEntry's into the Inventory of
+// the given Player.
+func (service *Service) restoreInventory(plr *Player, entries []character.ItemEntry) {
+	for _, entry := range entries {
+		if err := plr.Inventory().Add(entry.ItemID, entry.Quantity); err != nil {
+			service.logger.Errorf("failed to restore item %v of %v: %v", entry.ItemID, plr.DisplayName(), err)
+		}
+	}
+}
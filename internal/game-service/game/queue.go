@@ -0,0 +1,216 @@
+package game
+
+import (
+	"time"
+
+	"gitlab.com/pokesync/game-service/internal/game-service/account"
+	"gitlab.com/pokesync/game-service/internal/game-service/character"
+	"gitlab.com/pokesync/game-service/internal/game-service/client"
+	"gitlab.com/pokesync/game-service/internal/game-service/game/transport"
+	"gitlab.com/pokesync/game-service/internal/game-service/token"
+)
+
+// LoginQueue is a FIFO queue of users waiting for the game world to have
+// room for their selected character. Users of the Patron user group and
+// above are given priority over regular users.
+type LoginQueue struct {
+	limit int
+
+	priority []*QueuedLogin
+	regular  []*QueuedLogin
+}
+
+// QueuedLogin is a user waiting in the LoginQueue to enter the game world
+// with its selected character.
+type QueuedLogin struct {
+	Client    *client.Client
+	Account   account.Account
+	Character *character.Profile
+	Token     token.Token
+}
+
+// NewLoginQueue constructs a new instance of a LoginQueue that holds up
+// to the specified amount of users.
+func NewLoginQueue(limit int) *LoginQueue {
+	return &LoginQueue{limit: limit}
+}
+
+// Offer attempts to place the given QueuedLogin at the back of the queue.
+// Returns false if the queue is full.
+func (queue *LoginQueue) Offer(login *QueuedLogin) bool {
+	if queue.Size() >= queue.limit {
+		return false
+	}
+
+	if login.Character.UserGroup >= character.Patron {
+		queue.priority = append(queue.priority, login)
+	} else {
+		queue.regular = append(queue.regular, login)
+	}
+
+	return true
+}
+
+// Peek returns the QueuedLogin that is next in line, without removing it
+// from the queue. May return nil.
+func (queue *LoginQueue) Peek() *QueuedLogin {
+	if len(queue.priority) > 0 {
+		return queue.priority[0]
+	}
+
+	if len(queue.regular) > 0 {
+		return queue.regular[0]
+	}
+
+	return nil
+}
+
+// Poll removes and returns the QueuedLogin that is next in line. May
+// return nil.
+func (queue *LoginQueue) Poll() *QueuedLogin {
+	if len(queue.priority) > 0 {
+		login := queue.priority[0]
+		queue.priority = queue.priority[1:]
+
+		return login
+	}
+
+	if len(queue.regular) > 0 {
+		login := queue.regular[0]
+		queue.regular = queue.regular[1:]
+
+		return login
+	}
+
+	return nil
+}
+
+// Remove removes the QueuedLogin of the Client of the specified ID from
+// the queue. May return nil.
+func (queue *LoginQueue) Remove(id client.ID) *QueuedLogin {
+	var login *QueuedLogin
+
+	queue.priority, login = removeQueuedLogin(queue.priority, id)
+	if login != nil {
+		return login
+	}
+
+	queue.regular, login = removeQueuedLogin(queue.regular, id)
+	return login
+}
+
+// RemoveByEmail removes the QueuedLogin of the account of the specified
+// Email from the queue. May return nil.
+func (queue *LoginQueue) RemoveByEmail(email account.Email) *QueuedLogin {
+	var login *QueuedLogin
+	queue.ForEach(func(position int, queued *QueuedLogin) {
+		if queued.Account.Email == email {
+			login = queued
+		}
+	})
+
+	if login == nil {
+		return nil
+	}
+
+	return queue.Remove(login.Client.ID)
+}
+
+// ForEach calls the given function for every QueuedLogin in the queue,
+// along with its 1-based position in the queue.
+func (queue *LoginQueue) ForEach(f func(position int, login *QueuedLogin)) {
+	position := 1
+	for _, login := range queue.priority {
+		f(position, login)
+		position++
+	}
+
+	for _, login := range queue.regular {
+		f(position, login)
+		position++
+	}
+}
+
+// PositionOf returns the 1-based position in the queue of the Client of
+// the specified ID. Returns 0 if the Client is not in the queue.
+func (queue *LoginQueue) PositionOf(id client.ID) int {
+	position := 0
+	queue.ForEach(func(p int, login *QueuedLogin) {
+		if login.Client.ID == id {
+			position = p
+		}
+	})
+
+	return position
+}
+
+// Size returns the amount of users waiting in the queue.
+func (queue *LoginQueue) Size() int {
+	return len(queue.priority) + len(queue.regular)
+}
+
+// removeQueuedLogin removes the QueuedLogin of the Client of the specified
+// ID from the given slice, returning the resulting slice and the removed
+// QueuedLogin, if any.
+func removeQueuedLogin(logins []*QueuedLogin, id client.ID) ([]*QueuedLogin, *QueuedLogin) {
+	for i, login := range logins {
+		if login.Client.ID == id {
+			return append(logins[:i], logins[i+1:]...), login
+		}
+	}
+
+	return logins, nil
+}
+
+// enqueueLogin places the given Client user in the LoginQueue, for it to
+// enter the game world once there is room. Hangs up on the user if the
+// queue is full.
+func (service *Service) enqueueLogin(cl *client.Client, account account.Account, character *character.Profile, sessionToken token.Token) {
+	login := &QueuedLogin{Client: cl, Account: account, Character: character, Token: sessionToken}
+	if !service.loginQueue.Offer(login) {
+		cl.SendNow(&transport.WorldFull{})
+		cl.Terminate()
+
+		return
+	}
+
+	cl.SendNow(&transport.LoginQueuePosition{
+		Position: uint16(service.loginQueue.PositionOf(cl.ID)),
+		Size:     uint16(service.loginQueue.Size()),
+	})
+}
+
+// admitQueuedLogins lets users waiting in the LoginQueue enter the game
+// world for as long as there is room for them.
+func (service *Service) admitQueuedLogins() {
+	for {
+		login := service.loginQueue.Peek()
+		if login == nil {
+			return
+		}
+
+		if !service.enterWorld(login.Client, login.Account, login.Character, login.Token) {
+			return
+		}
+
+		service.loginQueue.Poll()
+	}
+}
+
+// updateQueuedLogins periodically informs every user waiting in the
+// LoginQueue of its position in the queue.
+func (service *Service) updateQueuedLogins() {
+	if time.Since(service.lastQueueUpdate) < service.config.LoginQueueUpdateRate {
+		return
+	}
+
+	service.lastQueueUpdate = time.Now()
+
+	size := uint16(service.loginQueue.Size())
+	service.loginQueue.ForEach(func(position int, login *QueuedLogin) {
+		login.Client.SendNow(&transport.LoginQueuePosition{
+			Position: uint16(position),
+			Size:     size,
+		})
+	})
+}
@@ -0,0 +1,97 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"gitlab.com/pokesync/game-service/internal/game-service/character"
+	"gitlab.com/pokesync/game-service/internal/game-service/client"
+)
+
+func queuedLogin(userGroup character.UserGroup) *QueuedLogin {
+	return &QueuedLogin{
+		Client:    &client.Client{ID: client.ID(uuid.New())},
+		Character: &character.Profile{UserGroup: userGroup},
+	}
+}
+
+func TestLoginQueue_Offer(t *testing.T) {
+	queue := NewLoginQueue(2)
+
+	if !queue.Offer(queuedLogin(character.Regular)) || !queue.Offer(queuedLogin(character.Regular)) {
+		t.Fatal("expected queue to accept logins up to its limit")
+	}
+
+	if queue.Offer(queuedLogin(character.Patron)) {
+		t.Error("expected queue to reject logins beyond its limit")
+	}
+}
+
+func TestLoginQueue_Priority(t *testing.T) {
+	queue := NewLoginQueue(8)
+
+	regular1 := queuedLogin(character.Regular)
+	regular2 := queuedLogin(character.Regular)
+	patron := queuedLogin(character.Patron)
+	moderator := queuedLogin(character.Moderator)
+
+	queue.Offer(regular1)
+	queue.Offer(regular2)
+	queue.Offer(patron)
+	queue.Offer(moderator)
+
+	if queue.PositionOf(patron.Client.ID) != 1 || queue.PositionOf(moderator.Client.ID) != 2 || queue.PositionOf(regular1.Client.ID) != 3 {
+		t.Error("expected patrons and above to be placed ahead of regular users")
+	}
+
+	for _, expected := range []*QueuedLogin{patron, moderator, regular1, regular2} {
+		if login := queue.Poll(); login != expected {
+			t.Error("expected logins to be polled in order of priority")
+		}
+	}
+
+	if queue.Poll() != nil {
+		t.Error("expected queue to be empty")
+	}
+}
+
+func TestLoginQueue_Remove(t *testing.T) {
+	queue := NewLoginQueue(8)
+
+	first := queuedLogin(character.Regular)
+	second := queuedLogin(character.Regular)
+	third := queuedLogin(character.Regular)
+
+	queue.Offer(first)
+	queue.Offer(second)
+	queue.Offer(third)
+
+	if queue.Remove(second.Client.ID) != second {
+		t.Fatal("expected second login to be removed")
+	}
+
+	if queue.PositionOf(third.Client.ID) != 2 {
+		t.Errorf("expected third login to have moved up to position %v but was %v instead", 2, queue.PositionOf(third.Client.ID))
+	}
+
+	if queue.Remove(second.Client.ID) != nil {
+		t.Error("expected no second login to be left to remove")
+	}
+}
+
+func TestService_AdmitQueuedLoginsWhileFull(t *testing.T) {
+	service := &Service{
+		game:       &Game{config: Config{MaxPlayers: 0, StaffOverflow: 0}},
+		loginQueue: NewLoginQueue(1),
+	}
+
+	service.loginQueue.Offer(queuedLogin(character.Regular))
+
+	// the game has no means to create a player with, so this would fail
+	// if the queued user was attempted to be let in regardless
+	service.admitQueuedLogins()
+
+	if service.loginQueue.Size() != 1 {
+		t.Error("expected the queued user to keep waiting whilst the world is full")
+	}
+}
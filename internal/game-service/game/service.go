@@ -2,7 +2,11 @@ package game
 
 import (
 	"context"
+	"math/rand"
 	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"gitlab.com/pokesync/game-service/pkg/event"
@@ -15,6 +19,7 @@ import (
 	"gitlab.com/pokesync/game-service/internal/game-service/account"
 	"gitlab.com/pokesync/game-service/internal/game-service/character"
 	"gitlab.com/pokesync/game-service/internal/game-service/client"
+	"gitlab.com/pokesync/game-service/internal/game-service/token"
 	"go.uber.org/zap"
 )
 
@@ -22,24 +27,56 @@ import (
 type Config struct {
 	IntervalRate time.Duration
 
+	// EntityLimit is the maximum amount of non-player entities, such as
+	// npc's and monsters, that can be in the game world at once.
 	EntityLimit int
 
+	// MaxPlayers is the maximum amount of players that can be in the game
+	// world at once, on top of which StaffOverflow is reserved for staff.
+	MaxPlayers    int
+	StaffOverflow int
+
 	CharacterFetchTimeout time.Duration
+	CharacterLimit        int
+
+	NameRules     *character.NameRules
+	StartPosition Position
+
+	TokenIssuer *token.Issuer
+
+	// DisableAccount disables the account of a banned user, for it to no
+	// longer be logged into.
+	DisableAccount AccountDisabler
 
-	SessionConfig SessionConfig
+	SessionConfig  SessionConfig
+	LingerDuration time.Duration
+
+	// AutosaveInterval is the interval at which the character profiles
+	// of online players are saved. Autosaving is disabled if zero.
+	AutosaveInterval time.Duration
+
+	LoginQueueLimit      int
+	LoginQueueUpdateRate time.Duration
 
 	ClockRate         time.Duration
 	ClockSynchronizer ClockSynchronizer
 
+	// ShinyOdds is the amount of monsters out of which one is generated
+	// shiny. Defaults to DefaultShinyOdds if zero.
+	ShinyOdds int
+
 	Modules []Module
 }
 
-// CharacterProvider attempts to provide a character Profile.
+// CharacterProvider attempts to provide the character Profile's of an account.
 type CharacterProvider func(email account.Email) <-chan character.LoadResult
 
 // CharacterSaver attempts to save character Profile's.
 type CharacterSaver func(email account.Email, profile *character.Profile)
 
+// AccountDisabler disables the account of the specified Email.
+type AccountDisabler func(email account.Email)
+
 // pulse represents a tick or a single heartbeat.
 type pulse struct{}
 
@@ -66,13 +103,21 @@ type Service struct {
 
 	logger *zap.SugaredLogger
 
-	routing  *client.Router
-	sessions *SessionRegistry
+	routing   *client.Router
+	sessions  *SessionRegistry
+	lingering *LingerRegistry
+	lobby     *Lobby
 
-	mailbox client.Mailbox
-	pulser  *pulser
+	loginQueue      *LoginQueue
+	lastQueueUpdate time.Time
+
+	mailbox  client.Mailbox
+	pulser   *pulser
+	stopped  chan bool
+	stopOnce sync.Once
 
 	characterProvider CharacterProvider
+	characterCreator  CharacterCreator
 	characterSaver    CharacterSaver
 
 	game *Game
@@ -80,11 +125,29 @@ type Service struct {
 
 // Game represents the game, mkay.
 type Game struct {
+	config Config
+
+	// playerCount is accessed atomically as it is read from outside of
+	// the game loop, entityCount is not
+	playerCount int32
+	entityCount int
+
 	world         *entity.World
 	entityFactory *EntityFactory
 	eventBus      event.Bus
 	grid          *Grid
 	chatCommands  *ChatCommandRegistry
+	generator     *MonsterGenerator
+	encounters    *Encounters
+	evolutions    *Evolutions
+	entities      *EntityIndex
+	interactions  *InteractionRegistry
+	trades        *Trades
+	healer        *Healer
+
+	// banPlayer bans the user of a Player, which is up to the Service
+	// as it keeps track of the Session's of users.
+	banPlayer func(plr *Player)
 }
 
 const (
@@ -96,6 +159,8 @@ const (
 // Service has any interest in for processing.
 var messageTopicsOfInterest = []client.Topic{
 	AuthenticationEventTopic,
+	transport.CreateCharacterConfig.Topic,
+	transport.SelectCharacterConfig.Topic,
 	transport.AttachFollowerConfig.Topic,
 	transport.SwitchPartySlotsConfig.Topic,
 	transport.ChangeMovementTypeConfig.Topic,
@@ -107,6 +172,25 @@ var messageTopicsOfInterest = []client.Topic{
 	transport.InteractWithEntityConfig.Topic,
 	transport.SelectPlayerOptionConfig.Topic,
 	transport.SubmitChatCommandConfig.Topic,
+	transport.ChooseBattleMoveConfig.Topic,
+	transport.SwitchBattleMonsterConfig.Topic,
+	transport.UseBattleItemConfig.Topic,
+	transport.FleeBattleConfig.Topic,
+	transport.AnswerEvolutionConfig.Topic,
+	transport.AnswerMoveConfig.Topic,
+	transport.BrowsePCBoxConfig.Topic,
+	transport.DepositPCMonsterConfig.Topic,
+	transport.WithdrawPCMonsterConfig.Topic,
+	transport.MovePCMonsterConfig.Topic,
+	transport.ReleasePCMonsterConfig.Topic,
+	transport.ClosePCConfig.Topic,
+	transport.OfferTradeMonsterConfig.Topic,
+	transport.RetractTradeOfferConfig.Topic,
+	transport.OfferTradeDollarsConfig.Topic,
+	transport.AcceptTradeConfig.Topic,
+	transport.DeclineTradeConfig.Topic,
+	transport.MoveInventoryItemConfig.Topic,
+	transport.UseItemConfig.Topic,
 	client.TerminationTopic,
 }
 
@@ -117,15 +201,25 @@ type Authenticated struct {
 	Account account.Account
 }
 
-// CharacterLoaded is an event of a client user having their character
-// profile loaded and is thus ready to be registered into the game world.
-type CharacterLoaded struct {
+// CharactersLoaded is an event of a client user having the profiles of
+// its characters loaded and is thus ready to pick the character it wants
+// to be registered into the game world with.
+type CharactersLoaded struct {
+	Account    account.Account
+	Characters []*character.Profile
+	Token      token.Token
+}
+
+// CharacterCreated is an event of a client user's attempt at creating a
+// new character having completed. The Character is nil if the attempt
+// was rejected.
+type CharacterCreated struct {
 	Account   account.Account
 	Character *character.Profile
 }
 
 // NewService constructs a new game Service.
-func NewService(config Config, routing *client.Router, characterProvider CharacterProvider, characterSaver CharacterSaver, assets *AssetBundle, logger *zap.SugaredLogger) *Service {
+func NewService(config Config, routing *client.Router, characterProvider CharacterProvider, characterCreator CharacterCreator, characterSaver CharacterSaver, assets *AssetBundle, logger *zap.SugaredLogger) *Service {
 	service := &Service{
 		config: config,
 
@@ -134,15 +228,25 @@ func NewService(config Config, routing *client.Router, characterProvider Charact
 		assets: assets,
 
 		characterProvider: characterProvider,
+		characterCreator:  characterCreator,
 		characterSaver:    characterSaver,
 
 		routing: routing,
 	}
 
 	service.sessions = NewSessionRegistry()
-	service.game = NewGame(config, assets, logger)
+	service.lingering = NewLingerRegistry()
+	service.lobby = NewLobby()
+	service.loginQueue = NewLoginQueue(config.LoginQueueLimit)
+	service.game = NewGame(config, assets, service.transformPlayerToCharacterProfile, characterSaver, logger)
+	service.game.banPlayer = service.ban
+
+	if config.AutosaveInterval > 0 {
+		service.game.world.AddSystem(NewAutosaveSystem(config.AutosaveInterval, service.transformPlayerToCharacterProfile, characterSaver))
+	}
 
 	service.pulser = newPulser(config.IntervalRate)
+	service.stopped = make(chan bool)
 	service.mailbox = routing.CreateMailbox()
 
 	for _, topic := range messageTopicsOfInterest {
@@ -162,19 +266,38 @@ func NewService(config Config, routing *client.Router, characterProvider Charact
 	return service
 }
 
-// NewGame constructs a new Game.
-func NewGame(config Config, assets *AssetBundle, logger *zap.SugaredLogger) *Game {
-	world := entity.NewWorld(config.EntityLimit)
+// NewGame constructs a new Game. The given ProfileSnapshotter and
+// CharacterSaver are used to save characters outside of autosaves, such
+// as right after a trade.
+func NewGame(config Config, assets *AssetBundle, snapshotter ProfileSnapshotter, saver CharacterSaver, logger *zap.SugaredLogger) *Game {
+	world := entity.NewWorld(config.EntityLimit + config.MaxPlayers + config.StaffOverflow)
 	entityFactory := NewEntityFactory(world, assets)
 	eventBus := event.NewSerialBus()
 	chatCommands := NewChatCommandRegistry()
+	dayNight := NewDayNightProcessor(config.ClockSynchronizer)
+	generator := NewMonsterGenerator(assets.Moves, config.ShinyOdds, rand.New(rand.NewSource(time.Now().UnixNano())))
+	encounters := NewEncounters(assets, entityFactory, generator, dayNight, rand.New(rand.NewSource(time.Now().UnixNano())), logger)
+	evolutions := NewEvolutions(assets.Monsters, dayNight)
+	entities := NewEntityIndex()
+	interactions := NewInteractionRegistry()
+	trades := NewTrades(entityFactory, evolutions, snapshotter, saver, logger)
+	healer := NewHealer(assets.Monsters, assets.Moves, config.StartPosition)
 
 	game := &Game{
+		config: config,
+
 		world:         world,
 		entityFactory: entityFactory,
 		eventBus:      eventBus,
 		grid:          assets.Grid,
 		chatCommands:  chatCommands,
+		generator:     generator,
+		encounters:    encounters,
+		evolutions:    evolutions,
+		entities:      entities,
+		interactions:  interactions,
+		trades:        trades,
+		healer:        healer,
 	}
 
 	world.AddSystem(NewInboundNetworkSystem(
@@ -185,18 +308,37 @@ func NewGame(config Config, assets *AssetBundle, logger *zap.SugaredLogger) *Gam
 		withSwitchPartySlotHandler(switchPartySlots()),
 		withClickTeleportHandler(clickTeleport()),
 		withContinueDialogueHandler(continueDialogue()),
-		withSelectPlayerOptionHandler(selectPlayerOption()),
-		withEntityInteraction(interactWithEntity()),
+		withSelectPlayerOptionHandler(selectPlayerOption(entities, trades)),
+		withEntityInteraction(interactWithEntity(entities, interactions)),
 		withDirectionFacingHandler(faceDirection()),
 		withMoveAvatarHandler(moveAvatar()),
 		withMovementTypeChangeHandler(changeMovementType()),
 		withSubmitChatCommandHandler(submitChatCommand(chatCommands)),
+		withChooseBattleMoveHandler(chooseBattleMove()),
+		withSwitchBattleMonsterHandler(switchBattleMonster()),
+		withUseBattleItemHandler(useBattleItem()),
+		withFleeBattleHandler(fleeBattle()),
+		withAnswerEvolutionHandler(answerEvolution(evolutions)),
+		withAnswerMoveHandler(answerMove(assets.Moves)),
+		withBrowsePCBoxHandler(browsePCBox()),
+		withDepositPCMonsterHandler(depositPCMonster()),
+		withWithdrawPCMonsterHandler(withdrawPCMonster(entityFactory)),
+		withMovePCMonsterHandler(movePCMonster()),
+		withReleasePCMonsterHandler(releasePCMonster()),
+		withClosePCHandler(closePC()),
+		withOfferTradeMonsterHandler(offerTradeMonster()),
+		withRetractTradeOfferHandler(retractTradeOffer()),
+		withOfferTradeDollarsHandler(offerTradeDollars()),
+		withAcceptTradeHandler(acceptTrade()),
+		withDeclineTradeHandler(declineTrade()),
+		withMoveInventoryItemHandler(moveInventoryItem()),
+		withUseItemHandler(useItem()),
 	))
 
-	world.AddSystem(NewWalkingSystem(game.grid, AStarRouteFinder()))
-	world.AddSystem(NewRunningSystem(game.grid, AStarRouteFinder()))
-	world.AddSystem(NewCyclingSystem(game.grid, AStarRouteFinder()))
-	world.AddSystem(NewDayNightSystem(config.ClockRate, config.ClockSynchronizer))
+	world.AddSystem(NewWalkingSystem(game.grid, AStarRouteFinder(), encounters))
+	world.AddSystem(NewRunningSystem(game.grid, AStarRouteFinder(), encounters))
+	world.AddSystem(NewCyclingSystem(game.grid, AStarRouteFinder(), encounters))
+	world.AddSystem(NewDayNightSystem(config.ClockRate, dayNight))
 	world.AddSystem(NewMapViewSystem(game.grid))
 	world.AddSystem(NewOutboundNetworkSystem())
 
@@ -211,18 +353,20 @@ func newPulser(intervalRate time.Duration) *pulser {
 	pulser.rate = intervalRate
 	pulser.lastTime = time.Now()
 
-	pulser.quit = make(chan bool, 1)
+	pulser.quit = make(chan bool)
 	pulser.resume = make(chan time.Duration)
 	pulser.pulses = make(chan pulse)
 
 	go func() {
 		for {
-			if pulser.hasQuitPulsing() {
-				break
-			}
+			// send out a heartbeat into the stream, unless we were told
+			// to quit pulsing whilst waiting for it to be picked up
+			select {
+			case <-pulser.quit:
+				return
 
-			// send out a heartbeat into the stream
-			pulser.pulses <- pulseInstance
+			case pulser.pulses <- pulseInstance:
+			}
 
 			// and then we wait for confirmation to resume, which is to
 			// indicate that processing of the pulse has been completed.
@@ -241,31 +385,32 @@ func newPulser(intervalRate time.Duration) *pulser {
 	return pulser
 }
 
-// quitPulsing sends a signal to have the pulser stop running.
+// quitPulsing sends a signal to have the pulser stop running. Must be
+// called from the goroutine that receives the pulses.
 func (pulser *pulser) quitPulsing() {
-	pulser.quit <- true
-	close(pulser.pulses)
-}
-
-// hasQuitPulsing returns whether a signal was fired to quit pulsing.
-func (pulser *pulser) hasQuitPulsing() bool {
-	select {
-	case <-pulser.quit:
-		return true
-	default:
-		return false
-	}
+	close(pulser.quit)
 }
 
 // receive receives and handles messages from the specified mailbox
-// and also deals with game pulses.
+// and also deals with game pulses, until the Service is stopped.
 func (service *Service) receive() {
 	for {
 		select {
 		case <-service.pulser.pulses:
 			service.pulse()
 
-		case mail := <-service.mailbox:
+		case mail, ok := <-service.mailbox:
+			if !ok {
+				return
+			}
+
+			if request, ok := mail.Payload.(stopRequest); ok {
+				service.stop()
+				close(request.done)
+
+				return
+			}
+
 			service.handleMail(mail)
 		}
 	}
@@ -277,8 +422,26 @@ func (service *Service) handleMail(mail client.Mail) {
 	case Authenticated:
 		go service.onAuthenticated(mail.Context, mail.Client, message.Account)
 
-	case CharacterLoaded:
-		service.onCharacterLoaded(mail.Client, message.Account, message.Character)
+	case CharactersLoaded:
+		service.hangUpPendingLogin(message.Account.Email)
+		if session := service.takeOverSession(message.Account.Email); session != nil {
+			service.onReconnected(mail.Client, session, message.Token)
+			return
+		}
+
+		service.onCharactersLoaded(mail.Client, message.Account, message.Characters, message.Token)
+
+	case shutdownNotice:
+		service.onShutdownNotice(message.remaining)
+
+	case CharacterCreated:
+		service.onCharacterCreated(mail.Client, message.Character)
+
+	case *transport.CreateCharacter:
+		service.onCreateCharacter(mail.Context, mail.Client, message)
+
+	case *transport.SelectCharacter:
+		service.onSelectCharacter(mail.Client, message)
 
 	case client.Message:
 		session := service.sessions.Get(mail.Client.ID)
@@ -289,13 +452,7 @@ func (service *Service) handleMail(mail client.Mail) {
 		session.QueueCommand(message)
 
 	case client.Terminated:
-		session := service.sessions.Remove(mail.Client.ID)
-		if session == nil {
-			return
-		}
-
-		service.game.RemovePlayer(session.Player)
-		service.characterSaver(session.Email, service.transformPlayerToCharacterProfile(session.Player))
+		service.onTerminated(message.ID)
 
 	default:
 		service.logger.Errorf("unexpected message received of type %v", reflect.TypeOf(message))
@@ -307,18 +464,25 @@ func (service *Service) handleMail(mail client.Mail) {
 func (service *Service) transformPlayerToCharacterProfile(player *Player) *character.Profile {
 	displayName := player.DisplayName()
 	userGroup := player.Rank()
+	gender := player.Gender()
 	position := player.Position()
 	bicycleType := player.BicycleType()
 	coinBag := player.CoinBag()
 
-	lastLoggedIn := time.Now()
+	snapshotAt := time.Now()
+
+	lastLoggedIn := snapshotAt
+	if component, ok := player.GetComponent(SessionTag).(*SessionComponent); ok {
+		lastLoggedIn = component.session.LoggedInAt
+	}
 
 	return &character.Profile{
 		DisplayName:  displayName,
 		LastLoggedIn: &lastLoggedIn,
+		SnapshotAt:   snapshotAt,
 		UserGroup:    userGroup,
 
-		Gender:      0,
+		Gender:      int(gender),
 		BicycleType: int(bicycleType),
 
 		PokeDollars:   coinBag.Dollars,
@@ -328,6 +492,13 @@ func (service *Service) transformPlayerToCharacterProfile(player *Player) *chara
 		MapZ:   position.MapZ,
 		LocalX: position.LocalX,
 		LocalZ: position.LocalZ,
+
+		Party: partyProfileOf(player.PartyBelt()),
+		PC:    pcProfileOf(player.PCStorage()),
+
+		Respawn: respawnPointOf(player.RespawnPoint()),
+
+		Inventory: inventoryProfileOf(player.Inventory()),
 	}
 }
 
@@ -347,17 +518,20 @@ func (service *Service) onAuthenticated(ctx context.Context, cl *client.Client,
 			return
 		}
 
-		if result.Profile == nil {
+		sessionToken, err := service.config.TokenIssuer.Issue(account.Email)
+		if err != nil {
+			service.logger.Error(err)
+
 			cl.SendNow(&transport.UnableToFetchProfile{})
 			cl.Terminate()
 
 			return
 		}
 
-		characterLoadEvent := CharacterLoaded{Account: account, Character: result.Profile}
-		mail := client.Mail{Context: ctx, Client: cl, Payload: characterLoadEvent}
+		charactersLoadEvent := CharactersLoaded{Account: account, Characters: result.Profiles, Token: sessionToken}
+		mail := client.Mail{Context: ctx, Client: cl, Payload: charactersLoadEvent}
 
-		service.mailbox <- mail
+		service.post(mail)
 
 	case <-time.After(service.config.CharacterFetchTimeout):
 		cl.SendNow(&transport.RequestTimedOut{})
@@ -365,9 +539,26 @@ func (service *Service) onAuthenticated(ctx context.Context, cl *client.Client,
 	}
 }
 
-// onCharacterLoaded reacts to the given Client user having a character
-// profile loaded for him/her.
-func (service *Service) onCharacterLoaded(cl *client.Client, account account.Account, character *character.Profile) {
+// onCharacterLoaded reacts to the given Client user having selected the
+// character profile it wants to enter the game world with. If the game
+// world is full, the user is placed in the LoginQueue instead.
+func (service *Service) onCharacterLoaded(cl *client.Client, account account.Account, character *character.Profile, sessionToken token.Token) {
+	queued := service.loginQueue.Size() > 0 && !character.UserGroup.IsStaff()
+	if queued || !service.enterWorld(cl, account, character, sessionToken) {
+		service.enqueueLogin(cl, account, character, sessionToken)
+	}
+}
+
+// enterWorld attempts to register the given Client user into the game world
+// with the specified character profile. Returns false if the game world is
+// full.
+func (service *Service) enterWorld(cl *client.Client, account account.Account, character *character.Profile, sessionToken token.Token) bool {
+	// checked up front as users waiting in the LoginQueue attempt to enter
+	// the game world every pulse, for as long as the world is full
+	if !service.game.HasRoomFor(character.UserGroup) {
+		return false
+	}
+
 	position := Position{
 		MapX:   character.MapX,
 		MapZ:   character.MapZ,
@@ -377,13 +568,12 @@ func (service *Service) onCharacterLoaded(cl *client.Client, account account.Acc
 
 	plr := service.game.CreatePlayer(position, Gender(character.Gender), character.DisplayName, character.UserGroup)
 	if ok := service.game.AddPlayer(plr); !ok {
-		cl.SendNow(&transport.WorldFull{})
-		cl.Terminate()
-
-		return
+		return false
 	}
 
 	session := NewInstalledSession(cl, service.config.SessionConfig, account.Email, plr)
+	session.Token = sessionToken
+
 	service.sessions.Put(cl.ID, session)
 
 	plr.Add(&SessionComponent{session: session})
@@ -393,6 +583,14 @@ func (service *Service) onCharacterLoaded(cl *client.Client, account account.Acc
 
 	plr.SetBicycleType(BicycleType(character.BicycleType))
 
+	service.restoreParty(plr, character.Party)
+	service.restorePC(plr, character.PC)
+	service.restoreInventory(plr, character.Inventory)
+
+	if character.Respawn != nil {
+		plr.SetRespawnPoint(respawnPositionOf(character.Respawn))
+	}
+
 	cl.SendNow(&transport.LoginSuccess{
 		PID:         uint16(plr.ID),
 		DisplayName: string(character.DisplayName),
@@ -404,6 +602,8 @@ func (service *Service) onCharacterLoaded(cl *client.Client, account account.Acc
 		MapZ:   uint16(character.MapZ),
 		LocalX: uint16(character.LocalX),
 		LocalZ: uint16(character.LocalZ),
+
+		SessionToken: string(sessionToken),
 	})
 
 	service.routing.Publish(chat.ServiceConnectTopic, client.Mail{
@@ -412,6 +612,8 @@ func (service *Service) onCharacterLoaded(cl *client.Client, account account.Acc
 			DisplayName: character.DisplayName,
 		},
 	})
+
+	return true
 }
 
 // CreatePlayer creates a new Player-like Entity.
@@ -426,46 +628,149 @@ func (game *Game) CreateNpc(modelID ModelID, position Position) *Npc {
 
 // CreateMonster creates a new Monster-like Entity.
 func (game *Game) CreateMonster(position Position, data MonsterData) *Monster {
-	return MonsterBy(game.entityFactory.CreateMonster(position, data.ModelID), data)
+	return MonsterBy(game.entityFactory.CreateMonster(position, &data), data)
+}
+
+// GiveMonster gives the given monster to the Player, adding it to the
+// Player's PartyBelt or depositing it into its PCStorage if the belt is
+// full. Returns ErrPCStorageFull if there is no room left for it at all.
+func (game *Game) GiveMonster(plr *Player, data MonsterData) error {
+	belt := plr.PartyBelt()
+	if !belt.IsFull() {
+		belt.Add(game.CreateMonster(plr.Position(), data))
+		return nil
+	}
+
+	_, _, err := plr.PCStorage().Deposit(&data)
+	return err
 }
 
-// AddPlayer attempts to add the given Player to the game world.
+// AddPlayer attempts to add the given Player to the game world. Fails if
+// the game world has reached its player capacity, although staff members
+// may still be let in on the staff overflow.
 func (game *Game) AddPlayer(plr *Player) bool {
-	return game.AddEntity(plr.Entity)
+	if !game.HasRoomFor(plr.Rank()) {
+		return false
+	}
+
+	if !game.AddEntity(plr.Entity) {
+		return false
+	}
+
+	atomic.AddInt32(&game.playerCount, 1)
+	return true
+}
+
+// HasRoomFor returns whether the game world has not yet reached its
+// player capacity for a user of the given UserGroup.
+func (game *Game) HasRoomFor(userGroup character.UserGroup) bool {
+	capacity := game.config.MaxPlayers
+	if userGroup.IsStaff() {
+		capacity += game.config.StaffOverflow
+	}
+
+	return game.PlayerCount() < capacity
 }
 
 // RemovePlayer removes the given Player-like entity.
 func (game *Game) RemovePlayer(plr *Player) {
 	game.RemoveEntity(plr.Entity)
+	atomic.AddInt32(&game.playerCount, -1)
 }
 
 // AddNpc attempts to add the given Npc to the game world.
 func (game *Game) AddNpc(npc *Npc) bool {
-	return game.AddEntity(npc.Entity)
+	return game.addNonPlayer(npc.Entity)
 }
 
 // RemoveNpc removes the given Npc-like entity.
 func (game *Game) RemoveNpc(npc *Npc) {
-	game.RemoveEntity(npc.Entity)
+	game.removeNonPlayer(npc.Entity)
+}
+
+// CreateObject creates a new Object-like Entity.
+func (game *Game) CreateObject(modelID ModelID, position Position) *Object {
+	return ObjectBy(game.entityFactory.CreateObject(position, modelID))
+}
+
+// AddObject attempts to add the given Object to the game world.
+func (game *Game) AddObject(obj *Object) bool {
+	return game.addNonPlayer(obj.Entity)
+}
+
+// RemoveObject removes the given Object-like entity.
+func (game *Game) RemoveObject(obj *Object) {
+	game.removeNonPlayer(obj.Entity)
 }
 
 // AddMonster attempts to add the given Monster to the game world.
 func (game *Game) AddMonster(monster *Monster) bool {
-	return game.AddEntity(monster.Entity)
+	return game.addNonPlayer(monster.Entity)
 }
 
 // RemoveMonster removes the given Monster-like entity.
 func (game *Game) RemoveMonster(monster *Monster) {
-	game.RemoveEntity(monster.Entity)
+	game.removeNonPlayer(monster.Entity)
+}
+
+// addNonPlayer attempts to add the given non-player Entity to the game
+// world. Fails if the game world has reached its entity limit.
+func (game *Game) addNonPlayer(entity *entity.Entity) bool {
+	if game.entityCount >= game.config.EntityLimit {
+		return false
+	}
+
+	if !game.AddEntity(entity) {
+		return false
+	}
+
+	game.entityCount++
+	return true
+}
+
+// removeNonPlayer removes the given non-player Entity from the game world.
+func (game *Game) removeNonPlayer(entity *entity.Entity) {
+	game.RemoveEntity(entity)
+	game.entityCount--
+}
+
+// PlayerByName looks up the Player in the game world that goes by the
+// specified DisplayName, regardless of casing. May return nil.
+func (game *Game) PlayerByName(displayName character.DisplayName) *Player {
+	for _, ent := range game.entities.entities {
+		kind, ok := ent.GetComponent(KindTag).(*KindComponent)
+		if !ok || kind.Kind != PlayerKind {
+			continue
+		}
+
+		plr := PlayerBy(ent)
+		if strings.EqualFold(string(plr.DisplayName()), string(displayName)) {
+			return plr
+		}
+	}
+
+	return nil
+}
+
+// PlayerCount returns the amount of players that are in the game world.
+// Safe to call from outside of the game loop.
+func (game *Game) PlayerCount() int {
+	return int(atomic.LoadInt32(&game.playerCount))
 }
 
 // AddEntity attempts to add the given Entity to the game world.
 func (game *Game) AddEntity(entity *entity.Entity) bool {
-	return game.world.AddEntity(entity)
+	if !game.world.AddEntity(entity) {
+		return false
+	}
+
+	game.entities.Put(entity)
+	return true
 }
 
 // RemoveEntity removes the given Entity from the game world.
 func (game *Game) RemoveEntity(entity *entity.Entity) {
+	game.entities.Remove(entity)
 	game.world.DestroyEntity(entity)
 }
 
@@ -483,10 +788,50 @@ func (service *Service) pulse() {
 		service.logger.Error(err)
 	}
 
+	service.expireLingeringSessions()
+
+	service.admitQueuedLogins()
+	service.updateQueuedLogins()
+
 	service.pulser.resume <- time.Since(service.pulser.lastTime)
 }
 
-// Stop stops this Service and cleans up resources.
+// PlayerCount returns the amount of players that are in the game world.
+func (service *Service) PlayerCount() int {
+	return service.game.PlayerCount()
+}
+
+// MaxPlayers returns the maximum amount of regular players that can be in
+// the game world at once.
+func (service *Service) MaxPlayers() int {
+	return service.config.MaxPlayers
+}
+
+// post posts the given Mail into the mailbox of this Service, unless the
+// Service has been stopped.
+func (service *Service) post(mail client.Mail) {
+	select {
+	case <-service.stopped:
+		return
+
+	default:
+		select {
+		case service.mailbox <- mail:
+		case <-service.stopped:
+		}
+	}
+}
+
+// Stop stops the game pulses, saves the character of every online and
+// lingering player and disconnects every client. Blocks until the
+// characters have been handed over to the CharacterSaver. Calling Stop
+// again, or whilst the Service is still stopping, blocks until the first
+// call has stopped the Service.
 func (service *Service) Stop() {
-	service.pulser.quitPulsing()
+	service.stopOnce.Do(func() {
+		done := make(chan bool)
+		service.post(client.Mail{Payload: stopRequest{done: done}})
+
+		<-done
+	})
 }
@@ -1,8 +1,11 @@
 package game
 
 import (
+	"time"
+
 	"gitlab.com/pokesync/game-service/internal/game-service/account"
 	"gitlab.com/pokesync/game-service/internal/game-service/client"
+	"gitlab.com/pokesync/game-service/internal/game-service/token"
 )
 
 // SessionConfig holds configurations specific to Session's.
@@ -21,6 +24,12 @@ type Session struct {
 	Email  account.Email
 	Player *Player
 
+	// Token is the session token the user can resume this Session with.
+	Token token.Token
+
+	// LoggedInAt is when the user logged in.
+	LoggedInAt time.Time
+
 	commands chan client.Message
 	events   chan client.Message
 }
@@ -28,6 +37,7 @@ type Session struct {
 // SessionRegistry keeps track of Session's.
 type SessionRegistry struct {
 	sessions map[client.ID]*Session
+	accounts map[account.Email]*Session
 }
 
 // NewSession constructs a new instance of a Session.
@@ -40,6 +50,8 @@ func NewSession(cl *client.Client, config SessionConfig, email account.Email, pl
 		Email:  email,
 		Player: player,
 
+		LoggedInAt: time.Now(),
+
 		commands: make(chan client.Message, config.CommandLimit),
 		events:   make(chan client.Message, config.EventLimit),
 	}
@@ -64,12 +76,23 @@ func NewInstalledSession(cl *client.Client, config SessionConfig, email account.
 		GetComponent(PartyBeltTag).(*PartyBeltComponent).PartyBelt.
 		AddListener(&PartyBeltSessionListener{session: session})
 
+	plr.
+		GetComponent(PCStorageTag).(*PCStorageComponent).PCStorage.
+		AddListener(&PCStorageSessionListener{session: session})
+
+	plr.
+		GetComponent(InventoryTag).(*InventoryComponent).Inventory.
+		AddListener(&InventorySessionListener{session: session})
+
 	return session
 }
 
 // NewSessionRegistry constructs a new instance of a SessionRegistry.
 func NewSessionRegistry() *SessionRegistry {
-	return &SessionRegistry{sessions: make(map[client.ID]*Session)}
+	return &SessionRegistry{
+		sessions: make(map[client.ID]*Session),
+		accounts: make(map[account.Email]*Session),
+	}
 }
 
 // DequeueCommand polls a command from the Session's buffer. May return nil
@@ -121,25 +144,67 @@ func (session *Session) QueueEvent(event client.Message) bool {
 }
 
 // Send sends the given client Message directly to the underlying Client
-// instance without any queueing.
+// instance without any queueing. The Message is dropped if the Session
+// is currently detached from its Client.
 func (session *Session) Send(message client.Message) {
+	if session.IsDetached() {
+		return
+	}
+
 	session.client.Send(message)
 }
 
 // Flush performs a flush call to write-and flush all of the queued
 // up events to the socket connection.
 func (session *Session) Flush() {
+	if session.IsDetached() {
+		return
+	}
+
 	session.client.Flush()
 }
 
 // Terminate terminates this Session and the underlying Client instance.
 func (session *Session) Terminate() {
+	if session.IsDetached() {
+		return
+	}
+
 	session.client.Terminate()
 }
 
+// Detach detaches this Session from its underlying Client, which is to
+// happen when the Client's connection has been dropped. Any commands that
+// are still queued up, are discarded.
+func (session *Session) Detach() {
+	session.client = nil
+
+	for session.DequeueCommand() != nil {
+	}
+}
+
+// Attach attaches this Session to the given Client, to continue where the
+// previously attached Client left off.
+func (session *Session) Attach(cl *client.Client) {
+	session.client = cl
+}
+
+// IsDetached returns whether this Session is currently not attached to
+// any Client.
+func (session *Session) IsDetached() bool {
+	return session.client == nil
+}
+
+// Client returns the Client this Session is attached to. May return nil
+// if the Session is currently detached.
+func (session *Session) Client() *client.Client {
+	return session.client
+}
+
 // Put puts the given Session into the registry under the specified client ID.
 func (registry *SessionRegistry) Put(id client.ID, session *Session) {
 	registry.sessions[id] = session
+	registry.accounts[session.Email] = session
 }
 
 // Remove removes any Session that is associated with the specified client ID,
@@ -151,10 +216,42 @@ func (registry *SessionRegistry) Remove(id client.ID) *Session {
 	}
 
 	delete(registry.sessions, id)
+	if registry.accounts[session.Email] == session {
+		delete(registry.accounts, session.Email)
+	}
+
 	return session
 }
 
+// Sessions returns every Session in this registry.
+func (registry *SessionRegistry) Sessions() []*Session {
+	sessions := make([]*Session, 0, len(registry.sessions))
+	for _, session := range registry.sessions {
+		sessions = append(sessions, session)
+	}
+
+	return sessions
+}
+
+// RemoveAll removes and returns every Session from this registry.
+func (registry *SessionRegistry) RemoveAll() []*Session {
+	sessions := make([]*Session, 0, len(registry.sessions))
+	for id, session := range registry.sessions {
+		sessions = append(sessions, session)
+		delete(registry.sessions, id)
+		delete(registry.accounts, session.Email)
+	}
+
+	return sessions
+}
+
 // Get looks up a Session instance by the given client ID. May return nil.
 func (registry *SessionRegistry) Get(id client.ID) *Session {
 	return registry.sessions[id]
 }
+
+// GetByEmail looks up the Session of the account of the specified Email.
+// May return nil.
+func (registry *SessionRegistry) GetByEmail(email account.Email) *Session {
+	return registry.accounts[email]
+}
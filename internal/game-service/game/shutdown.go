@@ -0,0 +1,95 @@
+package game
+
+import (
+	"fmt"
+	"time"
+
+	"gitlab.com/pokesync/game-service/internal/game-service/chat"
+	"gitlab.com/pokesync/game-service/internal/game-service/client"
+)
+
+// countdownMarks are the amounts of time left until the server shuts down,
+// at which online players are notified of the shutdown.
+var countdownMarks = []time.Duration{
+	5 * time.Minute,
+	1 * time.Minute,
+	30 * time.Second,
+	10 * time.Second,
+	5 * time.Second,
+	4 * time.Second,
+	3 * time.Second,
+	2 * time.Second,
+	1 * time.Second,
+}
+
+// shutdownNotice is a request for the game Service to notify every online
+// player of the server shutting down in the given amount of time.
+type shutdownNotice struct {
+	remaining time.Duration
+}
+
+// stopRequest is a request for the game Service to stop. The done channel
+// is closed once the Service has stopped.
+type stopRequest struct {
+	done chan bool
+}
+
+// Countdown notifies every online player of the server shutting down after
+// the specified duration, repeating the notice as the shutdown draws near.
+// Blocks until the duration has elapsed.
+func (service *Service) Countdown(duration time.Duration) {
+	deadline := time.Now().Add(duration)
+
+	service.post(client.Mail{Payload: shutdownNotice{remaining: duration}})
+	for _, mark := range countdownMarks {
+		if mark >= duration {
+			continue
+		}
+
+		time.Sleep(time.Until(deadline.Add(-mark)))
+		service.post(client.Mail{Payload: shutdownNotice{remaining: mark}})
+	}
+
+	time.Sleep(time.Until(deadline))
+}
+
+// onShutdownNotice notifies every online player of the server shutting
+// down in the given amount of time.
+func (service *Service) onShutdownNotice(remaining time.Duration) {
+	notice := &chat.DisplayChatMessage{
+		ChannelId: chat.ServerNoticeChannel,
+		Text:      fmt.Sprintf("The server is shutting down in %v.", remaining),
+	}
+
+	for _, session := range service.sessions.Sessions() {
+		if !session.IsDetached() {
+			session.client.SendNow(notice)
+		}
+	}
+}
+
+// stop stops the game pulses, saves the character of every online and
+// lingering player and disconnects every client. Session tokens are left
+// intact, for users to be able to resume their session once the server
+// is back up.
+func (service *Service) stop() {
+	service.pulser.quitPulsing()
+	close(service.stopped)
+
+	sessions := append(service.sessions.RemoveAll(), service.lingering.RemoveAll()...)
+	for _, session := range sessions {
+		session.Player.ForfeitBattle()
+		service.characterSaver(session.Email, service.transformPlayerToCharacterProfile(session.Player))
+		session.Terminate()
+	}
+
+	for _, entry := range service.lobby.RemoveAll() {
+		entry.Client.Terminate()
+	}
+
+	for login := service.loginQueue.Poll(); login != nil; login = service.loginQueue.Poll() {
+		login.Client.Terminate()
+	}
+
+	service.logger.Infof("Saved %v characters", len(sessions))
+}
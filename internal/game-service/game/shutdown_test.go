@@ -0,0 +1,31 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"gitlab.com/pokesync/game-service/internal/game-service/client"
+)
+
+func TestService_StopTwice(t *testing.T) {
+	service := newLoginTestService()
+	service.pulser = &pulser{quit: make(chan bool), pulses: make(chan pulse)}
+	service.stopped = make(chan bool)
+	service.mailbox = make(client.Mailbox)
+
+	go service.receive()
+
+	stopped := make(chan bool)
+	go func() {
+		service.Stop()
+		service.Stop()
+
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("expected a second call to Stop to return")
+	}
+}
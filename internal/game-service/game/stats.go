@@ -0,0 +1,246 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	HitPoints      Stat = 0
+	Attack         Stat = 1
+	Defence        Stat = 2
+	SpecialAttack  Stat = 3
+	SpecialDefence Stat = 4
+	Speed          Stat = 5
+
+	// StatCount is the amount of distinct Stat's a monster has.
+	StatCount = 6
+)
+
+const (
+	Hardy   Nature = 0
+	Lonely  Nature = 1
+	Brave   Nature = 2
+	Adamant Nature = 3
+	Naughty Nature = 4
+	Bold    Nature = 5
+	Docile  Nature = 6
+	Relaxed Nature = 7
+	Impish  Nature = 8
+	Lax     Nature = 9
+	Timid   Nature = 10
+	Hasty   Nature = 11
+	Serious Nature = 12
+	Jolly   Nature = 13
+	Naive   Nature = 14
+	Modest  Nature = 15
+	Mild    Nature = 16
+	Quiet   Nature = 17
+	Bashful Nature = 18
+	Rash    Nature = 19
+	Calm    Nature = 20
+	Gentle  Nature = 21
+	Sassy   Nature = 22
+	Careful Nature = 23
+	Quirky  Nature = 24
+
+	// NatureCount is the amount of distinct Nature's a monster can have.
+	NatureCount = 25
+)
+
+const (
+	MediumFast  GrowthRate = 0
+	Erratic     GrowthRate = 1
+	Fluctuating GrowthRate = 2
+	MediumSlow  GrowthRate = 3
+	Fast        GrowthRate = 4
+	Slow        GrowthRate = 5
+)
+
+const (
+	// MinLevel is the lowest level a monster can be at.
+	MinLevel = 1
+
+	// MaxLevel is the highest level a monster can reach.
+	MaxLevel = 100
+
+	// MaxIndividualValue is the highest individual value a monster can
+	// have in a single Stat.
+	MaxIndividualValue = 31
+
+	// MaxEffortValue is the highest effort value a monster can gain in
+	// a single Stat.
+	MaxEffortValue = 252
+
+	// MaxTotalEffortValue is the highest amount of effort values a monster
+	// can gain across all of its Stat's.
+	MaxTotalEffortValue = 510
+
+	// MaxHappiness is the highest amount of happiness a monster can have.
+	MaxHappiness = 255
+)
+
+// Stat is a type of statistic of a monster.
+type Stat int
+
+// Stats holds a value for each of the Stat's of a monster.
+type Stats [StatCount]int
+
+// Nature is a monster's nature, which raises one of its Stat's by 10%
+// and lowers another by 10%, unless both are the same Stat.
+type Nature int
+
+// GrowthRate is the rate at which a monster gains levels through
+// experience.
+type GrowthRate int
+
+// natureStats are the Stat's a Nature may raise or lower, in the order in
+// which the natures cycle through them.
+var natureStats = [5]Stat{Attack, Defence, Speed, SpecialAttack, SpecialDefence}
+
+// growthRatesByName maps the names of GrowthRate's in the monster configs
+// to their GrowthRate.
+var growthRatesByName = map[string]GrowthRate{
+	"MEDIUM_FAST": MediumFast,
+	"ERRATIC":     Erratic,
+	"FLUCTUATING": Fluctuating,
+	"MEDIUM_SLOW": MediumSlow,
+	"FAST":        Fast,
+	"SLOW":        Slow,
+}
+
+// Raised returns the Stat that is raised by the Nature.
+func (nature Nature) Raised() Stat {
+	return natureStats[int(nature)/len(natureStats)]
+}
+
+// Lowered returns the Stat that is lowered by the Nature.
+func (nature Nature) Lowered() Stat {
+	return natureStats[int(nature)%len(natureStats)]
+}
+
+// modify applies the Nature's modifier of the specified Stat to the
+// given value.
+func (nature Nature) modify(stat Stat, value int) int {
+	raised, lowered := nature.Raised(), nature.Lowered()
+	switch {
+	case raised == lowered:
+		return value
+	case stat == raised:
+		return value * 110 / 100
+	case stat == lowered:
+		return value * 90 / 100
+	default:
+		return value
+	}
+}
+
+// UnmarshalJSON decodes a GrowthRate from its name. A missing or null
+// GrowthRate decodes as MediumFast, which most monsters grow at.
+func (rate *GrowthRate) UnmarshalJSON(data []byte) error {
+	var name *string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return err
+	}
+
+	if name == nil {
+		*rate = MediumFast
+		return nil
+	}
+
+	growthRate, ok := growthRatesByName[*name]
+	if !ok {
+		return fmt.Errorf("unknown growth rate %q", *name)
+	}
+
+	*rate = growthRate
+	return nil
+}
+
+// ExperienceAt returns the total amount of experience a monster growing
+// at this GrowthRate requires to reach the specified level.
+func (rate GrowthRate) ExperienceAt(level int) int {
+	if level <= MinLevel {
+		return 0
+	}
+
+	if level > MaxLevel {
+		level = MaxLevel
+	}
+
+	n := level
+	cube := n * n * n
+
+	switch rate {
+	case Erratic:
+		switch {
+		case n < 50:
+			return cube * (100 - n) / 50
+		case n < 68:
+			return cube * (150 - n) / 100
+		case n < 98:
+			return cube * ((1911 - 10*n) / 3) / 500
+		default:
+			return cube * (160 - n) / 100
+		}
+
+	case Fluctuating:
+		switch {
+		case n < 15:
+			return cube * ((n+1)/3 + 24) / 50
+		case n < 36:
+			return cube * (n + 14) / 50
+		default:
+			return cube * (n/2 + 32) / 50
+		}
+
+	case MediumSlow:
+		return 6*cube/5 - 15*n*n + 100*n - 140
+
+	case Fast:
+		return 4 * cube / 5
+
+	case Slow:
+		return 5 * cube / 4
+
+	default:
+		return cube
+	}
+}
+
+// LevelAt returns the level a monster growing at this GrowthRate is at
+// with the given total amount of experience.
+func (rate GrowthRate) LevelAt(experience int) int {
+	level := MinLevel
+	for level < MaxLevel && experience >= rate.ExperienceAt(level+1) {
+		level++
+	}
+
+	return level
+}
+
+// CalculateStats calculates the Stat's of a monster at the specified level,
+// with the given base stats, individual and effort values and Nature.
+func CalculateStats(base Stats, level int, individualValues, effortValues Stats, nature Nature) Stats {
+	var stats Stats
+	for stat := range stats {
+		value := (2*base[stat] + individualValues[stat] + effortValues[stat]/4) * level / 100
+		if Stat(stat) == HitPoints {
+			stats[stat] = value + level + 10
+		} else {
+			stats[stat] = nature.modify(Stat(stat), value+5)
+		}
+	}
+
+	return stats
+}
+
+// Total returns the sum of the values of every Stat.
+func (stats Stats) Total() int {
+	total := 0
+	for _, value := range stats {
+		total += value
+	}
+
+	return total
+}
@@ -0,0 +1,72 @@
+package game
+
+import "testing"
+
+func TestGrowthRate_ExperienceAt(t *testing.T) {
+	experience := map[GrowthRate]int{
+		Erratic:     600000,
+		Fast:        800000,
+		MediumFast:  1000000,
+		MediumSlow:  1059860,
+		Slow:        1250000,
+		Fluctuating: 1640000,
+	}
+
+	for rate, expected := range experience {
+		if actual := rate.ExperienceAt(MaxLevel); actual != expected {
+			t.Errorf("expected growth rate %v to require %v experience but was %v instead", rate, expected, actual)
+		}
+
+		if level := rate.LevelAt(expected); level != MaxLevel {
+			t.Errorf("expected growth rate %v to be at level %v but was %v instead", rate, MaxLevel, level)
+		}
+
+		if level := rate.LevelAt(0); level != MinLevel {
+			t.Errorf("expected growth rate %v to be at level %v but was %v instead", rate, MinLevel, level)
+		}
+	}
+}
+
+func TestCalculateStats(t *testing.T) {
+	base := Stats{108, 130, 95, 80, 85, 102}
+	individualValues := Stats{24, 12, 30, 16, 23, 5}
+	effortValues := Stats{74, 190, 91, 48, 84, 23}
+
+	stats := CalculateStats(base, 78, individualValues, effortValues, Adamant)
+
+	expected := Stats{289, 278, 193, 135, 171, 171}
+	if stats != expected {
+		t.Errorf("expected stats %v but was %v instead", expected, stats)
+	}
+}
+
+func TestMonsterData_GainExperience(t *testing.T) {
+	descriptor := &MonsterDescriptor{ID: 1, BaseStats: [7]int{45, 49, 49, 65, 65, 45}, GrowthRate: MediumSlow}
+
+	monster := NewMonsterData(descriptor, &MoveConfig{}, 5)
+	monster.HitPoints--
+
+	levelUp := monster.GainExperience(descriptor, &MoveConfig{}, MediumSlow.ExperienceAt(7)-monster.Experience)
+	if levelUp.LevelsGained != 2 || monster.Level != 7 {
+		t.Fatalf("expected to gain %v levels up to level %v but gained %v up to %v", 2, 7, levelUp.LevelsGained, monster.Level)
+	}
+
+	if maxHitPoints := monster.Stats(descriptor)[HitPoints]; monster.HitPoints != maxHitPoints-1 {
+		t.Errorf("expected %v hit points but was %v instead", maxHitPoints-1, monster.HitPoints)
+	}
+}
+
+func TestMonsterData_GainEffortValues(t *testing.T) {
+	monster := MonsterData{}
+
+	monster.GainEffortValues(Stats{300, 0, 0, 0, 0, 0})
+	monster.GainEffortValues(Stats{0, 252, 100, 0, 0, 0})
+
+	if monster.EffortValues[HitPoints] != MaxEffortValue {
+		t.Errorf("expected %v effort values but was %v instead", MaxEffortValue, monster.EffortValues[HitPoints])
+	}
+
+	if total := monster.EffortValues.Total(); total != MaxTotalEffortValue {
+		t.Errorf("expected %v effort values in total but was %v instead", MaxTotalEffortValue, total)
+	}
+}
@@ -47,11 +47,11 @@ func NewClock(seconds int) *Clock {
 }
 
 // NewDayNightSystem constructs a System that processes the transitions
-// between day-and night. The system processes time four times as fast
-// as real-time does. This means that there are four transitions between
-// day and night, a day.
-func NewDayNightSystem(clockRate time.Duration, synchronizer ClockSynchronizer) *entity.System {
-	return entity.NewSystem(entity.NewIntervalPolicy(clockRate), NewDayNightProcessor(synchronizer))
+// between day-and night with the given DayNightProcessor. The system
+// processes time four times as fast as real-time does. This means that
+// there are four transitions between day and night, a day.
+func NewDayNightSystem(clockRate time.Duration, processor *DayNightProcessor) *entity.System {
+	return entity.NewSystem(entity.NewIntervalPolicy(clockRate), processor)
 }
 
 // NewDayNightProcessor processes the day-and night transitions.
@@ -92,6 +92,11 @@ func (clock Clock) CurrentMinute() int {
 	return (clock.seconds / SecondsInAMinute) % SecondsInAMinute
 }
 
+// TimeOfDay returns the current TimeOfDay.
+func (clock Clock) TimeOfDay() TimeOfDay {
+	return TimeOfDayAt(clock.CurrentHour())
+}
+
 // NewGMT0Synchronizer constructs a new ClockSynchronizer that synchronizes
 // with the GMT+0 timezone.
 func NewGMT0Synchronizer() ClockSynchronizer {
@@ -112,7 +117,17 @@ func (gmt *GMT0Synchronizer) Synchronize() (*Clock, error) {
 	minutes := t.Minute()
 	seconds := t.Second()
 
-	return NewClock((hours * MinutesInAnHour * SecondsInADay) + (minutes * SecondsInAMinute) + seconds), nil
+	return NewClock((hours * MinutesInAnHour * SecondsInAMinute) + (minutes * SecondsInAMinute) + seconds), nil
+}
+
+// TimeOfDay returns the current TimeOfDay on the clock of the game.
+// Returns Day if the clock has yet to be synchronized.
+func (processor *DayNightProcessor) TimeOfDay() TimeOfDay {
+	if processor.clock == nil {
+		return Day
+	}
+
+	return processor.clock.TimeOfDay()
 }
 
 // AddedToWorld is called when the System of this Processor is added
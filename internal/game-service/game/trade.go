@@ -0,0 +1,511 @@
+package game
+
+import (
+	"errors"
+
+	"gitlab.com/pokesync/game-service/internal/game-service/game/entity"
+	"gitlab.com/pokesync/game-service/internal/game-service/game/transport"
+	"go.uber.org/zap"
+)
+
+// TradeOption is the slot of the player option menu through which a Player
+// requests to trade with another player.
+const TradeOption = 0
+
+// These are the stages a TradeSession goes through. Both players have to
+// accept the trade in the OfferStage, after which they have to confirm it
+// in the ConfirmStage for the trade to be completed.
+const (
+	OfferStage   TradeStage = 0
+	ConfirmStage TradeStage = 1
+)
+
+var (
+	// ErrInTrade is returned when a Player attempts to do something it
+	// can not do whilst in a trade, such as moving around.
+	ErrInTrade = errors.New("player is in a trade")
+
+	// ErrNotInTrade is returned when a Player attempts to perform a trade
+	// action whilst not in a trade.
+	ErrNotInTrade = errors.New("player is not in a trade")
+
+	// ErrTradePartnerBusy is returned when a Player requests to trade with
+	// a player that is either battling or already trading.
+	ErrTradePartnerBusy = errors.New("trade partner is busy")
+
+	// ErrMonsterAlreadyOffered is returned when a Player offers a monster
+	// it has already offered.
+	ErrMonsterAlreadyOffered = errors.New("monster is already offered")
+
+	// ErrTradeOfferSlotEmpty is returned when a Player retracts a monster
+	// from a slot of its offer that holds no monster.
+	ErrTradeOfferSlotEmpty = errors.New("trade offer slot is empty")
+
+	// ErrInsufficientPokeDollars is returned when a Player offers more
+	// pokedollars than it has.
+	ErrInsufficientPokeDollars = errors.New("not enough pokedollars")
+
+	// ErrMonsterNoLongerOwned is returned when a trade is completed for a
+	// monster that is no longer in the party of the Player that offered it.
+	ErrMonsterNoLongerOwned = errors.New("offered monster is no longer owned")
+)
+
+// TradeStage is a stage of a TradeSession.
+type TradeStage int
+
+// TradeOffer is what a Player offers in a TradeSession.
+type TradeOffer struct {
+	Monsters []*Monster
+	Dollars  int
+	Accepted bool
+}
+
+// TradeSession is a trade between two players. Any change made to either
+// offer withdraws the acceptance of both players and sends the trade back
+// to the OfferStage, so that a player never confirms a trade other than
+// the one it was shown.
+type TradeSession struct {
+	trades  *Trades
+	traders [2]*Player
+	offers  [2]*TradeOffer
+	stage   TradeStage
+}
+
+// Trades sets up trades between players and carries them out.
+type Trades struct {
+	factory     *EntityFactory
+	evolutions  *Evolutions
+	snapshotter ProfileSnapshotter
+	saver       CharacterSaver
+	logger      *zap.SugaredLogger
+}
+
+// NewTrades constructs a new instance of Trades. The characters of both
+// players are saved through the given CharacterSaver as soon as a trade
+// is completed.
+func NewTrades(factory *EntityFactory, evolutions *Evolutions, snapshotter ProfileSnapshotter, saver CharacterSaver, logger *zap.SugaredLogger) *Trades {
+	return &Trades{
+		factory:     factory,
+		evolutions:  evolutions,
+		snapshotter: snapshotter,
+		saver:       saver,
+		logger:      logger,
+	}
+}
+
+// Request has the Player request to trade with the given partner. If the
+// partner already requested to trade with the Player, the trade is opened
+// right away. Otherwise the partner is asked to trade with the Player.
+func (trades *Trades) Request(plr *Player, partner *Player) error {
+	if plr.Entity == partner.Entity {
+		return nil
+	}
+
+	if plr.InBattle() {
+		return ErrInBattle
+	}
+
+	if plr.InTrade() {
+		return ErrInTrade
+	}
+
+	if partner.InBattle() || partner.InTrade() {
+		return ErrTradePartnerBusy
+	}
+
+	if !plr.WithinReach(partner.Position()) {
+		return ErrOutOfReach
+	}
+
+	if requested := partner.GetComponent(TradeTag).(*TradeComponent).Requested; requested != nil && requested.Entity == plr.Entity {
+		trades.open(partner, plr)
+		return nil
+	}
+
+	plr.GetComponent(TradeTag).(*TradeComponent).Requested = partner
+	partner.QueueEvent(&transport.TradeRequest{PID: uint16(plr.ID)})
+
+	return nil
+}
+
+// open opens a TradeSession between the two given players.
+func (trades *Trades) open(first, second *Player) *TradeSession {
+	session := &TradeSession{
+		trades:  trades,
+		traders: [2]*Player{first, second},
+		offers:  [2]*TradeOffer{{}, {}},
+	}
+
+	for side, trader := range session.traders {
+		component := trader.GetComponent(TradeTag).(*TradeComponent)
+		component.Session = session
+		component.Requested = nil
+
+		trader.QueueEvent(&transport.OpenTrade{PID: uint16(session.partnerOf(side).ID)})
+	}
+
+	return session
+}
+
+// save saves the character of the given Player. The snapshot is taken on
+// the game goroutine but saved on a separate one, to not hold up the game.
+func (trades *Trades) save(plr *Player) {
+	component, ok := plr.GetComponent(SessionTag).(*SessionComponent)
+	if !ok {
+		return
+	}
+
+	email, profile := component.session.Email, trades.snapshotter(plr)
+	go trades.saver(email, profile)
+}
+
+// OfferMonster adds the monster in the specified slot of the Player's
+// PartyBelt to the Player's offer.
+func (session *TradeSession) OfferMonster(plr *Player, partySlot int) error {
+	side := session.sideOf(plr)
+
+	monster, err := plr.PartyBelt().Get(partySlot)
+	if err != nil {
+		return err
+	}
+
+	offer := session.offers[side]
+	for _, offered := range offer.Monsters {
+		if offered == monster {
+			return ErrMonsterAlreadyOffered
+		}
+	}
+
+	offer.Monsters = append(offer.Monsters, monster)
+	session.changed(side)
+
+	return nil
+}
+
+// RetractOffer removes the monster in the specified slot of the Player's
+// offer.
+func (session *TradeSession) RetractOffer(plr *Player, offerSlot int) error {
+	side := session.sideOf(plr)
+
+	offer := session.offers[side]
+	if offerSlot < 0 || offerSlot >= len(offer.Monsters) {
+		return ErrTradeOfferSlotEmpty
+	}
+
+	offer.Monsters = append(offer.Monsters[:offerSlot], offer.Monsters[offerSlot+1:]...)
+	session.changed(side)
+
+	return nil
+}
+
+// OfferDollars sets the amount of pokedollars the Player offers.
+func (session *TradeSession) OfferDollars(plr *Player, amount int) error {
+	side := session.sideOf(plr)
+
+	if amount < 0 || amount > plr.CoinBag().Dollars {
+		return ErrInsufficientPokeDollars
+	}
+
+	session.offers[side].Dollars = amount
+	session.changed(side)
+
+	return nil
+}
+
+// Accept has the Player accept the trade as it currently stands. Once
+// both players have accepted in the OfferStage, the trade moves on to the
+// ConfirmStage. Once both players have accepted in the ConfirmStage, the
+// trade is completed.
+func (session *TradeSession) Accept(plr *Player) error {
+	side := session.sideOf(plr)
+
+	session.offers[side].Accepted = true
+	if !session.offers[1-side].Accepted {
+		session.syncStage()
+		return nil
+	}
+
+	if session.stage == ConfirmStage {
+		return session.complete()
+	}
+
+	session.resetAcceptance()
+	if err := session.validate(); err != nil {
+		session.syncStage()
+		return err
+	}
+
+	session.stage = ConfirmStage
+	session.syncStage()
+
+	return nil
+}
+
+// Decline ends the trade without anything changing hands.
+func (session *TradeSession) Decline(plr *Player) {
+	session.close(false)
+}
+
+// sideOf returns the index of the given Player's side of the trade.
+func (session *TradeSession) sideOf(plr *Player) int {
+	if session.traders[0].Entity == plr.Entity {
+		return 0
+	}
+
+	return 1
+}
+
+// partnerOf returns the trader on the other side of the trade.
+func (session *TradeSession) partnerOf(side int) *Player {
+	return session.traders[1-side]
+}
+
+// changed withdraws the acceptance of both players and sends the trade
+// back to the OfferStage, after the offer of the given side has changed.
+func (session *TradeSession) changed(side int) {
+	session.stage = OfferStage
+	session.resetAcceptance()
+
+	offer := session.offers[side]
+	message := &transport.SetTradeOffer{Dollars: uint32(offer.Dollars)}
+	for _, monster := range offer.Monsters {
+		message.Monsters = append(message.Monsters, transport.TradeMonster{
+			MonsterID:  uint16(monster.ModelID),
+			Gender:     byte(monster.Gender),
+			Coloration: byte(monster.Coloration),
+			Level:      byte(monster.Level),
+		})
+	}
+
+	session.traders[side].QueueEvent(message)
+
+	forPartner := *message
+	forPartner.Partner = true
+	session.partnerOf(side).QueueEvent(&forPartner)
+
+	session.syncStage()
+}
+
+// resetAcceptance withdraws the acceptance of both players.
+func (session *TradeSession) resetAcceptance() {
+	for _, offer := range session.offers {
+		offer.Accepted = false
+	}
+}
+
+// syncStage lets both players know of the stage the trade is in and of
+// who has accepted it.
+func (session *TradeSession) syncStage() {
+	for side, trader := range session.traders {
+		trader.QueueEvent(&transport.SetTradeStage{
+			Stage:           byte(session.stage),
+			Accepted:        session.offers[side].Accepted,
+			PartnerAccepted: session.offers[1-side].Accepted,
+		})
+	}
+}
+
+// validate checks that both players are still able to give what they
+// offered and to receive what they are offered.
+func (session *TradeSession) validate() error {
+	for side, trader := range session.traders {
+		if trader.InBattle() {
+			return ErrInBattle
+		}
+
+		if !trader.WithinReach(session.partnerOf(side).Position()) {
+			return ErrOutOfReach
+		}
+
+		offer, received := session.offers[side], session.offers[1-side]
+		if offer.Dollars < 0 || offer.Dollars > trader.CoinBag().Dollars {
+			return ErrInsufficientPokeDollars
+		}
+
+		belt := trader.PartyBelt()
+		for _, monster := range offer.Monsters {
+			if belt.SlotOf(monster) < 0 {
+				return ErrMonsterNoLongerOwned
+			}
+		}
+
+		size := belt.Size - len(offer.Monsters) + len(received.Monsters)
+		if size < 1 {
+			return ErrLastPartyMonster
+		}
+
+		if size > PartyBeltCapacity {
+			return ErrPartyFull
+		}
+	}
+
+	return nil
+}
+
+// complete carries out the trade, after checking one last time that it
+// can be carried out as a whole. What each player receives is prepared
+// before either player is changed, and nothing can fail once it is, so
+// that a trade is never left half carried out. The trade is declined if
+// it can no longer be carried out.
+func (session *TradeSession) complete() error {
+	if err := session.validate(); err != nil {
+		session.close(false)
+		return err
+	}
+
+	trades := session.trades
+
+	var given [2][]MonsterData
+	var received [2][]*Monster
+	for side := range session.traders {
+		partner := session.partnerOf(side)
+		for _, monster := range session.offers[side].Monsters {
+			given[side] = append(given[side], *monster.MonsterData)
+
+			data := *monster.MonsterData
+			trades.evolutions.EvolveByTrade(&data)
+
+			received[1-side] = append(received[1-side], MonsterBy(trades.factory.CreateMonster(partner.Position(), &data), data))
+		}
+	}
+
+	for side, trader := range session.traders {
+		belt := trader.PartyBelt()
+		for _, monster := range session.offers[side].Monsters {
+			belt.Remove(monster)
+		}
+
+		for _, monster := range received[side] {
+			belt.Add(monster)
+		}
+
+		trader.CoinBag().exchangePokeDollars(session.offers[side].Dollars, session.offers[1-side].Dollars)
+	}
+
+	session.close(true)
+
+	first, second := session.traders[0], session.traders[1]
+	trades.logger.Infow("trade completed",
+		"first", first.DisplayName(),
+		"firstGaveDollars", session.offers[0].Dollars,
+		"firstGaveMonsters", given[0],
+		"second", second.DisplayName(),
+		"secondGaveDollars", session.offers[1].Dollars,
+		"secondGaveMonsters", given[1],
+	)
+
+	trades.save(first)
+	trades.save(second)
+
+	return nil
+}
+
+// close releases both players from the trade and lets them know whether
+// it was completed.
+func (session *TradeSession) close(completed bool) {
+	for _, trader := range session.traders {
+		trader.GetComponent(TradeTag).(*TradeComponent).Session = nil
+		trader.QueueEvent(&transport.CloseTrade{Completed: completed})
+	}
+}
+
+// Trade returns the TradeSession the Player is in. May return nil if the
+// Player is not in a trade.
+func (plr *Player) Trade() *TradeSession {
+	return plr.GetComponent(TradeTag).(*TradeComponent).Session
+}
+
+// InTrade returns whether the Player is in a TradeSession.
+func (plr *Player) InTrade() bool {
+	return plr.Trade() != nil
+}
+
+// DeclineTrade declines the trade the Player is in, if any, and withdraws
+// any request the Player made to trade.
+func (plr *Player) DeclineTrade() {
+	component := plr.GetComponent(TradeTag).(*TradeComponent)
+	component.Requested = nil
+
+	if session := component.Session; session != nil {
+		session.Decline(plr)
+	}
+}
+
+// selectPlayerOption is a selectPlayerOptionHandler that has the Player request
+// to trade with the selected player, if the TradeOption was selected.
+func selectPlayerOption(entities *EntityIndex, trades *Trades) selectPlayerOptionHandler {
+	return func(plr *Player, entityID entity.ID, slot int) error {
+		target := entities.Get(entityID)
+		if target == nil {
+			return nil
+		}
+
+		kind, ok := target.GetComponent(KindTag).(*KindComponent)
+		if !ok || kind.Kind != PlayerKind {
+			return nil
+		}
+
+		switch slot {
+		case TradeOption:
+			return trades.Request(plr, PlayerBy(target))
+		default:
+			return nil
+		}
+	}
+}
+
+func offerTradeMonster() offerTradeMonsterHandler {
+	return func(plr *Player, partySlot int) error {
+		session := plr.Trade()
+		if session == nil {
+			return ErrNotInTrade
+		}
+
+		return session.OfferMonster(plr, partySlot)
+	}
+}
+
+func retractTradeOffer() retractTradeOfferHandler {
+	return func(plr *Player, offerSlot int) error {
+		session := plr.Trade()
+		if session == nil {
+			return ErrNotInTrade
+		}
+
+		return session.RetractOffer(plr, offerSlot)
+	}
+}
+
+func offerTradeDollars() offerTradeDollarsHandler {
+	return func(plr *Player, amount int) error {
+		session := plr.Trade()
+		if session == nil {
+			return ErrNotInTrade
+		}
+
+		return session.OfferDollars(plr, amount)
+	}
+}
+
+func acceptTrade() acceptTradeHandler {
+	return func(plr *Player) error {
+		session := plr.Trade()
+		if session == nil {
+			return ErrNotInTrade
+		}
+
+		return session.Accept(plr)
+	}
+}
+
+func declineTrade() declineTradeHandler {
+	return func(plr *Player) error {
+		session := plr.Trade()
+		if session == nil {
+			return ErrNotInTrade
+		}
+
+		session.Decline(plr)
+		return nil
+	}
+}
@@ -0,0 +1,189 @@
+package game
+
+import (
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+const (
+	kadabra  ModelID = 0
+	alakazam ModelID = 1
+	rattata  ModelID = 2
+)
+
+func newTradeTestSetup() (*Trades, *Player, *Player) {
+	assets := &AssetBundle{
+		Monsters: &MonsterConfig{Descriptors: []MonsterDescriptor{
+			{ID: int(kadabra), Name: "KADABRA", BaseStats: [7]int{40, 35, 30, 120, 70, 105}, Evolutions: []Evolution{
+				{Into: alakazam, EvolutionMethod: EvolutionMethod{Trigger: TradeTrigger}},
+			}},
+			{ID: int(alakazam), Name: "ALAKAZAM", BaseStats: [7]int{55, 50, 45, 135, 95, 120}},
+			{ID: int(rattata), Name: "RATTATA", BaseStats: [7]int{30, 56, 35, 25, 35, 72}},
+		}},
+		Moves: &MoveConfig{},
+	}
+
+	fixture := newTestFixture(assets, 8)
+	evolutions := NewEvolutions(assets.Monsters, NewDayNightProcessor(nil))
+
+	first := fixture.newPlayer("Sino", Position{})
+	fixture.fillParty(first, 30, kadabra, rattata)
+
+	second := fixture.newPlayer("Ash", Position{LocalX: 1})
+	fixture.fillParty(second, 30, rattata)
+
+	second.CoinBag().AddPokeDollars(500)
+
+	return NewTrades(fixture.factory, evolutions, nil, nil, zap.NewNop().Sugar()), first, second
+}
+
+func openTestTrade(t *testing.T, trades *Trades, first, second *Player) *TradeSession {
+	if err := trades.Request(first, second); err != nil {
+		t.Fatal(err)
+	}
+
+	if first.InTrade() {
+		t.Fatal("expected the trade to not open before the partner agrees")
+	}
+
+	if err := trades.Request(second, first); err != nil {
+		t.Fatal(err)
+	}
+
+	if !first.InTrade() || !second.InTrade() {
+		t.Fatal("expected both players to be trading")
+	}
+
+	return first.Trade()
+}
+
+func TestTrades_Complete(t *testing.T) {
+	trades, first, second := newTradeTestSetup()
+	session := openTestTrade(t, trades, first, second)
+
+	if err := session.OfferMonster(first, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := session.OfferDollars(second, 300); err != nil {
+		t.Fatal(err)
+	}
+
+	session.Accept(first)
+	if err := session.Accept(second); err != nil {
+		t.Fatal(err)
+	}
+
+	if session.stage != ConfirmStage || !first.InTrade() {
+		t.Fatal("expected the trade to be awaiting confirmation")
+	}
+
+	session.Accept(first)
+	if err := session.Accept(second); err != nil {
+		t.Fatal(err)
+	}
+
+	if first.InTrade() || second.InTrade() {
+		t.Fatal("expected the trade to have been completed")
+	}
+
+	if first.PartyBelt().Size != 1 || second.PartyBelt().Size != 2 {
+		t.Errorf("expected party sizes of 1 and 2 but were %v and %v", first.PartyBelt().Size, second.PartyBelt().Size)
+	}
+
+	received, _ := second.PartyBelt().Get(1)
+	if received.ModelID != alakazam {
+		t.Errorf("expected the kadabra to have evolved into an alakazam by being traded but was %+v", received.MonsterData)
+	}
+
+	if first.CoinBag().Dollars != 300 || second.CoinBag().Dollars != 200 {
+		t.Errorf("expected 300 and 200 pokedollars but were %v and %v", first.CoinBag().Dollars, second.CoinBag().Dollars)
+	}
+}
+
+func TestTradeSession_ChangeResetsAcceptance(t *testing.T) {
+	trades, first, second := newTradeTestSetup()
+	session := openTestTrade(t, trades, first, second)
+
+	session.OfferMonster(first, 1)
+	session.Accept(first)
+	session.Accept(second)
+
+	if session.stage != ConfirmStage {
+		t.Fatal("expected the trade to be awaiting confirmation")
+	}
+
+	session.Accept(first)
+	if err := session.OfferDollars(second, 100); err != nil {
+		t.Fatal(err)
+	}
+
+	if session.stage != OfferStage || session.offers[0].Accepted {
+		t.Error("expected the change to have withdrawn acceptance and sent the trade back to the offer stage")
+	}
+
+	if err := session.RetractOffer(first, 1); err != ErrTradeOfferSlotEmpty {
+		t.Errorf("expected %v but was %v", ErrTradeOfferSlotEmpty, err)
+	}
+
+	if err := session.OfferMonster(first, 1); err != ErrMonsterAlreadyOffered {
+		t.Errorf("expected %v but was %v", ErrMonsterAlreadyOffered, err)
+	}
+
+	if err := session.OfferDollars(second, 501); err != ErrInsufficientPokeDollars {
+		t.Errorf("expected %v but was %v", ErrInsufficientPokeDollars, err)
+	}
+}
+
+func TestTradeSession_RequiresMonsterInParty(t *testing.T) {
+	trades, first, second := newTradeTestSetup()
+	session := openTestTrade(t, trades, first, second)
+
+	session.OfferMonster(second, 0)
+	session.Accept(first)
+	if err := session.Accept(second); err != ErrLastPartyMonster {
+		t.Fatalf("expected %v but was %v", ErrLastPartyMonster, err)
+	}
+
+	session.OfferMonster(first, 0)
+	session.Accept(first)
+	session.Accept(second)
+
+	// the offered monster leaves the party after the offer was accepted
+	offered, _ := first.PartyBelt().Clear(0)
+
+	session.Accept(first)
+	if err := session.Accept(second); err != ErrMonsterNoLongerOwned {
+		t.Fatalf("expected %v but was %v", ErrMonsterNoLongerOwned, err)
+	}
+
+	if first.InTrade() || second.InTrade() {
+		t.Error("expected the trade to have been declined")
+	}
+
+	if second.PartyBelt().Size != 1 || second.PartyBelt().SlotOf(offered) >= 0 {
+		t.Error("expected nothing to have changed hands")
+	}
+}
+
+func TestTrades_Request(t *testing.T) {
+	trades, first, second := newTradeTestSetup()
+
+	second.GetComponent(TransformTag).(*TransformComponent).MovementQueue.Position = Position{LocalX: 5}
+	if err := trades.Request(first, second); err != ErrOutOfReach {
+		t.Errorf("expected %v but was %v", ErrOutOfReach, err)
+	}
+
+	second.GetComponent(TransformTag).(*TransformComponent).MovementQueue.Position = Position{LocalX: 1}
+	openTestTrade(t, trades, first, second)
+
+	if err := moveAvatar()(first, North); err != ErrInTrade {
+		t.Errorf("expected %v but was %v", ErrInTrade, err)
+	}
+
+	second.DeclineTrade()
+	if first.InTrade() || second.InTrade() {
+		t.Error("expected the trade to have been declined")
+	}
+}
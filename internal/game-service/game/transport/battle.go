@@ -0,0 +1,278 @@
+package transport
+
+import (
+	"gitlab.com/pokesync/game-service/internal/game-service/client"
+	"gitlab.com/pokesync/game-service/pkg/bytes"
+)
+
+var (
+	ChooseBattleMoveConfig = client.MessageConfig{
+		Kind:  client.ChooseBattleMove,
+		Topic: "choose_battle_move",
+		New:   func() client.Message { return &ChooseBattleMove{} },
+	}
+
+	SwitchBattleMonsterConfig = client.MessageConfig{
+		Kind:  client.SwitchBattleMon,
+		Topic: "switch_battle_monster",
+		New:   func() client.Message { return &SwitchBattleMonster{} },
+	}
+
+	UseBattleItemConfig = client.MessageConfig{
+		Kind:  client.UseBattleItem,
+		Topic: "use_battle_item",
+		New:   func() client.Message { return &UseBattleItem{} },
+	}
+
+	FleeBattleConfig = client.MessageConfig{
+		Kind:  client.FleeBattle,
+		Topic: "flee_battle",
+		New:   func() client.Message { return &FleeBattle{} },
+	}
+
+	StartBattleConfig = client.MessageConfig{
+		Kind:  client.StartBattle,
+		Topic: "start_battle",
+		New:   func() client.Message { return &StartBattle{} },
+	}
+
+	BattleTurnResultConfig = client.MessageConfig{
+		Kind:  client.BattleTurnResult,
+		Topic: "battle_turn_result",
+		New:   func() client.Message { return &BattleTurnResult{} },
+	}
+
+	EndBattleConfig = client.MessageConfig{
+		Kind:  client.EndBattle,
+		Topic: "end_battle",
+		New:   func() client.Message { return &EndBattle{} },
+	}
+)
+
+type ChooseBattleMove struct {
+	Slot byte
+}
+
+type SwitchBattleMonster struct {
+	PartySlot byte
+}
+
+type UseBattleItem struct {
+	ItemID uint16
+}
+
+type FleeBattle struct{}
+
+type StartBattle struct {
+	Kind            byte
+	ActivePartySlot byte
+
+	OpponentMonsterID       uint16
+	OpponentLevel           byte
+	OpponentGender          byte
+	OpponentColoration      byte
+	OpponentStatusCondition byte
+	OpponentHitPoints       uint16
+	OpponentMaxHitPoints    uint16
+}
+
+type BattleTurnResult struct {
+	Events      []BattleEvent
+	MustReplace bool
+}
+
+type BattleEvent struct {
+	Kind          byte
+	Side          byte
+	Slot          byte
+	MoveID        uint16
+	Amount        uint32
+	Effectiveness byte
+	Critical      bool
+	Status        byte
+	Stat          byte
+}
+
+type EndBattle struct {
+	Outcome byte
+}
+
+func (message *ChooseBattleMove) Demarshal(packet *client.Packet) {
+	itr := packet.Bytes.Iterator()
+
+	message.Slot, _ = itr.ReadByte()
+}
+
+func (message *ChooseBattleMove) Marshal() *bytes.String {
+	bldr := bytes.NewDefaultBuilder()
+
+	bldr.WriteByte(message.Slot)
+
+	return bldr.Build()
+}
+
+func (message *ChooseBattleMove) GetConfig() client.MessageConfig {
+	return ChooseBattleMoveConfig
+}
+
+func (message *SwitchBattleMonster) Demarshal(packet *client.Packet) {
+	itr := packet.Bytes.Iterator()
+
+	message.PartySlot, _ = itr.ReadByte()
+}
+
+func (message *SwitchBattleMonster) Marshal() *bytes.String {
+	bldr := bytes.NewDefaultBuilder()
+
+	bldr.WriteByte(message.PartySlot)
+
+	return bldr.Build()
+}
+
+func (message *SwitchBattleMonster) GetConfig() client.MessageConfig {
+	return SwitchBattleMonsterConfig
+}
+
+func (message *UseBattleItem) Demarshal(packet *client.Packet) {
+	itr := packet.Bytes.Iterator()
+
+	message.ItemID, _ = itr.ReadUInt16()
+}
+
+func (message *UseBattleItem) Marshal() *bytes.String {
+	bldr := bytes.NewDefaultBuilder()
+
+	bldr.WriteInt16(int16(message.ItemID))
+
+	return bldr.Build()
+}
+
+func (message *UseBattleItem) GetConfig() client.MessageConfig {
+	return UseBattleItemConfig
+}
+
+func (message *FleeBattle) Demarshal(packet *client.Packet) {
+}
+
+func (message *FleeBattle) Marshal() *bytes.String {
+	return bytes.EmptyString()
+}
+
+func (message *FleeBattle) GetConfig() client.MessageConfig {
+	return FleeBattleConfig
+}
+
+func (message *StartBattle) Demarshal(packet *client.Packet) {
+	itr := packet.Bytes.Iterator()
+
+	message.Kind, _ = itr.ReadByte()
+	message.ActivePartySlot, _ = itr.ReadByte()
+
+	message.OpponentMonsterID, _ = itr.ReadUInt16()
+	message.OpponentLevel, _ = itr.ReadByte()
+	message.OpponentGender, _ = itr.ReadByte()
+	message.OpponentColoration, _ = itr.ReadByte()
+	message.OpponentStatusCondition, _ = itr.ReadByte()
+	message.OpponentHitPoints, _ = itr.ReadUInt16()
+	message.OpponentMaxHitPoints, _ = itr.ReadUInt16()
+}
+
+func (message *StartBattle) Marshal() *bytes.String {
+	bldr := bytes.NewDefaultBuilder()
+
+	bldr.
+		WriteByte(message.Kind).
+		WriteByte(message.ActivePartySlot).
+		WriteInt16(int16(message.OpponentMonsterID)).
+		WriteByte(message.OpponentLevel).
+		WriteByte(message.OpponentGender).
+		WriteByte(message.OpponentColoration).
+		WriteByte(message.OpponentStatusCondition).
+		WriteInt16(int16(message.OpponentHitPoints)).
+		WriteInt16(int16(message.OpponentMaxHitPoints))
+
+	return bldr.Build()
+}
+
+func (message *StartBattle) GetConfig() client.MessageConfig {
+	return StartBattleConfig
+}
+
+func (message *BattleTurnResult) Demarshal(packet *client.Packet) {
+	itr := packet.Bytes.Iterator()
+
+	count, _ := itr.ReadByte()
+	for i := 0; i < int(count); i++ {
+		event := BattleEvent{}
+
+		event.Kind, _ = itr.ReadByte()
+		event.Side, _ = itr.ReadByte()
+		event.Slot, _ = itr.ReadByte()
+		event.MoveID, _ = itr.ReadUInt16()
+		event.Amount, _ = itr.ReadUInt32()
+		event.Effectiveness, _ = itr.ReadByte()
+
+		critical, _ := itr.ReadByte()
+		event.Critical = critical == 1
+
+		event.Status, _ = itr.ReadByte()
+		event.Stat, _ = itr.ReadByte()
+
+		message.Events = append(message.Events, event)
+	}
+
+	mustReplace, _ := itr.ReadByte()
+	message.MustReplace = mustReplace == 1
+}
+
+func (message *BattleTurnResult) Marshal() *bytes.String {
+	bldr := bytes.NewDefaultBuilder()
+
+	bldr.WriteByte(byte(len(message.Events)))
+	for _, event := range message.Events {
+		bldr.
+			WriteByte(event.Kind).
+			WriteByte(event.Side).
+			WriteByte(event.Slot).
+			WriteInt16(int16(event.MoveID)).
+			WriteInt32(int32(event.Amount)).
+			WriteByte(event.Effectiveness).
+			WriteByte(boolToByte(event.Critical)).
+			WriteByte(event.Status).
+			WriteByte(event.Stat)
+	}
+
+	bldr.WriteByte(boolToByte(message.MustReplace))
+
+	return bldr.Build()
+}
+
+func (message *BattleTurnResult) GetConfig() client.MessageConfig {
+	return BattleTurnResultConfig
+}
+
+func (message *EndBattle) Demarshal(packet *client.Packet) {
+	itr := packet.Bytes.Iterator()
+
+	message.Outcome, _ = itr.ReadByte()
+}
+
+func (message *EndBattle) Marshal() *bytes.String {
+	bldr := bytes.NewDefaultBuilder()
+
+	bldr.WriteByte(message.Outcome)
+
+	return bldr.Build()
+}
+
+func (message *EndBattle) GetConfig() client.MessageConfig {
+	return EndBattleConfig
+}
+
+func boolToByte(value bool) byte {
+	if value {
+		return 1
+	}
+
+	return 0
+}
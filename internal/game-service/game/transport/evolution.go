@@ -0,0 +1,72 @@
+package transport
+
+import (
+	"gitlab.com/pokesync/game-service/internal/game-service/client"
+	"gitlab.com/pokesync/game-service/pkg/bytes"
+)
+
+var (
+	AnswerEvolutionConfig = client.MessageConfig{
+		Kind:  client.AnswerEvolution,
+		Topic: "answer_evolution",
+		New:   func() client.Message { return &AnswerEvolution{} },
+	}
+
+	OfferEvolutionConfig = client.MessageConfig{
+		Kind:  client.OfferEvolution,
+		Topic: "offer_evolution",
+		New:   func() client.Message { return &OfferEvolution{} },
+	}
+)
+
+type AnswerEvolution struct {
+	PartySlot byte
+	Accept    bool
+}
+
+type OfferEvolution struct {
+	PartySlot     byte
+	IntoMonsterID uint16
+}
+
+func (message *AnswerEvolution) Demarshal(packet *client.Packet) {
+	itr := packet.Bytes.Iterator()
+
+	message.PartySlot, _ = itr.ReadByte()
+
+	accept, _ := itr.ReadByte()
+	message.Accept = accept == 1
+}
+
+func (message *AnswerEvolution) Marshal() *bytes.String {
+	bldr := bytes.NewDefaultBuilder()
+
+	bldr.WriteByte(message.PartySlot)
+	bldr.WriteByte(boolToByte(message.Accept))
+
+	return bldr.Build()
+}
+
+func (message *AnswerEvolution) GetConfig() client.MessageConfig {
+	return AnswerEvolutionConfig
+}
+
+func (message *OfferEvolution) Demarshal(packet *client.Packet) {
+	itr := packet.Bytes.Iterator()
+
+	message.PartySlot, _ = itr.ReadByte()
+	message.IntoMonsterID, _ = itr.ReadUInt16()
+}
+
+func (message *OfferEvolution) Marshal() *bytes.String {
+	bldr := bytes.NewDefaultBuilder()
+
+	bldr.WriteByte(message.PartySlot)
+	bldr.WriteInt16(int16(message.IntoMonsterID))
+
+	return bldr.Build()
+}
+
+func (message *OfferEvolution) GetConfig() client.MessageConfig {
+	return OfferEvolutionConfig
+}
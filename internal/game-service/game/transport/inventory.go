@@ -0,0 +1,107 @@
+package transport
+
+import (
+	"gitlab.com/pokesync/game-service/internal/game-service/client"
+	"gitlab.com/pokesync/game-service/pkg/bytes"
+)
+
+var (
+	MoveInventoryItemConfig = client.MessageConfig{
+		Kind:  client.MoveInventoryItem,
+		Topic: "move_inventory_item",
+		New:   func() client.Message { return &MoveInventoryItem{} },
+	}
+
+	UseItemConfig = client.MessageConfig{
+		Kind:  client.UseItem,
+		Topic: "use_item",
+		New:   func() client.Message { return &UseItem{} },
+	}
+
+	SetInventorySlotConfig = client.MessageConfig{
+		Kind:  client.SetInventorySlot,
+		Topic: "set_inventory_slot",
+		New:   func() client.Message { return &SetInventorySlot{} },
+	}
+)
+
+type MoveInventoryItem struct {
+	Pocket   byte
+	SlotFrom byte
+	SlotTo   byte
+}
+
+type UseItem struct {
+	ItemID uint16
+}
+
+type SetInventorySlot struct {
+	Pocket   byte
+	Slot     byte
+	ItemID   uint16
+	Quantity uint16
+}
+
+func (message *MoveInventoryItem) Demarshal(packet *client.Packet) {
+	itr := packet.Bytes.Iterator()
+
+	message.Pocket, _ = itr.ReadByte()
+	message.SlotFrom, _ = itr.ReadByte()
+	message.SlotTo, _ = itr.ReadByte()
+}
+
+func (message *MoveInventoryItem) Marshal() *bytes.String {
+	bldr := bytes.NewDefaultBuilder()
+
+	bldr.WriteByte(message.Pocket)
+	bldr.WriteByte(message.SlotFrom)
+	bldr.WriteByte(message.SlotTo)
+
+	return bldr.Build()
+}
+
+func (message *MoveInventoryItem) GetConfig() client.MessageConfig {
+	return MoveInventoryItemConfig
+}
+
+func (message *UseItem) Demarshal(packet *client.Packet) {
+	itr := packet.Bytes.Iterator()
+
+	message.ItemID, _ = itr.ReadUInt16()
+}
+
+func (message *UseItem) Marshal() *bytes.String {
+	bldr := bytes.NewDefaultBuilder()
+
+	bldr.WriteInt16(int16(message.ItemID))
+
+	return bldr.Build()
+}
+
+func (message *UseItem) GetConfig() client.MessageConfig {
+	return UseItemConfig
+}
+
+func (message *SetInventorySlot) Demarshal(packet *client.Packet) {
+	itr := packet.Bytes.Iterator()
+
+	message.Pocket, _ = itr.ReadByte()
+	message.Slot, _ = itr.ReadByte()
+	message.ItemID, _ = itr.ReadUInt16()
+	message.Quantity, _ = itr.ReadUInt16()
+}
+
+func (message *SetInventorySlot) Marshal() *bytes.String {
+	bldr := bytes.NewDefaultBuilder()
+
+	bldr.WriteByte(message.Pocket)
+	bldr.WriteByte(message.Slot)
+	bldr.WriteInt16(int16(message.ItemID))
+	bldr.WriteInt16(int16(message.Quantity))
+
+	return bldr.Build()
+}
+
+func (message *SetInventorySlot) GetConfig() client.MessageConfig {
+	return SetInventorySlotConfig
+}
@@ -41,6 +41,24 @@ var (
 		Topic: "create_character",
 		New:   func() client.Message { return &CreateCharacter{} },
 	}
+
+	CharacterListConfig = client.MessageConfig{
+		Kind:  client.CharacterList,
+		Topic: "character_list",
+		New:   func() client.Message { return &CharacterList{} },
+	}
+
+	LoginQueuePositionConfig = client.MessageConfig{
+		Kind:  client.LoginQueuePosition,
+		Topic: "login_queue_position",
+		New:   func() client.Message { return &LoginQueuePosition{} },
+	}
+
+	RejectCharacterCreationConfig = client.MessageConfig{
+		Kind:  client.RejectCharacterCreation,
+		Topic: "reject_character_creation",
+		New:   func() client.Message { return &RejectCharacterCreation{} },
+	}
 )
 
 type LoginSuccess struct {
@@ -52,6 +70,8 @@ type LoginSuccess struct {
 	MapZ        uint16
 	LocalX      uint16
 	LocalZ      uint16
+
+	SessionToken string
 }
 
 type UnableToFetchProfile struct{}
@@ -61,6 +81,29 @@ type RequestTimedOut struct{}
 type WorldFull struct{}
 
 type CreateCharacter struct {
+	DisplayName string
+	Gender      byte
+}
+
+type CharacterList struct {
+	Characters []CharacterSummary
+}
+
+type CharacterSummary struct {
+	DisplayName string
+	Gender      byte
+	UserGroup   byte
+	MapX        uint16
+	MapZ        uint16
+}
+
+type RejectCharacterCreation struct {
+	Reason byte
+}
+
+type LoginQueuePosition struct {
+	Position uint16
+	Size     uint16
 }
 
 type SelectCharacter struct {
@@ -79,6 +122,8 @@ func (message *LoginSuccess) Demarshal(packet *client.Packet) {
 	message.MapZ, _ = itr.ReadUInt16()
 	message.LocalX, _ = itr.ReadUInt16()
 	message.LocalZ, _ = itr.ReadUInt16()
+
+	message.SessionToken, _ = itr.ReadCString()
 }
 
 func (message *LoginSuccess) Marshal() *bytes.String {
@@ -92,7 +137,8 @@ func (message *LoginSuccess) Marshal() *bytes.String {
 		WriteInt16(int16(message.MapX)).
 		WriteInt16(int16(message.MapZ)).
 		WriteInt16(int16(message.LocalX)).
-		WriteInt16(int16(message.LocalZ))
+		WriteInt16(int16(message.LocalZ)).
+		WriteCString(message.SessionToken)
 
 	return bldr.Build()
 }
@@ -135,10 +181,20 @@ func (r *WorldFull) GetConfig() client.MessageConfig {
 }
 
 func (message *CreateCharacter) Demarshal(packet *client.Packet) {
+	itr := packet.Bytes.Iterator()
+
+	message.DisplayName, _ = itr.ReadCString()
+	message.Gender, _ = itr.ReadByte()
 }
 
 func (message *CreateCharacter) Marshal() *bytes.String {
-	return bytes.EmptyString()
+	bldr := bytes.NewDefaultBuilder()
+
+	bldr.
+		WriteCString(message.DisplayName).
+		WriteByte(message.Gender)
+
+	return bldr.Build()
 }
 
 func (message *CreateCharacter) GetConfig() client.MessageConfig {
@@ -162,3 +218,79 @@ func (message *SelectCharacter) Marshal() *bytes.String {
 func (message *SelectCharacter) GetConfig() client.MessageConfig {
 	return SelectCharacterConfig
 }
+
+func (message *CharacterList) Demarshal(packet *client.Packet) {
+	itr := packet.Bytes.Iterator()
+
+	count, _ := itr.ReadByte()
+	for i := 0; i < int(count); i++ {
+		summary := CharacterSummary{}
+
+		summary.DisplayName, _ = itr.ReadCString()
+		summary.Gender, _ = itr.ReadByte()
+		summary.UserGroup, _ = itr.ReadByte()
+		summary.MapX, _ = itr.ReadUInt16()
+		summary.MapZ, _ = itr.ReadUInt16()
+
+		message.Characters = append(message.Characters, summary)
+	}
+}
+
+func (message *CharacterList) Marshal() *bytes.String {
+	bldr := bytes.NewDefaultBuilder()
+
+	bldr.WriteByte(byte(len(message.Characters)))
+	for _, summary := range message.Characters {
+		bldr.
+			WriteCString(summary.DisplayName).
+			WriteByte(summary.Gender).
+			WriteByte(summary.UserGroup).
+			WriteInt16(int16(summary.MapX)).
+			WriteInt16(int16(summary.MapZ))
+	}
+
+	return bldr.Build()
+}
+
+func (message *CharacterList) GetConfig() client.MessageConfig {
+	return CharacterListConfig
+}
+
+func (message *RejectCharacterCreation) Demarshal(packet *client.Packet) {
+	itr := packet.Bytes.Iterator()
+
+	message.Reason, _ = itr.ReadByte()
+}
+
+func (message *RejectCharacterCreation) Marshal() *bytes.String {
+	bldr := bytes.NewDefaultBuilder()
+
+	bldr.WriteByte(message.Reason)
+
+	return bldr.Build()
+}
+
+func (message *RejectCharacterCreation) GetConfig() client.MessageConfig {
+	return RejectCharacterCreationConfig
+}
+
+func (message *LoginQueuePosition) Demarshal(packet *client.Packet) {
+	itr := packet.Bytes.Iterator()
+
+	message.Position, _ = itr.ReadUInt16()
+	message.Size, _ = itr.ReadUInt16()
+}
+
+func (message *LoginQueuePosition) Marshal() *bytes.String {
+	bldr := bytes.NewDefaultBuilder()
+
+	bldr.
+		WriteInt16(int16(message.Position)).
+		WriteInt16(int16(message.Size))
+
+	return bldr.Build()
+}
+
+func (message *LoginQueuePosition) GetConfig() client.MessageConfig {
+	return LoginQueuePositionConfig
+}
@@ -11,6 +11,12 @@ var (
 		Topic: "move_avatar",
 		New:   func() client.Message { return &RefreshMap{} },
 	}
+
+	TeleportAvatarConfig = client.MessageConfig{
+		Kind:  client.TeleportAvatar,
+		Topic: "teleport_avatar",
+		New:   func() client.Message { return &TeleportAvatar{} },
+	}
 )
 
 type RefreshMap struct {
@@ -18,6 +24,13 @@ type RefreshMap struct {
 	MapZ uint16
 }
 
+type TeleportAvatar struct {
+	MapX   uint16
+	MapZ   uint16
+	LocalX uint16
+	LocalZ uint16
+}
+
 func (message *RefreshMap) Demarshal(packet *client.Packet) {
 	itr := packet.Bytes.Iterator()
 
@@ -37,3 +50,27 @@ func (message *RefreshMap) Marshal() *bytes.String {
 func (message *RefreshMap) GetConfig() client.MessageConfig {
 	return RefreshMapConfig
 }
+
+func (message *TeleportAvatar) Demarshal(packet *client.Packet) {
+	itr := packet.Bytes.Iterator()
+
+	message.MapX, _ = itr.ReadUInt16()
+	message.MapZ, _ = itr.ReadUInt16()
+	message.LocalX, _ = itr.ReadUInt16()
+	message.LocalZ, _ = itr.ReadUInt16()
+}
+
+func (message *TeleportAvatar) Marshal() *bytes.String {
+	bldr := bytes.NewDefaultBuilder()
+
+	bldr.WriteInt16(int16(message.MapX))
+	bldr.WriteInt16(int16(message.MapZ))
+	bldr.WriteInt16(int16(message.LocalX))
+	bldr.WriteInt16(int16(message.LocalZ))
+
+	return bldr.Build()
+}
+
+func (message *TeleportAvatar) GetConfig() client.MessageConfig {
+	return TeleportAvatarConfig
+}
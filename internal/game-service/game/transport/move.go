@@ -0,0 +1,79 @@
+package transport
+
+import (
+	"gitlab.com/pokesync/game-service/internal/game-service/client"
+	"gitlab.com/pokesync/game-service/pkg/bytes"
+)
+
+var (
+	AnswerMoveConfig = client.MessageConfig{
+		Kind:  client.AnswerMove,
+		Topic: "answer_move",
+		New:   func() client.Message { return &AnswerMove{} },
+	}
+
+	OfferMoveConfig = client.MessageConfig{
+		Kind:  client.OfferMove,
+		Topic: "offer_move",
+		New:   func() client.Message { return &OfferMove{} },
+	}
+)
+
+type AnswerMove struct {
+	PartySlot  byte
+	MoveID     uint16
+	Learn      bool
+	ForgetSlot byte
+}
+
+type OfferMove struct {
+	PartySlot byte
+	MoveID    uint16
+}
+
+func (message *AnswerMove) Demarshal(packet *client.Packet) {
+	itr := packet.Bytes.Iterator()
+
+	message.PartySlot, _ = itr.ReadByte()
+	message.MoveID, _ = itr.ReadUInt16()
+
+	learn, _ := itr.ReadByte()
+	message.Learn = learn == 1
+
+	message.ForgetSlot, _ = itr.ReadByte()
+}
+
+func (message *AnswerMove) Marshal() *bytes.String {
+	bldr := bytes.NewDefaultBuilder()
+
+	bldr.WriteByte(message.PartySlot)
+	bldr.WriteInt16(int16(message.MoveID))
+	bldr.WriteByte(boolToByte(message.Learn))
+	bldr.WriteByte(message.ForgetSlot)
+
+	return bldr.Build()
+}
+
+func (message *AnswerMove) GetConfig() client.MessageConfig {
+	return AnswerMoveConfig
+}
+
+func (message *OfferMove) Demarshal(packet *client.Packet) {
+	itr := packet.Bytes.Iterator()
+
+	message.PartySlot, _ = itr.ReadByte()
+	message.MoveID, _ = itr.ReadUInt16()
+}
+
+func (message *OfferMove) Marshal() *bytes.String {
+	bldr := bytes.NewDefaultBuilder()
+
+	bldr.WriteByte(message.PartySlot)
+	bldr.WriteInt16(int16(message.MoveID))
+
+	return bldr.Build()
+}
+
+func (message *OfferMove) GetConfig() client.MessageConfig {
+	return OfferMoveConfig
+}
@@ -0,0 +1,283 @@
+package transport
+
+import (
+	"gitlab.com/pokesync/game-service/internal/game-service/client"
+	"gitlab.com/pokesync/game-service/pkg/bytes"
+)
+
+var (
+	BrowsePCBoxConfig = client.MessageConfig{
+		Kind:  client.BrowsePCBox,
+		Topic: "browse_pc_box",
+		New:   func() client.Message { return &BrowsePCBox{} },
+	}
+
+	DepositPCMonsterConfig = client.MessageConfig{
+		Kind:  client.DepositPCMonster,
+		Topic: "deposit_pc_monster",
+		New:   func() client.Message { return &DepositPCMonster{} },
+	}
+
+	WithdrawPCMonsterConfig = client.MessageConfig{
+		Kind:  client.WithdrawPCMonster,
+		Topic: "withdraw_pc_monster",
+		New:   func() client.Message { return &WithdrawPCMonster{} },
+	}
+
+	MovePCMonsterConfig = client.MessageConfig{
+		Kind:  client.MovePCMonster,
+		Topic: "move_pc_monster",
+		New:   func() client.Message { return &MovePCMonster{} },
+	}
+
+	ReleasePCMonsterConfig = client.MessageConfig{
+		Kind:  client.ReleasePCMonster,
+		Topic: "release_pc_monster",
+		New:   func() client.Message { return &ReleasePCMonster{} },
+	}
+
+	ClosePCConfig = client.MessageConfig{
+		Kind:  client.ClosePC,
+		Topic: "close_pc",
+		New:   func() client.Message { return &ClosePC{} },
+	}
+
+	PCBoxContentsConfig = client.MessageConfig{
+		Kind:  client.PCBoxContents,
+		Topic: "pc_box_contents",
+		New:   func() client.Message { return &PCBoxContents{} },
+	}
+
+	SetPCSlotConfig = client.MessageConfig{
+		Kind:  client.SetPCSlot,
+		Topic: "set_pc_slot",
+		New:   func() client.Message { return &SetPCSlot{} },
+	}
+)
+
+type BrowsePCBox struct {
+	Box byte
+}
+
+type DepositPCMonster struct {
+	PartySlot byte
+	Box       byte
+}
+
+type WithdrawPCMonster struct {
+	Box  byte
+	Slot byte
+}
+
+type MovePCMonster struct {
+	BoxFrom  byte
+	SlotFrom byte
+	BoxTo    byte
+	SlotTo   byte
+}
+
+type ReleasePCMonster struct {
+	Box  byte
+	Slot byte
+}
+
+type ClosePC struct{}
+
+type PCBoxContents struct {
+	Box      byte
+	Monsters []PCBoxMonster
+}
+
+type SetPCSlot struct {
+	Box     byte
+	Monster PCBoxMonster
+}
+
+type PCBoxMonster struct {
+	Slot       byte
+	MonsterID  uint16
+	Gender     byte
+	Coloration byte
+	Level      byte
+}
+
+func (message *BrowsePCBox) Demarshal(packet *client.Packet) {
+	itr := packet.Bytes.Iterator()
+
+	message.Box, _ = itr.ReadByte()
+}
+
+func (message *BrowsePCBox) Marshal() *bytes.String {
+	bldr := bytes.NewDefaultBuilder()
+
+	bldr.WriteByte(message.Box)
+
+	return bldr.Build()
+}
+
+func (message *BrowsePCBox) GetConfig() client.MessageConfig {
+	return BrowsePCBoxConfig
+}
+
+func (message *DepositPCMonster) Demarshal(packet *client.Packet) {
+	itr := packet.Bytes.Iterator()
+
+	message.PartySlot, _ = itr.ReadByte()
+	message.Box, _ = itr.ReadByte()
+}
+
+func (message *DepositPCMonster) Marshal() *bytes.String {
+	bldr := bytes.NewDefaultBuilder()
+
+	bldr.WriteByte(message.PartySlot)
+	bldr.WriteByte(message.Box)
+
+	return bldr.Build()
+}
+
+func (message *DepositPCMonster) GetConfig() client.MessageConfig {
+	return DepositPCMonsterConfig
+}
+
+func (message *WithdrawPCMonster) Demarshal(packet *client.Packet) {
+	itr := packet.Bytes.Iterator()
+
+	message.Box, _ = itr.ReadByte()
+	message.Slot, _ = itr.ReadByte()
+}
+
+func (message *WithdrawPCMonster) Marshal() *bytes.String {
+	bldr := bytes.NewDefaultBuilder()
+
+	bldr.WriteByte(message.Box)
+	bldr.WriteByte(message.Slot)
+
+	return bldr.Build()
+}
+
+func (message *WithdrawPCMonster) GetConfig() client.MessageConfig {
+	return WithdrawPCMonsterConfig
+}
+
+func (message *MovePCMonster) Demarshal(packet *client.Packet) {
+	itr := packet.Bytes.Iterator()
+
+	message.BoxFrom, _ = itr.ReadByte()
+	message.SlotFrom, _ = itr.ReadByte()
+	message.BoxTo, _ = itr.ReadByte()
+	message.SlotTo, _ = itr.ReadByte()
+}
+
+func (message *MovePCMonster) Marshal() *bytes.String {
+	bldr := bytes.NewDefaultBuilder()
+
+	bldr.WriteByte(message.BoxFrom)
+	bldr.WriteByte(message.SlotFrom)
+	bldr.WriteByte(message.BoxTo)
+	bldr.WriteByte(message.SlotTo)
+
+	return bldr.Build()
+}
+
+func (message *MovePCMonster) GetConfig() client.MessageConfig {
+	return MovePCMonsterConfig
+}
+
+func (message *ReleasePCMonster) Demarshal(packet *client.Packet) {
+	itr := packet.Bytes.Iterator()
+
+	message.Box, _ = itr.ReadByte()
+	message.Slot, _ = itr.ReadByte()
+}
+
+func (message *ReleasePCMonster) Marshal() *bytes.String {
+	bldr := bytes.NewDefaultBuilder()
+
+	bldr.WriteByte(message.Box)
+	bldr.WriteByte(message.Slot)
+
+	return bldr.Build()
+}
+
+func (message *ReleasePCMonster) GetConfig() client.MessageConfig {
+	return ReleasePCMonsterConfig
+}
+
+func (message *ClosePC) Demarshal(packet *client.Packet) {
+}
+
+func (message *ClosePC) Marshal() *bytes.String {
+	return bytes.EmptyString()
+}
+
+func (message *ClosePC) GetConfig() client.MessageConfig {
+	return ClosePCConfig
+}
+
+func (message *PCBoxContents) Demarshal(packet *client.Packet) {
+	itr := packet.Bytes.Iterator()
+
+	message.Box, _ = itr.ReadByte()
+
+	count, _ := itr.ReadByte()
+	for i := 0; i < int(count); i++ {
+		message.Monsters = append(message.Monsters, readPCBoxMonster(itr))
+	}
+}
+
+func (message *PCBoxContents) Marshal() *bytes.String {
+	bldr := bytes.NewDefaultBuilder()
+
+	bldr.WriteByte(message.Box)
+	bldr.WriteByte(byte(len(message.Monsters)))
+	for _, monster := range message.Monsters {
+		writePCBoxMonster(bldr, monster)
+	}
+
+	return bldr.Build()
+}
+
+func (message *PCBoxContents) GetConfig() client.MessageConfig {
+	return PCBoxContentsConfig
+}
+
+func (message *SetPCSlot) Demarshal(packet *client.Packet) {
+	itr := packet.Bytes.Iterator()
+
+	message.Box, _ = itr.ReadByte()
+	message.Monster = readPCBoxMonster(itr)
+}
+
+func (message *SetPCSlot) Marshal() *bytes.String {
+	bldr := bytes.NewDefaultBuilder()
+
+	bldr.WriteByte(message.Box)
+	writePCBoxMonster(bldr, message.Monster)
+
+	return bldr.Build()
+}
+
+func (message *SetPCSlot) GetConfig() client.MessageConfig {
+	return SetPCSlotConfig
+}
+
+func readPCBoxMonster(itr *bytes.Iterator) PCBoxMonster {
+	monster := PCBoxMonster{}
+
+	monster.Slot, _ = itr.ReadByte()
+	monster.MonsterID, _ = itr.ReadUInt16()
+	monster.Gender, _ = itr.ReadByte()
+	monster.Coloration, _ = itr.ReadByte()
+	monster.Level, _ = itr.ReadByte()
+
+	return monster
+}
+
+func writePCBoxMonster(bldr *bytes.Builder, monster PCBoxMonster) {
+	bldr.
+		WriteByte(monster.Slot).
+		WriteInt16(int16(monster.MonsterID)).
+		WriteByte(monster.Gender).
+		WriteByte(monster.Coloration).
+		WriteByte(monster.Level)
+}
@@ -0,0 +1,314 @@
+package transport
+
+import (
+	"gitlab.com/pokesync/game-service/internal/game-service/client"
+	"gitlab.com/pokesync/game-service/pkg/bytes"
+)
+
+var (
+	OfferTradeMonsterConfig = client.MessageConfig{
+		Kind:  client.OfferTradeMonster,
+		Topic: "offer_trade_monster",
+		New:   func() client.Message { return &OfferTradeMonster{} },
+	}
+
+	RetractTradeOfferConfig = client.MessageConfig{
+		Kind:  client.RetractTradeOffer,
+		Topic: "retract_trade_offer",
+		New:   func() client.Message { return &RetractTradeOffer{} },
+	}
+
+	OfferTradeDollarsConfig = client.MessageConfig{
+		Kind:  client.OfferTradeDollars,
+		Topic: "offer_trade_dollars",
+		New:   func() client.Message { return &OfferTradeDollars{} },
+	}
+
+	AcceptTradeConfig = client.MessageConfig{
+		Kind:  client.AcceptTrade,
+		Topic: "accept_trade",
+		New:   func() client.Message { return &AcceptTrade{} },
+	}
+
+	DeclineTradeConfig = client.MessageConfig{
+		Kind:  client.DeclineTrade,
+		Topic: "decline_trade",
+		New:   func() client.Message { return &DeclineTrade{} },
+	}
+
+	TradeRequestConfig = client.MessageConfig{
+		Kind:  client.TradeRequest,
+		Topic: "trade_request",
+		New:   func() client.Message { return &TradeRequest{} },
+	}
+
+	OpenTradeConfig = client.MessageConfig{
+		Kind:  client.OpenTrade,
+		Topic: "open_trade",
+		New:   func() client.Message { return &OpenTrade{} },
+	}
+
+	SetTradeOfferConfig = client.MessageConfig{
+		Kind:  client.SetTradeOffer,
+		Topic: "set_trade_offer",
+		New:   func() client.Message { return &SetTradeOffer{} },
+	}
+
+	SetTradeStageConfig = client.MessageConfig{
+		Kind:  client.SetTradeStage,
+		Topic: "set_trade_stage",
+		New:   func() client.Message { return &SetTradeStage{} },
+	}
+
+	CloseTradeConfig = client.MessageConfig{
+		Kind:  client.CloseTrade,
+		Topic: "close_trade",
+		New:   func() client.Message { return &CloseTrade{} },
+	}
+)
+
+type OfferTradeMonster struct {
+	PartySlot byte
+}
+
+type RetractTradeOffer struct {
+	OfferSlot byte
+}
+
+type OfferTradeDollars struct {
+	Amount uint32
+}
+
+type AcceptTrade struct{}
+
+type DeclineTrade struct{}
+
+type TradeRequest struct {
+	PID uint16
+}
+
+type OpenTrade struct {
+	PID uint16
+}
+
+type SetTradeOffer struct {
+	Partner  bool
+	Dollars  uint32
+	Monsters []TradeMonster
+}
+
+type SetTradeStage struct {
+	Stage           byte
+	Accepted        bool
+	PartnerAccepted bool
+}
+
+type CloseTrade struct {
+	Completed bool
+}
+
+type TradeMonster struct {
+	MonsterID  uint16
+	Gender     byte
+	Coloration byte
+	Level      byte
+}
+
+func (message *OfferTradeMonster) Demarshal(packet *client.Packet) {
+	itr := packet.Bytes.Iterator()
+
+	message.PartySlot, _ = itr.ReadByte()
+}
+
+func (message *OfferTradeMonster) Marshal() *bytes.String {
+	bldr := bytes.NewDefaultBuilder()
+
+	bldr.WriteByte(message.PartySlot)
+
+	return bldr.Build()
+}
+
+func (message *OfferTradeMonster) GetConfig() client.MessageConfig {
+	return OfferTradeMonsterConfig
+}
+
+func (message *RetractTradeOffer) Demarshal(packet *client.Packet) {
+	itr := packet.Bytes.Iterator()
+
+	message.OfferSlot, _ = itr.ReadByte()
+}
+
+func (message *RetractTradeOffer) Marshal() *bytes.String {
+	bldr := bytes.NewDefaultBuilder()
+
+	bldr.WriteByte(message.OfferSlot)
+
+	return bldr.Build()
+}
+
+func (message *RetractTradeOffer) GetConfig() client.MessageConfig {
+	return RetractTradeOfferConfig
+}
+
+func (message *OfferTradeDollars) Demarshal(packet *client.Packet) {
+	itr := packet.Bytes.Iterator()
+
+	message.Amount, _ = itr.ReadUInt32()
+}
+
+func (message *OfferTradeDollars) Marshal() *bytes.String {
+	bldr := bytes.NewDefaultBuilder()
+
+	bldr.WriteInt32(int32(message.Amount))
+
+	return bldr.Build()
+}
+
+func (message *OfferTradeDollars) GetConfig() client.MessageConfig {
+	return OfferTradeDollarsConfig
+}
+
+func (message *AcceptTrade) Demarshal(packet *client.Packet) {
+}
+
+func (message *AcceptTrade) Marshal() *bytes.String {
+	return bytes.EmptyString()
+}
+
+func (message *AcceptTrade) GetConfig() client.MessageConfig {
+	return AcceptTradeConfig
+}
+
+func (message *DeclineTrade) Demarshal(packet *client.Packet) {
+}
+
+func (message *DeclineTrade) Marshal() *bytes.String {
+	return bytes.EmptyString()
+}
+
+func (message *DeclineTrade) GetConfig() client.MessageConfig {
+	return DeclineTradeConfig
+}
+
+func (message *TradeRequest) Demarshal(packet *client.Packet) {
+	itr := packet.Bytes.Iterator()
+
+	message.PID, _ = itr.ReadUInt16()
+}
+
+func (message *TradeRequest) Marshal() *bytes.String {
+	bldr := bytes.NewDefaultBuilder()
+
+	bldr.WriteInt16(int16(message.PID))
+
+	return bldr.Build()
+}
+
+func (message *TradeRequest) GetConfig() client.MessageConfig {
+	return TradeRequestConfig
+}
+
+func (message *OpenTrade) Demarshal(packet *client.Packet) {
+	itr := packet.Bytes.Iterator()
+
+	message.PID, _ = itr.ReadUInt16()
+}
+
+func (message *OpenTrade) Marshal() *bytes.String {
+	bldr := bytes.NewDefaultBuilder()
+
+	bldr.WriteInt16(int16(message.PID))
+
+	return bldr.Build()
+}
+
+func (message *OpenTrade) GetConfig() client.MessageConfig {
+	return OpenTradeConfig
+}
+
+func (message *SetTradeOffer) Demarshal(packet *client.Packet) {
+	itr := packet.Bytes.Iterator()
+
+	partner, _ := itr.ReadByte()
+	message.Partner = partner == 1
+	message.Dollars, _ = itr.ReadUInt32()
+
+	count, _ := itr.ReadByte()
+	for i := 0; i < int(count); i++ {
+		monster := TradeMonster{}
+
+		monster.MonsterID, _ = itr.ReadUInt16()
+		monster.Gender, _ = itr.ReadByte()
+		monster.Coloration, _ = itr.ReadByte()
+		monster.Level, _ = itr.ReadByte()
+
+		message.Monsters = append(message.Monsters, monster)
+	}
+}
+
+func (message *SetTradeOffer) Marshal() *bytes.String {
+	bldr := bytes.NewDefaultBuilder()
+
+	bldr.WriteByte(boolToByte(message.Partner))
+	bldr.WriteInt32(int32(message.Dollars))
+
+	bldr.WriteByte(byte(len(message.Monsters)))
+	for _, monster := range message.Monsters {
+		bldr.
+			WriteInt16(int16(monster.MonsterID)).
+			WriteByte(monster.Gender).
+			WriteByte(monster.Coloration).
+			WriteByte(monster.Level)
+	}
+
+	return bldr.Build()
+}
+
+func (message *SetTradeOffer) GetConfig() client.MessageConfig {
+	return SetTradeOfferConfig
+}
+
+func (message *SetTradeStage) Demarshal(packet *client.Packet) {
+	itr := packet.Bytes.Iterator()
+
+	message.Stage, _ = itr.ReadByte()
+
+	accepted, _ := itr.ReadByte()
+	message.Accepted = accepted == 1
+
+	partnerAccepted, _ := itr.ReadByte()
+	message.PartnerAccepted = partnerAccepted == 1
+}
+
+func (message *SetTradeStage) Marshal() *bytes.String {
+	bldr := bytes.NewDefaultBuilder()
+
+	bldr.WriteByte(message.Stage)
+	bldr.WriteByte(boolToByte(message.Accepted))
+	bldr.WriteByte(boolToByte(message.PartnerAccepted))
+
+	return bldr.Build()
+}
+
+func (message *SetTradeStage) GetConfig() client.MessageConfig {
+	return SetTradeStageConfig
+}
+
+func (message *CloseTrade) Demarshal(packet *client.Packet) {
+	itr := packet.Bytes.Iterator()
+
+	completed, _ := itr.ReadByte()
+	message.Completed = completed == 1
+}
+
+func (message *CloseTrade) Marshal() *bytes.String {
+	bldr := bytes.NewDefaultBuilder()
+
+	bldr.WriteByte(boolToByte(message.Completed))
+
+	return bldr.Build()
+}
+
+func (message *CloseTrade) GetConfig() client.MessageConfig {
+	return CloseTradeConfig
+}
@@ -0,0 +1,146 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+)
+
+// TypeChartConfig is the configuration of a TypeChart. Matchups holds,
+// per attacking type, the multiplier against each defending type that
+// is not neutral.
+type TypeChartConfig struct {
+	Types    []string                      `json:"types"`
+	Matchups map[string]map[string]float64 `json:"matchups"`
+}
+
+// TypeChart is the registry of every type there is, along with how
+// effective moves of each type are against monsters of each type.
+type TypeChart struct {
+	names   []string
+	indices map[string]int
+	matrix  [][]float64
+}
+
+// LoadTypeChartAt loads the TypeChart from the config file at the given
+// path. May return an error.
+func LoadTypeChartAt(path string) (*TypeChart, error) {
+	fileBytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	config := TypeChartConfig{}
+	if err := json.Unmarshal(fileBytes, &config); err != nil {
+		return nil, err
+	}
+
+	return NewTypeChart(config)
+}
+
+// NewTypeChart constructs a new TypeChart from the given TypeChartConfig.
+// Returns an error if a type is registered twice or if a matchup refers
+// to a type that is not registered.
+func NewTypeChart(config TypeChartConfig) (*TypeChart, error) {
+	chart := &TypeChart{
+		names:   config.Types,
+		indices: make(map[string]int, len(config.Types)),
+		matrix:  make([][]float64, len(config.Types)),
+	}
+
+	for index, name := range config.Types {
+		if _, exists := chart.indices[name]; exists {
+			return nil, fmt.Errorf("type %v is registered twice", name)
+		}
+
+		chart.indices[name] = index
+		chart.matrix[index] = make([]float64, len(config.Types))
+		for defending := range chart.matrix[index] {
+			chart.matrix[index][defending] = 1
+		}
+	}
+
+	for attacking, matchups := range config.Matchups {
+		attackingIndex, exists := chart.indices[attacking]
+		if !exists {
+			return nil, fmt.Errorf("matchups of unknown type %v", attacking)
+		}
+
+		for defending, multiplier := range matchups {
+			defendingIndex, exists := chart.indices[defending]
+			if !exists {
+				return nil, fmt.Errorf("type %v has a matchup against unknown type %v", attacking, defending)
+			}
+
+			if multiplier < 0 {
+				return nil, fmt.Errorf("type %v has a negative multiplier against type %v", attacking, defending)
+			}
+
+			chart.matrix[attackingIndex][defendingIndex] = multiplier
+		}
+	}
+
+	return chart, nil
+}
+
+// Types returns the names of every registered type.
+func (chart *TypeChart) Types() []string {
+	return chart.names
+}
+
+// Has returns whether a type of the given name is registered.
+func (chart *TypeChart) Has(name string) bool {
+	_, exists := chart.indices[name]
+	return exists
+}
+
+// Effectiveness returns the multiplier of how effective a move of the
+// attacking type is against a monster of the defending type. Unknown
+// types are neutral against every type.
+func (chart *TypeChart) Effectiveness(attacking, defending string) float64 {
+	attackingIndex, exists := chart.indices[attacking]
+	if !exists {
+		return 1
+	}
+
+	defendingIndex, exists := chart.indices[defending]
+	if !exists {
+		return 1
+	}
+
+	return chart.matrix[attackingIndex][defendingIndex]
+}
+
+// EffectivenessAgainst returns the combined multiplier of how effective a
+// move of the attacking type is against a monster of the given TypePair,
+// which may be of a single type.
+func (chart *TypeChart) EffectivenessAgainst(attacking string, defending TypePair) float64 {
+	effectiveness := chart.Effectiveness(attacking, defending.First)
+	if defending.Second != "" && defending.Second != defending.First {
+		effectiveness *= chart.Effectiveness(attacking, defending.Second)
+	}
+
+	return effectiveness
+}
+
+// validateTypes checks that every monster and every move is of a type
+// that is registered in the given TypeChart.
+func validateTypes(chart *TypeChart, monsters *MonsterConfig, moves *MoveConfig) error {
+	for _, monster := range monsters.Descriptors {
+		if !chart.Has(monster.Types.First) {
+			return fmt.Errorf("monster %v is of unknown type %q", monster.Name, monster.Types.First)
+		}
+
+		if monster.Types.Second != "" && !chart.Has(monster.Types.Second) {
+			return fmt.Errorf("monster %v is of unknown type %q", monster.Name, monster.Types.Second)
+		}
+	}
+
+	for _, move := range moves.Descriptors {
+		if !chart.Has(move.Type) {
+			return fmt.Errorf("move %v is of unknown type %q", move.Name, move.Type)
+		}
+	}
+
+	return nil
+}
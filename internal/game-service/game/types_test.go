@@ -0,0 +1,76 @@
+package game
+
+import "testing"
+
+func TestLoadTypeChartAt(t *testing.T) {
+	chart, err := LoadTypeChartAt("../../../assets/config/type/chart.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	monsters, err := LoadMonsterConfigsAt("../../../assets/config/monster")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	moves, err := LoadMoveConfigsAt("../../../assets/config/move")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := validateTypes(chart, monsters, moves); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestTypeChart_Effectiveness(t *testing.T) {
+	chart, err := NewTypeChart(TypeChartConfig{
+		Types: []string{"NORMAL", "FIRE", "GRASS", "GHOST"},
+		Matchups: map[string]map[string]float64{
+			"FIRE":   {"FIRE": 0.5, "GRASS": 2},
+			"NORMAL": {"GHOST": 0},
+		},
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		attacking string
+		defending TypePair
+		expected  float64
+	}{
+		{"FIRE", TypePair{First: "GRASS"}, 2},
+		{"FIRE", TypePair{First: "GRASS", Second: "GRASS"}, 2},
+		{"FIRE", TypePair{First: "GRASS", Second: "FIRE"}, 1},
+		{"FIRE", TypePair{First: "NORMAL"}, 1},
+		{"NORMAL", TypePair{First: "FIRE", Second: "GHOST"}, 0},
+	}
+
+	for _, test := range tests {
+		if effectiveness := chart.EffectivenessAgainst(test.attacking, test.defending); effectiveness != test.expected {
+			t.Errorf("expected %v against %v to be %v but was %v instead", test.attacking, test.defending, test.expected, effectiveness)
+		}
+	}
+}
+
+func TestNewTypeChart_RefusesUnknownTypes(t *testing.T) {
+	_, err := NewTypeChart(TypeChartConfig{
+		Types:    []string{"FIRE"},
+		Matchups: map[string]map[string]float64{"FIRE": {"GRASS": 2}},
+	})
+
+	if err == nil {
+		t.Error("expected a matchup against an unknown type to be refused")
+	}
+}
+
+func TestValidateTypes_RefusesUnknownMonsterTypes(t *testing.T) {
+	chart, _ := NewTypeChart(TypeChartConfig{Types: []string{"FIRE"}})
+
+	monsters := &MonsterConfig{Descriptors: []MonsterDescriptor{{Name: "CHARMANDER", Types: TypePair{First: "FIRE", Second: "FLAME"}}}}
+	if err := validateTypes(chart, monsters, &MoveConfig{}); err == nil {
+		t.Error("expected a monster of an unknown type to be refused")
+	}
+}
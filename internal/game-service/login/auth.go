@@ -25,6 +25,7 @@ type Authenticator struct {
 var (
 	couldNotFindAccount AuthResult = CouldNotFindAccount{}
 	passwordMismatch    AuthResult = PasswordMismatch{}
+	accountIsDisabled   AuthResult = AccountIsDisabled{}
 	timedOut            AuthResult = TimedOut{}
 )
 
@@ -44,6 +45,10 @@ type CouldNotFindAccount struct {
 // been entered by the user.
 type PasswordMismatch struct{}
 
+// AccountIsDisabled is an AuthResult of the user having provided the
+// credentials of an Account that has been disabled, such as after a ban.
+type AccountIsDisabled struct{}
+
 // TimedOut is an AuthResult of the authentication procedure taking
 // too long and has thus been 'timed out'.
 type TimedOut struct{}
@@ -81,6 +86,10 @@ func (auth Authenticator) Authenticate(ctx context.Context, email account.Email,
 			return passwordMismatch, nil
 		}
 
+		if result.Account.Disabled {
+			return accountIsDisabled, nil
+		}
+
 		return AuthSuccess{Account: *result.Account}, nil
 
 	case <-ctx.Done():
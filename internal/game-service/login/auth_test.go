@@ -91,3 +91,29 @@ func TestAuthenticator_Authenticat_WrongPassword(t *testing.T) {
 
 	cancel()
 }
+
+func TestAuthenticator_Authenticate_AccountIsDisabled(t *testing.T) {
+	config := AuthConfig{AccountFetchTimeout: 1 * time.Second}
+	authenticator := NewAuthenticator(config, func(email account.Email, password account.Password) <-chan account.LoadResult {
+		ch := make(chan account.LoadResult, 1)
+		ch <- account.LoadResult{Account: &account.Account{Email: email, Password: password, Disabled: true}}
+		return ch
+	}, account.BasicPasswordMatcher())
+
+	background := context.Background()
+	ctx, cancel := context.WithCancel(background)
+
+	result, err := authenticator.Authenticate(ctx, account.Email("Sino@gmail.com"), account.Password("hello123"))
+	if err != nil {
+		t.Error(err)
+	}
+
+	switch result.(type) {
+	case AccountIsDisabled:
+		break
+	default:
+		t.Error("expected result to be of type AccountIsDisabled")
+	}
+
+	cancel()
+}
@@ -7,6 +7,7 @@ import (
 	"gitlab.com/pokesync/game-service/internal/game-service/account"
 	"gitlab.com/pokesync/game-service/internal/game-service/client"
 	"gitlab.com/pokesync/game-service/internal/game-service/game"
+	"gitlab.com/pokesync/game-service/internal/game-service/token"
 	"go.uber.org/zap"
 )
 
@@ -19,7 +20,7 @@ type Config struct {
 type Job struct {
 	Context context.Context
 	Client  *client.Client
-	Request Request
+	Request client.Message
 }
 
 // Service is an implementation of a login service providing
@@ -29,11 +30,12 @@ type Service struct {
 	logger        *zap.SugaredLogger
 	jobQueue      chan Job
 	authenticator Authenticator
+	tokenIssuer   *token.Issuer
 	routing       *client.Router
 }
 
 // NewService constructs a new login Service.
-func NewService(config Config, logger *zap.SugaredLogger, authenticator Authenticator, routing *client.Router) *Service {
+func NewService(config Config, logger *zap.SugaredLogger, authenticator Authenticator, tokenIssuer *token.Issuer, routing *client.Router) *Service {
 	jobQueue := make(chan Job)
 
 	service := &Service{
@@ -41,10 +43,12 @@ func NewService(config Config, logger *zap.SugaredLogger, authenticator Authenti
 		logger:        logger,
 		jobQueue:      jobQueue,
 		authenticator: authenticator,
+		tokenIssuer:   tokenIssuer,
 		routing:       routing,
 	}
 
-	mailbox := routing.Subscribe("login_request")
+	mailbox := routing.Subscribe(RequestConfig.Topic)
+	routing.SubscribeMailboxToTopic(ResumeSessionConfig.Topic, mailbox)
 	go service.receiver(mailbox)
 
 	for i := 0; i < config.WorkerCount; i++ {
@@ -62,63 +66,109 @@ func (service *Service) receiver(mailbox client.Mailbox) {
 			service.queueRequest(mail.Context, mail.Client, message)
 			break
 
+		case *ResumeSession:
+			service.queueRequest(mail.Context, mail.Client, message)
+			break
+
 		default:
 			service.logger.Errorf("unexpected message received of type %v", reflect.TypeOf(message))
 		}
 	}
 }
 
-// queueRequest buffers the given login Request of the given Client for processing.
-func (service *Service) queueRequest(ctx context.Context, client *client.Client, request *Request) {
-	service.jobQueue <- Job{Context: ctx, Client: client, Request: *request}
+// queueRequest buffers the given login request of the given Client for processing.
+func (service *Service) queueRequest(ctx context.Context, client *client.Client, request client.Message) {
+	service.jobQueue <- Job{Context: ctx, Client: client, Request: request}
 }
 
 // worker continuously reads from the service's job queue until the
 // queue is closed.
 func (service *Service) worker() {
 	for job := range service.jobQueue {
-		email := account.Email(job.Request.Email)
-		password := account.Password(job.Request.Password)
+		switch request := job.Request.(type) {
+		case *Request:
+			service.login(job, request)
 
-		if !email.Validate() || !password.Validate() {
-			job.Client.SendNow(&InvalidCredentials{})
-			job.Client.Terminate()
+		case *ResumeSession:
+			service.resume(job, request)
 
-			continue
+		default:
+			service.logger.Errorf("unexpected login request of type %v", reflect.TypeOf(request))
 		}
+	}
+}
 
-		result, err := service.authenticator.Authenticate(job.Context, email, password)
-		if err != nil {
-			job.Client.SendNow(&ErrorDuringAccountFetch{})
-			job.Client.Terminate()
+// login authenticates the user of the given Job by the e-mail and password
+// of the specified login Request.
+func (service *Service) login(job Job, request *Request) {
+	email := account.Email(request.Email)
+	password := account.Password(request.Password)
 
-			continue
-		}
+	if status := email.Status(); status != account.ValidEmail {
+		job.Client.SendNow(&InvalidEmail{Reason: byte(status)})
+		job.Client.Terminate()
 
-		switch res := result.(type) {
-		case AuthSuccess:
-			service.routing.Publish(game.AuthenticationEventTopic, client.Mail{
-				Context: job.Context,
-				Client:  job.Client,
-				Payload: game.Authenticated{Account: res.Account},
-			})
+		return
+	}
 
-		case TimedOut:
-			job.Client.SendNow(&RequestTimedOut{})
-			job.Client.Terminate()
+	if !password.Validate() {
+		job.Client.SendNow(&InvalidCredentials{})
+		job.Client.Terminate()
 
-			continue
+		return
+	}
 
-		case CouldNotFindAccount, PasswordMismatch:
-			job.Client.SendNow(&InvalidCredentials{})
-			job.Client.Terminate()
+	result, err := service.authenticator.Authenticate(job.Context, email, password)
+	if err != nil {
+		job.Client.SendNow(&ErrorDuringAccountFetch{})
+		job.Client.Terminate()
 
-			continue
+		return
+	}
 
-		default:
-			service.logger.Errorf("unexpected authentication result type of %v", reflect.TypeOf(res))
-		}
+	switch res := result.(type) {
+	case AuthSuccess:
+		service.publishAuthenticated(job, res.Account)
+
+	case AccountIsDisabled:
+		job.Client.SendNow(&AccountDisabled{})
+		job.Client.Terminate()
+
+	case TimedOut:
+		job.Client.SendNow(&RequestTimedOut{})
+		job.Client.Terminate()
+
+	case CouldNotFindAccount, PasswordMismatch:
+		job.Client.SendNow(&InvalidCredentials{})
+		job.Client.Terminate()
+
+	default:
+		service.logger.Errorf("unexpected authentication result type of %v", reflect.TypeOf(res))
+	}
+}
+
+// resume authenticates the user of the given Job by the session token it
+// was handed upon its previous login. Each token can only be redeemed once.
+func (service *Service) resume(job Job, request *ResumeSession) {
+	email, err := service.tokenIssuer.Redeem(token.Token(request.Token))
+	if err != nil {
+		job.Client.SendNow(&InvalidCredentials{})
+		job.Client.Terminate()
+
+		return
 	}
+
+	service.publishAuthenticated(job, account.Account{Email: email})
+}
+
+// publishAuthenticated notifies the game service of the user of the given
+// Job having been authenticated as the owner of the specified Account.
+func (service *Service) publishAuthenticated(job Job, acc account.Account) {
+	service.routing.Publish(game.AuthenticationEventTopic, client.Mail{
+		Context: job.Context,
+		Client:  job.Client,
+		Payload: game.Authenticated{Account: acc},
+	})
 }
 
 // Stop stops this service, closing its job queue and
@@ -12,12 +12,24 @@ var (
 		New:   func() client.Message { return &Request{} },
 	}
 
+	ResumeSessionConfig = client.MessageConfig{
+		Kind:  client.ResumeSession,
+		Topic: "resume_session",
+		New:   func() client.Message { return &ResumeSession{} },
+	}
+
 	InvalidCredentialsConfig = client.MessageConfig{
 		Kind:  client.InvalidCredentials,
 		Topic: "invalid_cred",
 		New:   func() client.Message { return &InvalidCredentials{} },
 	}
 
+	InvalidEmailConfig = client.MessageConfig{
+		Kind:  client.InvalidEmail,
+		Topic: "invalid_email",
+		New:   func() client.Message { return &InvalidEmail{} },
+	}
+
 	AlreadyLoggedInConfig = client.MessageConfig{
 		Kind:  client.AlreadyLoggedIn,
 		Topic: "already_logged_in",
@@ -58,8 +70,20 @@ type Request struct {
 	Password string
 }
 
+type ResumeSession struct {
+	MajorVersion uint8
+	MinorVersion uint8
+	PatchVersion uint8
+
+	Token string
+}
+
 type InvalidCredentials struct{}
 
+type InvalidEmail struct {
+	Reason byte
+}
+
 type WorldFull struct{}
 
 type AlreadyLoggedIn struct{}
@@ -98,6 +122,32 @@ func (r *Request) GetConfig() client.MessageConfig {
 	return RequestConfig
 }
 
+func (r *ResumeSession) Demarshal(packet *client.Packet) {
+	itr := packet.Bytes.Iterator()
+
+	r.MajorVersion, _ = itr.ReadByte()
+	r.MinorVersion, _ = itr.ReadByte()
+	r.PatchVersion, _ = itr.ReadByte()
+
+	r.Token, _ = itr.ReadCString()
+}
+
+func (r *ResumeSession) Marshal() *bytes.String {
+	bldr := bytes.NewDefaultBuilder()
+
+	bldr.
+		WriteByte(r.MajorVersion).
+		WriteByte(r.MinorVersion).
+		WriteByte(r.PatchVersion).
+		WriteCString(r.Token)
+
+	return bldr.Build()
+}
+
+func (r *ResumeSession) GetConfig() client.MessageConfig {
+	return ResumeSessionConfig
+}
+
 func (r *AccountDisabled) Demarshal(packet *client.Packet) {
 }
 
@@ -142,6 +192,24 @@ func (r *InvalidCredentials) GetConfig() client.MessageConfig {
 	return InvalidCredentialsConfig
 }
 
+func (r *InvalidEmail) Demarshal(packet *client.Packet) {
+	itr := packet.Bytes.Iterator()
+
+	r.Reason, _ = itr.ReadByte()
+}
+
+func (r *InvalidEmail) Marshal() *bytes.String {
+	bldr := bytes.NewDefaultBuilder()
+
+	bldr.WriteByte(r.Reason)
+
+	return bldr.Build()
+}
+
+func (r *InvalidEmail) GetConfig() client.MessageConfig {
+	return InvalidEmailConfig
+}
+
 func (r *RequestTimedOut) Demarshal(packet *client.Packet) {
 }
 
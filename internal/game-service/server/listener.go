@@ -5,6 +5,7 @@ import (
 	"io"
 	"net"
 	"strconv"
+	"sync"
 
 	"gitlab.com/pokesync/game-service/internal/game-service/client"
 	"go.uber.org/zap"
@@ -23,22 +24,29 @@ type Listener interface {
 
 // TCPListener is a Listener that listens at a TCP port.
 type TCPListener struct {
-	config   Config
+	config Config
+	Router *client.Router
+	logger *zap.SugaredLogger
+
+	// mutex guards the listener, which is set by Bind and closed by
+	// Unbind from another goroutine
+	mutex    sync.Mutex
 	listener net.Listener
-	Router   *client.Router
-	logger   *zap.SugaredLogger
+	unbound  chan bool
 }
 
 // NewTCPListener constructs a TcpListener.
 func NewTCPListener(config Config, routing *client.Router, logger *zap.SugaredLogger) *TCPListener {
 	return &TCPListener{
-		config: config,
-		Router: routing,
-		logger: logger,
+		config:  config,
+		Router:  routing,
+		logger:  logger,
+		unbound: make(chan bool),
 	}
 }
 
 // Bind binds the TCPListener to listen at the specified address and port.
+// Blocks until the TCPListener is unbound, after which nil is returned.
 func (l *TCPListener) Bind(address string, port int) error {
 	fullAddress := address + ":" + strconv.Itoa(port)
 	listener, err := net.Listen("tcp", fullAddress)
@@ -46,7 +54,20 @@ func (l *TCPListener) Bind(address string, port int) error {
 		return err
 	}
 
-	l.listener = listener
+	l.mutex.Lock()
+
+	// the TCPListener may have been unbound whilst it was still binding,
+	// in which case there is no one left to close the listener but us
+	select {
+	case <-l.unbound:
+		l.mutex.Unlock()
+		return listener.Close()
+
+	default:
+		l.listener = listener
+		l.mutex.Unlock()
+	}
+
 	l.logger.Info("Channel bound at: ", fullAddress)
 
 	background := context.Background()
@@ -54,7 +75,13 @@ func (l *TCPListener) Bind(address string, port int) error {
 	for {
 		connection, err := listener.Accept()
 		if err != nil {
-			return err
+			select {
+			case <-l.unbound:
+				return nil
+
+			default:
+				return err
+			}
 		}
 
 		c := client.NewClient(connection, l.config.ClientConfig)
@@ -83,7 +110,24 @@ func (l *TCPListener) Bind(address string, port int) error {
 	}
 }
 
-// Unbind unbinds the TcpListener from listening at a port.
+// Unbind unbinds the TcpListener from listening at a port, no longer
+// accepting any new connections. Unbinding a TcpListener that has already
+// been unbound does nothing.
 func (l *TCPListener) Unbind() error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	select {
+	case <-l.unbound:
+		return nil
+
+	default:
+		close(l.unbound)
+	}
+
+	if l.listener == nil {
+		return nil
+	}
+
 	return l.listener.Close()
 }
@@ -0,0 +1,20 @@
+package server
+
+import (
+	"testing"
+
+	"gitlab.com/pokesync/game-service/internal/game-service/client"
+	"go.uber.org/zap"
+)
+
+func TestTCPListener_UnbindTwice(t *testing.T) {
+	listener := NewTCPListener(Config{}, client.NewRouter(client.RouterConfig{}), zap.NewNop().Sugar())
+
+	if err := listener.Unbind(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := listener.Unbind(); err != nil {
+		t.Errorf("expected unbinding again to do nothing but was %v", err)
+	}
+}
@@ -7,6 +7,7 @@ import (
 // Parameters holds information about this game server's notifiable status.
 type Parameters struct {
 	PlayerCount int
+	MaxPlayers  int
 }
 
 // Provider provides the list of status parameters to notify external
@@ -27,7 +28,10 @@ func NewProvider(gameService *game.Service) *ProviderImpl {
 
 // Provide provides the list of status Parameters from other internal services.
 func (provider *ProviderImpl) Provide() (Parameters, error) {
-	parameters := &Parameters{}
+	parameters := &Parameters{
+		PlayerCount: provider.gameService.PlayerCount(),
+		MaxPlayers:  provider.gameService.MaxPlayers(),
+	}
 
 	return *parameters, nil
 }
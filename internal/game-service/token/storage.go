@@ -0,0 +1,240 @@
+package token
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis"
+	"gitlab.com/pokesync/game-service/internal/game-service/account"
+)
+
+// Store keeps track of the Token's that are issued and have yet to
+// expire or be revoked, by the id's of the Token's.
+type Store interface {
+	Put(id string, email account.Email, expireAfter time.Duration) error
+	Get(id string) (account.Email, bool, error)
+	Take(id string) (account.Email, bool, error)
+	Remove(id string, email account.Email) error
+	RemoveAll(email account.Email) error
+}
+
+// InMemoryStore is an in-memory implementation of a token Store where
+// issued tokens are forgotten about once the application's lifecycle ends.
+type InMemoryStore struct {
+	tokens map[string]storedToken
+	owners map[account.Email]map[string]bool
+	mutex  *sync.Mutex
+}
+
+// storedToken is an entry of an InMemoryStore.
+type storedToken struct {
+	email     account.Email
+	expiresAt time.Time
+}
+
+// RedisStore is a token Store that keeps track of issued tokens in a
+// connected Redis instance.
+type RedisStore struct {
+	redisClient *redis.Client
+}
+
+// NewInMemoryStore constructs a new instance of an InMemoryStore.
+func NewInMemoryStore() *InMemoryStore {
+	return &InMemoryStore{
+		tokens: make(map[string]storedToken),
+		owners: make(map[account.Email]map[string]bool),
+		mutex:  &sync.Mutex{},
+	}
+}
+
+// NewRedisStore constructs a new instance of a RedisStore.
+func NewRedisStore(redisClient *redis.Client) *RedisStore {
+	return &RedisStore{redisClient: redisClient}
+}
+
+// Put stores the id of an issued token for the account of the specified
+// Email, until the token expires.
+func (store *InMemoryStore) Put(id string, email account.Email, expireAfter time.Duration) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	store.tokens[id] = storedToken{email: email, expiresAt: time.Now().Add(expireAfter)}
+	if store.owners[email] == nil {
+		store.owners[email] = make(map[string]bool)
+	}
+
+	store.owners[email][id] = true
+	return nil
+}
+
+// Get looks up the Email of the account the token of the specified id was
+// issued for. Returns false if no such token is stored or if it expired.
+func (store *InMemoryStore) Get(id string) (account.Email, bool, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	token, exists := store.tokens[id]
+	if !exists {
+		return "", false, nil
+	}
+
+	if time.Now().After(token.expiresAt) {
+		delete(store.tokens, id)
+		delete(store.owners[token.email], id)
+
+		return "", false, nil
+	}
+
+	return token.email, true, nil
+}
+
+// Take removes the token of the specified id from the store, returning the
+// Email of the account it was issued for. Returns false if no such token
+// was stored or if it expired, which makes for only one of any concurrent
+// takes of the same token to succeed.
+func (store *InMemoryStore) Take(id string) (account.Email, bool, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	token, exists := store.tokens[id]
+	if !exists {
+		return "", false, nil
+	}
+
+	delete(store.tokens, id)
+	delete(store.owners[token.email], id)
+
+	if time.Now().After(token.expiresAt) {
+		return "", false, nil
+	}
+
+	return token.email, true, nil
+}
+
+// Remove removes the token of the specified id from the store.
+func (store *InMemoryStore) Remove(id string, email account.Email) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	delete(store.tokens, id)
+	delete(store.owners[email], id)
+
+	return nil
+}
+
+// RemoveAll removes every token that was issued for the account of the
+// specified Email, from the store.
+func (store *InMemoryStore) RemoveAll(email account.Email) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	for id := range store.owners[email] {
+		delete(store.tokens, id)
+	}
+
+	delete(store.owners, email)
+	return nil
+}
+
+// Put stores the id of an issued token for the account of the specified
+// Email, until the token expires.
+func (store *RedisStore) Put(id string, email account.Email, expireAfter time.Duration) error {
+	if err := store.redisClient.Set(createTokenKey(id), string(email), expireAfter).Err(); err != nil {
+		return err
+	}
+
+	ownerKey := createOwnerKey(email)
+	if err := store.redisClient.SAdd(ownerKey, id).Err(); err != nil {
+		return err
+	}
+
+	// the set of token id's of an account lives as long as its most
+	// recently issued token does
+	return store.redisClient.Expire(ownerKey, expireAfter).Err()
+}
+
+// Get looks up the Email of the account the token of the specified id was
+// issued for. Returns false if no such token is stored or if it expired.
+func (store *RedisStore) Get(id string) (account.Email, bool, error) {
+	email, err := store.redisClient.Get(createTokenKey(id)).Result()
+	if err == redis.Nil {
+		return "", false, nil
+	}
+
+	if err != nil {
+		return "", false, err
+	}
+
+	return account.Email(email), true, nil
+}
+
+// Take removes the token of the specified id from the store, returning the
+// Email of the account it was issued for. Returns false if no such token
+// was stored or if it expired. The token is looked up and deleted within
+// a single transaction, for only one of any concurrent takes of the same
+// token to succeed.
+func (store *RedisStore) Take(id string) (account.Email, bool, error) {
+	tokenKey := createTokenKey(id)
+
+	var get *redis.StringCmd
+	_, err := store.redisClient.TxPipelined(func(pipe redis.Pipeliner) error {
+		get = pipe.Get(tokenKey)
+		pipe.Del(tokenKey)
+
+		return nil
+	})
+
+	if err == redis.Nil {
+		return "", false, nil
+	}
+
+	if err != nil {
+		return "", false, err
+	}
+
+	email := account.Email(get.Val())
+	if err := store.redisClient.SRem(createOwnerKey(email), id).Err(); err != nil {
+		return "", false, err
+	}
+
+	return email, true, nil
+}
+
+// Remove removes the token of the specified id from the store.
+func (store *RedisStore) Remove(id string, email account.Email) error {
+	if err := store.redisClient.Del(createTokenKey(id)).Err(); err != nil {
+		return err
+	}
+
+	return store.redisClient.SRem(createOwnerKey(email), id).Err()
+}
+
+// RemoveAll removes every token that was issued for the account of the
+// specified Email, from the store.
+func (store *RedisStore) RemoveAll(email account.Email) error {
+	ownerKey := createOwnerKey(email)
+
+	ids, err := store.redisClient.SMembers(ownerKey).Result()
+	if err != nil {
+		return err
+	}
+
+	keys := []string{ownerKey}
+	for _, id := range ids {
+		keys = append(keys, createTokenKey(id))
+	}
+
+	return store.redisClient.Del(keys...).Err()
+}
+
+// createTokenKey creates a Redis key of the specified token id.
+func createTokenKey(id string) string {
+	return fmt.Sprint("session-token-", id)
+}
+
+// createOwnerKey creates a Redis key of the set of token id's that
+// were issued for the specified Email.
+func createOwnerKey(email account.Email) string {
+	return fmt.Sprint("session-tokens-", string(email))
+}
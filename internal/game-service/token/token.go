@@ -0,0 +1,207 @@
+package token
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"gitlab.com/pokesync/game-service/internal/game-service/account"
+)
+
+var (
+	// ErrMalformed is returned when a Token could not be decoded.
+	ErrMalformed = errors.New("session token is malformed")
+
+	// ErrInvalidSignature is returned when the signature of a Token does
+	// not match its contents.
+	ErrInvalidSignature = errors.New("session token has an invalid signature")
+
+	// ErrExpired is returned when a Token has passed its expiration.
+	ErrExpired = errors.New("session token has expired")
+
+	// ErrRevoked is returned when a Token has been revoked or was already
+	// used to resume a session with.
+	ErrRevoked = errors.New("session token has been revoked")
+)
+
+// Token is a signed and expiring credential that is handed to a user
+// upon a successful login, for the user to resume its session with
+// without having to authenticate with its e-mail and password again.
+type Token string
+
+// Config holds configurations specific to the Issuer.
+type Config struct {
+	Secret      []byte
+	ExpireAfter time.Duration
+}
+
+// Issuer issues, verifies and revokes Token's.
+type Issuer struct {
+	config Config
+	store  Store
+}
+
+// claims are the contents of a Token.
+type claims struct {
+	ID        string        `json:"id"`
+	Email     account.Email `json:"email"`
+	ExpiresAt int64         `json:"exp"`
+}
+
+// NewIssuer constructs a new instance of an Issuer that keeps track of
+// issued Token's using the given Store.
+func NewIssuer(config Config, store Store) *Issuer {
+	return &Issuer{config: config, store: store}
+}
+
+// Issue issues a new Token for the account of the specified Email.
+// May return an error.
+func (issuer *Issuer) Issue(email account.Email) (Token, error) {
+	id, err := generateID()
+	if err != nil {
+		return "", err
+	}
+
+	expiresAt := time.Now().Add(issuer.config.ExpireAfter)
+
+	payload, err := json.Marshal(claims{ID: id, Email: email, ExpiresAt: expiresAt.Unix()})
+	if err != nil {
+		return "", err
+	}
+
+	if err := issuer.store.Put(id, email, issuer.config.ExpireAfter); err != nil {
+		return "", err
+	}
+
+	encodedPayload := base64.RawURLEncoding.EncodeToString(payload)
+	signature := base64.RawURLEncoding.EncodeToString(issuer.sign(encodedPayload))
+
+	return Token(encodedPayload + "." + signature), nil
+}
+
+// Verify verifies the given Token, returning the Email of the account the
+// Token was issued for. Returns an error if the Token is malformed, has an
+// invalid signature, has expired or has been revoked.
+func (issuer *Issuer) Verify(token Token) (account.Email, error) {
+	c, err := issuer.decodeUnexpired(token)
+	if err != nil {
+		return "", err
+	}
+
+	email, exists, err := issuer.store.Get(c.ID)
+	if err != nil {
+		return "", err
+	}
+
+	if !exists || email != c.Email {
+		return "", ErrRevoked
+	}
+
+	return c.Email, nil
+}
+
+// Redeem verifies the given Token and revokes it in the same go, so that
+// each Token can only be used once to resume a session with, even when it
+// is redeemed more than once at the same time.
+func (issuer *Issuer) Redeem(token Token) (account.Email, error) {
+	c, err := issuer.decodeUnexpired(token)
+	if err != nil {
+		return "", err
+	}
+
+	email, taken, err := issuer.store.Take(c.ID)
+	if err != nil {
+		return "", err
+	}
+
+	if !taken || email != c.Email {
+		return "", ErrRevoked
+	}
+
+	return c.Email, nil
+}
+
+// Revoke revokes the given Token, which is to happen when the user logs out.
+func (issuer *Issuer) Revoke(token Token) error {
+	c, err := issuer.decode(token)
+	if err != nil {
+		return err
+	}
+
+	return issuer.store.Remove(c.ID, c.Email)
+}
+
+// RevokeAll revokes every Token that has been issued for the account of
+// the specified Email, which is to happen when the account is banned.
+func (issuer *Issuer) RevokeAll(email account.Email) error {
+	return issuer.store.RemoveAll(email)
+}
+
+// decodeUnexpired decodes the given Token into its claims, after verifying
+// its signature and that it has yet to expire.
+func (issuer *Issuer) decodeUnexpired(token Token) (claims, error) {
+	c, err := issuer.decode(token)
+	if err != nil {
+		return claims{}, err
+	}
+
+	if time.Now().After(time.Unix(c.ExpiresAt, 0)) {
+		return claims{}, ErrExpired
+	}
+
+	return c, nil
+}
+
+// decode decodes the given Token into its claims, after verifying its
+// signature.
+func (issuer *Issuer) decode(token Token) (claims, error) {
+	c := claims{}
+
+	parts := strings.Split(string(token), ".")
+	if len(parts) != 2 {
+		return c, ErrMalformed
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return c, ErrMalformed
+	}
+
+	if !hmac.Equal(signature, issuer.sign(parts[0])) {
+		return c, ErrInvalidSignature
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return c, ErrMalformed
+	}
+
+	if err := json.Unmarshal(payload, &c); err != nil {
+		return c, ErrMalformed
+	}
+
+	return c, nil
+}
+
+// sign computes the signature of the given encoded payload.
+func (issuer *Issuer) sign(encodedPayload string) []byte {
+	mac := hmac.New(sha256.New, issuer.config.Secret)
+	mac.Write([]byte(encodedPayload))
+	return mac.Sum(nil)
+}
+
+// generateID generates a random and unique identifier for a Token.
+func generateID() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(id), nil
+}
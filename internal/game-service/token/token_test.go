@@ -0,0 +1,113 @@
+package token
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"gitlab.com/pokesync/game-service/internal/game-service/account"
+)
+
+var config = Config{Secret: []byte("secret"), ExpireAfter: time.Minute}
+
+func TestIssuer_Verify(t *testing.T) {
+	issuer := NewIssuer(config, NewInMemoryStore())
+
+	token, err := issuer.Issue(account.Email("sino@gmail.com"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	email, err := issuer.Verify(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if email != account.Email("sino@gmail.com") {
+		t.Errorf("expected token to be issued for %v but was %v instead", "sino@gmail.com", email)
+	}
+}
+
+func TestIssuer_VerifyTampered(t *testing.T) {
+	issuer := NewIssuer(config, NewInMemoryStore())
+	forger := NewIssuer(Config{Secret: []byte("guessed"), ExpireAfter: time.Minute}, NewInMemoryStore())
+
+	token, _ := forger.Issue(account.Email("sino@gmail.com"))
+	if _, err := issuer.Verify(token); err != ErrInvalidSignature {
+		t.Errorf("expected error %v but was %v instead", ErrInvalidSignature, err)
+	}
+
+	if _, err := issuer.Verify(Token("garbage")); err != ErrMalformed {
+		t.Errorf("expected error %v but was %v instead", ErrMalformed, err)
+	}
+}
+
+func TestIssuer_VerifyExpired(t *testing.T) {
+	issuer := NewIssuer(Config{Secret: []byte("secret"), ExpireAfter: -time.Minute}, NewInMemoryStore())
+
+	token, _ := issuer.Issue(account.Email("sino@gmail.com"))
+	if _, err := issuer.Verify(token); err != ErrExpired {
+		t.Errorf("expected error %v but was %v instead", ErrExpired, err)
+	}
+}
+
+func TestIssuer_Redeem(t *testing.T) {
+	issuer := NewIssuer(config, NewInMemoryStore())
+
+	token, _ := issuer.Issue(account.Email("sino@gmail.com"))
+	if _, err := issuer.Redeem(token); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := issuer.Redeem(token); err != ErrRevoked {
+		t.Errorf("expected error %v but was %v instead", ErrRevoked, err)
+	}
+}
+
+func TestIssuer_RedeemConcurrently(t *testing.T) {
+	issuer := NewIssuer(config, NewInMemoryStore())
+	token, _ := issuer.Issue(account.Email("sino@gmail.com"))
+
+	var redeemed int32
+	var wg sync.WaitGroup
+
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if _, err := issuer.Redeem(token); err == nil {
+				atomic.AddInt32(&redeemed, 1)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if redeemed != 1 {
+		t.Errorf("expected the token to be redeemed %v time but was %v times", 1, redeemed)
+	}
+}
+
+func TestIssuer_RevokeAll(t *testing.T) {
+	issuer := NewIssuer(config, NewInMemoryStore())
+
+	token1, _ := issuer.Issue(account.Email("sino@gmail.com"))
+	token2, _ := issuer.Issue(account.Email("sino@gmail.com"))
+	token3, _ := issuer.Issue(account.Email("someone@gmail.com"))
+
+	if err := issuer.RevokeAll(account.Email("sino@gmail.com")); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, token := range []Token{token1, token2} {
+		if _, err := issuer.Verify(token); err != ErrRevoked {
+			t.Errorf("expected error %v but was %v instead", ErrRevoked, err)
+		}
+	}
+
+	if _, err := issuer.Verify(token3); err != nil {
+		t.Error(err)
+	}
+}
@@ -66,8 +66,18 @@ CREATE UNIQUE INDEX character_name ON character(display_name);
 CREATE INDEX character_owner ON character(account_id);
 
 CREATE TABLE monster (
-    id serial PRIMARY KEY
-    model_id smallint CHECK (model_id >= 0)
+    id serial PRIMARY KEY,
+    model_id smallint CHECK (model_id >= 0),
+    gender gender NOT NULL,
+    status_condition smallint NOT NULL DEFAULT 0 CHECK (status_condition >= 0),
+    coloration smallint NOT NULL DEFAULT 0 CHECK (coloration >= 0),
+    level smallint NOT NULL DEFAULT 1 CHECK (level BETWEEN 1 AND 100),
+    experience integer NOT NULL DEFAULT 0 CHECK (experience >= 0),
+    individual_values smallint[6] NOT NULL,
+    effort_values smallint[6] NOT NULL,
+    nature smallint NOT NULL CHECK (nature BETWEEN 0 AND 24),
+    hit_points smallint NOT NULL CHECK (hit_points >= 0),
+    happiness smallint NOT NULL CHECK (happiness BETWEEN 0 AND 255),
     nickname varchar (32),
     original_trainer varchar (32) NOT NULL
 );
@@ -89,4 +99,12 @@ CREATE TABLE party_entry (
     character_id integer REFERENCES character (id)
 );
 
-CREATE INDEX party_owner ON pc_entry(character_id);
\ No newline at end of file
+CREATE INDEX party_owner ON pc_entry(character_id);
+
+CREATE TABLE monster_move (
+    monster_id integer REFERENCES monster (id) ON DELETE CASCADE,
+    slot smallint CHECK (slot BETWEEN 0 AND 3),
+    move_id smallint NOT NULL CHECK (move_id >= 0),
+    pp smallint NOT NULL CHECK (pp >= 0),
+    PRIMARY KEY (monster_id, slot)
+);